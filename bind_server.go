@@ -0,0 +1,382 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	std_driver "database/sql/driver"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+	"github.com/supresu/clickhouse-go/v2/lib/timezone"
+)
+
+// bindServerRe matches the {name:Type} placeholders of the parameters bound by the server
+var bindServerRe = regexp.MustCompile(`\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*:\s*([^{}]+?)\s*\}`)
+
+// serverBinding reports whether the values are bound by the server: parameters were given with WithParameters,
+// or the query has {name:Type} placeholders and only named arguments
+func serverBinding(query string, o *QueryOptions, args []interface{}) bool {
+	if len(o.parameters) != 0 {
+		return true
+	}
+	if len(args) == 0 || !bindServerRe.MatchString(query) {
+		return false
+	}
+	for _, arg := range args {
+		if _, ok := arg.(driver.NamedValue); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// bind sends the values of the {name:Type} placeholders with the query when the server supports it,
+// older servers get the values as literals cast to the declared type
+func (c *connect) bind(query string, o *QueryOptions, args ...interface{}) (string, error) {
+	if !serverBinding(query, o, args) {
+		return bind(c.server.Timezone, query, args...)
+	}
+	if c.revision < proto.DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS {
+		return bindCast(c.server.Timezone, query, o, args)
+	}
+	params, err := bindParams(c.server.Timezone, query, o, args)
+	if err != nil {
+		return "", err
+	}
+	o.serverParams = params
+	return query, nil
+}
+
+// params returns the values of the placeholders in the order of their names
+func (o *QueryOptions) params() proto.Parameters {
+	params := make(proto.Parameters, 0, len(o.serverParams))
+	for name, v := range o.serverParams {
+		params = append(params, proto.Parameter{Key: name, Value: v})
+	}
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Key < params[j].Key
+	})
+	return params
+}
+
+// paramValues merges the parameters of WithParameters and the named arguments, the arguments win
+func paramValues(o *QueryOptions, args []interface{}) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(o.parameters)+len(args))
+	for name, v := range o.parameters {
+		values[name] = v
+	}
+	for _, arg := range args {
+		named, ok := arg.(driver.NamedValue)
+		if !ok {
+			return nil, ErrBindMixedParamsFormats
+		}
+		values[named.Name] = named.Value
+	}
+	return values, nil
+}
+
+// bindParams returns the values of the placeholders of the query in the text format of their declared type
+func bindParams(tz *time.Location, query string, o *QueryOptions, args []interface{}) (map[string]string, error) {
+	values, err := paramValues(o, args)
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]string)
+	for _, match := range bindServerRe.FindAllStringSubmatch(query, -1) {
+		name, chType := match[1], match[2]
+		if _, found := params[name]; found {
+			continue
+		}
+		v, found := values[name]
+		if !found {
+			return nil, fmt.Errorf("have no arg for {%s:%s} param", name, chType)
+		}
+		if params[name], err = formatParam(tz, chType, v, false); err != nil {
+			return nil, &OpError{
+				Op:  "bind",
+				Err: fmt.Errorf("{%s:%s}: %w", name, chType, err),
+			}
+		}
+	}
+	return params, nil
+}
+
+// bindCast replaces the placeholders by CAST(literal AS Type), for the servers that do not receive parameters
+func bindCast(tz *time.Location, query string, o *QueryOptions, args []interface{}) (string, error) {
+	values, err := paramValues(o, args)
+	if err != nil {
+		return "", err
+	}
+	query = bindServerRe.ReplaceAllStringFunc(query, func(placeholder string) string {
+		if err != nil {
+			return placeholder
+		}
+		var (
+			match        = bindServerRe.FindStringSubmatch(placeholder)
+			name, chType = match[1], match[2]
+			v, found     = values[name]
+			literal      string
+		)
+		if !found {
+			err = fmt.Errorf("have no arg for {%s:%s} param", name, chType)
+			return placeholder
+		}
+		if literal, err = formatParam(tz, chType, v, true); err != nil {
+			err = &OpError{
+				Op:  "bind",
+				Err: fmt.Errorf("{%s:%s}: %w", name, chType, err),
+			}
+			return placeholder
+		}
+		return "CAST(" + literal + " AS " + chType + ")"
+	})
+	if err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
+var (
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	escapeParam  = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`, "\x00", `\0`)
+	quoteParam   = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+)
+
+// formatParam returns v in the ClickHouse text format of chType. The whole value is in the escaped format
+// that the server parses the parameters with, the values inside arrays, maps and tuples are quoted
+func formatParam(tz *time.Location, chType string, v interface{}, quoted bool) (string, error) {
+	if valuer, ok := v.(std_driver.Valuer); ok {
+		var err error
+		if v, err = valuer.Value(); err != nil {
+			return "", err
+		}
+	}
+	for value := reflect.ValueOf(v); value.Kind() == reflect.Ptr; value = value.Elem() {
+		if value.IsNil() {
+			v = nil
+			break
+		}
+		if value.Type().Implements(stringerType) && !value.Type().Elem().Implements(stringerType) {
+			break // the String method of *big.Int and the like needs the pointer
+		}
+		v = value.Elem().Interface()
+	}
+	str := func(s string) string {
+		if quoted {
+			return "'" + quoteParam.Replace(s) + "'"
+		}
+		return escapeParam.Replace(s)
+	}
+	if v == nil {
+		if quoted {
+			return "NULL", nil
+		}
+		return `\N`, nil
+	}
+	name, args := paramType(chType)
+	switch name {
+	case "Nullable", "LowCardinality":
+		if len(args) == 1 {
+			return formatParam(tz, args[0], v, quoted)
+		}
+	case "Array":
+		value := reflect.ValueOf(v)
+		if len(args) != 1 || (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) {
+			break
+		}
+		items := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, err := formatParam(tz, args[0], value.Index(i).Interface(), true)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "[" + strings.Join(items, ",") + "]", nil
+	case "Map":
+		value := reflect.ValueOf(v)
+		if len(args) != 2 || value.Kind() != reflect.Map {
+			break
+		}
+		items := make([]string, 0, value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			key, err := formatParam(tz, args[0], iter.Key().Interface(), true)
+			if err != nil {
+				return "", err
+			}
+			item, err := formatParam(tz, args[1], iter.Value().Interface(), true)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, key+":"+item)
+		}
+		sort.Strings(items)
+		return "{" + strings.Join(items, ",") + "}", nil
+	case "Tuple":
+		value := reflect.ValueOf(v)
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			break
+		}
+		if value.Len() != len(args) {
+			return "", fmt.Errorf("expected %d tuple elements, not %d", len(args), value.Len())
+		}
+		items := make([]string, 0, len(args))
+		for i, element := range args {
+			// named elements: "name Type"
+			if parts := strings.SplitN(element, " ", 2); len(parts) == 2 && !strings.Contains(parts[0], "(") {
+				element = parts[1]
+			}
+			item, err := formatParam(tz, element, value.Index(i).Interface(), true)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "(" + strings.Join(items, ",") + ")", nil
+	case "Decimal", "Decimal32", "Decimal64", "Decimal128", "Decimal256":
+		if len(args) == 0 {
+			break
+		}
+		scale, err := strconv.Atoi(args[len(args)-1])
+		if err != nil {
+			return "", fmt.Errorf("invalid decimal scale: %w", err)
+		}
+		switch v := v.(type) {
+		case decimal.Decimal:
+			return v.StringFixed(int32(scale)), nil
+		case float32:
+			return decimal.NewFromFloat32(v).StringFixed(int32(scale)), nil
+		case float64:
+			return decimal.NewFromFloat(v).StringFixed(int32(scale)), nil
+		case string: // decimal.Decimal is a driver.Valuer
+			if d, err := decimal.NewFromString(v); err == nil {
+				return d.StringFixed(int32(scale)), nil
+			}
+			return v, nil
+		}
+	case "Date", "Date32":
+		if t, ok := v.(time.Time); ok {
+			return str(t.Format("2006-01-02")), nil
+		}
+	case "DateTime", "DateTime64":
+		t, ok := v.(time.Time)
+		if !ok {
+			break
+		}
+		layout, loc := "2006-01-02 15:04:05", tz
+		if name == "DateTime64" && len(args) != 0 {
+			precision, err := strconv.Atoi(args[0])
+			if err != nil || precision < 0 || precision > 9 {
+				return "", fmt.Errorf("invalid DateTime64 precision %q", args[0])
+			}
+			if precision != 0 {
+				layout += "." + strings.Repeat("0", precision)
+			}
+			args = args[1:]
+		}
+		if len(args) != 0 {
+			var err error
+			if loc, err = timezone.Load(strings.Trim(args[0], "'")); err != nil {
+				return "", err
+			}
+		}
+		if loc != nil {
+			t = t.In(loc)
+		}
+		return str(t.Format(layout)), nil
+	case "Bool":
+		if b, ok := v.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+	case "Int8", "Int16", "Int32", "Int64", "Int128", "Int256",
+		"UInt8", "UInt16", "UInt32", "UInt64", "UInt128", "UInt256",
+		"Float32", "Float64":
+		switch v := v.(type) {
+		case bool:
+			if v {
+				return "1", nil
+			}
+			return "0", nil
+		case string:
+			return v, nil
+		case *big.Int:
+			return v.String(), nil
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		return str(v), nil
+	case []byte:
+		return str(string(v)), nil
+	case time.Time:
+		return str(v.Format("2006-01-02 15:04:05")), nil
+	case fmt.Stringer:
+		return str(v.String()), nil
+	}
+	switch value := reflect.ValueOf(v); value.Kind() {
+	case reflect.String:
+		return str(value.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("converting %T to %s is unsupported", v, chType)
+}
+
+// paramType splits a type into its name and arguments: Map(String, Array(UInt8)) is Map and [String, Array(UInt8)]
+func paramType(chType string) (name string, args []string) {
+	chType = strings.TrimSpace(chType)
+	open := strings.IndexByte(chType, '(')
+	if open == -1 || !strings.HasSuffix(chType, ")") {
+		return chType, nil
+	}
+	var (
+		start    int
+		brackets int
+		inQuote  bool
+		body     = chType[open+1 : len(chType)-1]
+	)
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case inQuote && c == '\\':
+			i++
+		case c == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '(':
+			brackets++
+		case c == ')':
+			brackets--
+		case c == ',' && brackets == 0:
+			args, start = append(args, strings.TrimSpace(body[start:i])), i+1
+		}
+	}
+	return strings.TrimSpace(chType[:open]), append(args, strings.TrimSpace(body[start:]))
+}
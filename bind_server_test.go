@@ -0,0 +1,152 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatParam(t *testing.T) {
+	var (
+		berlin, _ = time.LoadLocation("Europe/Berlin")
+		ts        = time.Date(2022, 5, 10, 12, 30, 15, 123456789, time.UTC)
+		name      = "name"
+	)
+	for _, test := range []struct {
+		chType   string
+		value    interface{}
+		expected string
+	}{
+		{"UInt64", uint64(42), "42"},
+		{"Int8", -1, "-1"},
+		{"UInt8", true, "1"},
+		{"Float64", 1.5, "1.5"},
+		{"Bool", true, "true"},
+		{"String", "it's a\ttab \\ and\nnewline", `it's a\ttab \\ and\nnewline`},
+		{"FixedString(4)", []byte("abcd"), "abcd"},
+		{"LowCardinality(String)", &name, "name"},
+		{"Nullable(String)", nil, `\N`},
+		{"Nullable(UInt8)", (*uint8)(nil), `\N`},
+		{"UUID", uuid.MustParse("3e5a3b3c-0d4c-4c2a-9b4b-3a4e5f6a7b8c"), "3e5a3b3c-0d4c-4c2a-9b4b-3a4e5f6a7b8c"},
+		{"IPv4", net.ParseIP("127.0.0.1"), "127.0.0.1"},
+		{"Date", ts, "2022-05-10"},
+		{"DateTime", ts, "2022-05-10 12:30:15"},
+		{"DateTime('Europe/Berlin')", ts, "2022-05-10 14:30:15"},
+		{"DateTime64(3)", ts, "2022-05-10 12:30:15.123"},
+		{"DateTime64(6, 'Europe/Berlin')", ts.In(berlin), "2022-05-10 14:30:15.123456"},
+		{"Int256", big.NewInt(-42), "-42"},
+		{"Nullable(UInt128)", new(big.Int).Lsh(big.NewInt(1), 100), "1267650600228229401496703205376"},
+		{"Array(Int128)", []*big.Int{big.NewInt(1), nil}, "[1,NULL]"},
+		{"Decimal(9, 2)", decimal.RequireFromString("3.14159"), "3.14"},
+		{"Decimal64(4)", 2.5, "2.5000"},
+		{"Decimal128(2)", 10, "10"},
+		{"Array(String)", []string{"a", "it's"}, `['a','it\'s']`},
+		{"Array(Nullable(UInt8))", []interface{}{uint8(1), nil}, "[1,NULL]"},
+		{"Array(Array(UInt8))", [][]uint8{{1, 2}, {}}, "[[1,2],[]]"},
+		{"Array(DateTime)", []time.Time{ts}, "['2022-05-10 12:30:15']"},
+		{"Map(String, UInt64)", map[string]uint64{"b": 2, "a": 1}, "{'a':1,'b':2}"},
+		{"Map(String, Array(String))", map[string][]string{"k": {"v"}}, "{'k':['v']}"},
+		{"Tuple(String, UInt8)", []interface{}{"a", uint8(1)}, "('a',1)"},
+		{"Tuple(name String, ts DateTime64(3, 'UTC'))", []interface{}{"a", ts}, "('a','2022-05-10 12:30:15.123')"},
+	} {
+		actual, err := formatParam(time.UTC, test.chType, test.value, false)
+		if assert.NoError(t, err, test.chType) {
+			assert.Equal(t, test.expected, actual, test.chType)
+		}
+	}
+	_, err := formatParam(time.UTC, "Tuple(String, UInt8)", []interface{}{"a"}, false)
+	assert.Error(t, err)
+	_, err = formatParam(time.UTC, "UInt8", struct{}{}, false)
+	assert.Error(t, err)
+}
+
+func TestParamType(t *testing.T) {
+	for chType, expected := range map[string][]string{
+		"String":                            nil,
+		"Array(String)":                     {"String"},
+		"Map(String, Array(Tuple(a, b)))":   {"String", "Array(Tuple(a, b))"},
+		"DateTime64(3, 'Europe/Berlin')":    {"3", "'Europe/Berlin'"},
+		"Enum8('a,b' = 1, 'it\\'s)' = 2)":   {"'a,b' = 1", "'it\\'s)' = 2"},
+		"Tuple(a String, b Nullable(Int8))": {"a String", "b Nullable(Int8)"},
+	} {
+		_, args := paramType(chType)
+		assert.Equal(t, expected, args, chType)
+	}
+}
+
+func TestServerBinding(t *testing.T) {
+	var (
+		empty  QueryOptions
+		params = QueryOptions{parameters: Parameters{"id": 1}}
+	)
+	assert.True(t, serverBinding("SELECT {id:UInt64}", &empty, []interface{}{Named("id", 1)}))
+	assert.True(t, serverBinding("SELECT {id:UInt64}", &params, nil))
+	assert.False(t, serverBinding("SELECT {id:UInt64}", &empty, nil), "the value may come from SET param_id")
+	assert.False(t, serverBinding("SELECT @id", &empty, []interface{}{Named("id", 1)}))
+	assert.False(t, serverBinding("SELECT '{a:b}', ?", &empty, []interface{}{1}))
+
+	values, err := bindParams(time.UTC, "SELECT {id:UInt64}, {name:String}, {id:UInt64}", &params, []interface{}{Named("name", "a\tb")})
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"id": "1", "name": `a\tb`}, values)
+	}
+	values, err = bindParams(time.UTC, "SELECT {x:Int256}", &empty, []interface{}{Named("x", new(big.Int).Lsh(big.NewInt(-1), 200))})
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"x": new(big.Int).Lsh(big.NewInt(-1), 200).String()}, values)
+	}
+	_, err = bindParams(time.UTC, "SELECT {missing:UInt64}", &params, nil)
+	assert.Error(t, err)
+	_, err = bindParams(time.UTC, "SELECT {id:UInt64}", &params, []interface{}{1})
+	assert.ErrorIs(t, err, ErrBindMixedParamsFormats)
+}
+
+func TestBindCast(t *testing.T) {
+	query, err := bindCast(time.UTC, "SELECT {id:UInt64}, {names:Array(String)}, {n:Nullable(UInt8)}", &QueryOptions{}, []interface{}{
+		Named("id", 1),
+		Named("names", []string{"it's"}),
+		Named("n", nil),
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, `SELECT CAST(1 AS UInt64), CAST(['it\'s'] AS Array(String)), CAST(NULL AS Nullable(UInt8))`, query)
+	}
+	_, err = bindCast(time.UTC, "SELECT {id:UInt64}", &QueryOptions{}, nil)
+	assert.Error(t, err)
+}
+
+func TestHTTPServerParams(t *testing.T) {
+	standIn, conn := openHTTP(t, nil)
+	ctx := Context(context.Background(), WithParameters(Parameters{
+		"names": []string{"a", "b"},
+	}))
+	rows, err := conn.Query(ctx, "SELECT number FROM t WHERE id = {id:UInt64} AND name IN {names:Array(String)}", Named("id", 42))
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	require.NoError(t, rows.Err())
+	params := standIn.requests[len(standIn.requests)-1].URL.Query()
+	assert.Equal(t, "42", params.Get("param_id"))
+	assert.Equal(t, "['a','b']", params.Get("param_names"))
+}
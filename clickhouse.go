@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -33,17 +34,22 @@ import (
 type Conn = driver.Conn
 
 type (
-	Progress      = proto.Progress
-	Exception     = proto.Exception
-	ProfileInfo   = proto.ProfileInfo
-	ServerVersion = proto.ServerHandshake
+	Progress          = proto.Progress
+	Exception         = proto.Exception
+	ProfileInfo       = proto.ProfileInfo
+	ServerVersion     = proto.ServerHandshake
+	ColumnDescription = proto.ColumnDescription
 )
 
 var (
 	ErrBatchAlreadySent          = errors.New("clickhouse: batch has already been sent")
 	ErrAcquireConnTimeout        = errors.New("clickhouse: acquire conn timeout. you can increase the number of max open conn or the dial timeout")
 	ErrUnsupportedServerRevision = errors.New("clickhouse: unsupported server revision")
+	ErrSSHAuthenticationRevision = errors.New("clickhouse: SSH key authentication needs the protocol revision 54466")
 	ErrBindMixedParamsFormats    = errors.New("clickhouse [bind]: mixed named, numeric or positional parameters")
+	// ErrEndOfStream is returned when the server ends a query before it sends a block,
+	// the connection is healthy and the statement is not run again by the RetryPolicy
+	ErrEndOfStream = errors.New("clickhouse: end of stream before the first block")
 )
 
 type OpError struct {
@@ -72,21 +78,43 @@ func (e *OpError) Error() string {
 
 func Open(opt *Options) (driver.Conn, error) {
 	opt.setDefaults()
-	return &clickhouse{
-		opt:  opt,
-		idle: make(chan *connect, opt.MaxIdleConns),
-		open: make(chan struct{}, opt.MaxOpenConns),
-	}, nil
+	ch := &clickhouse{
+		opt:   opt,
+		idle:  make(chan *connect, opt.MaxIdleConns),
+		open:  make(chan struct{}, opt.MaxOpenConns),
+		exit:  make(chan struct{}),
+		done:  make(chan struct{}),
+		hosts: newHostSet(opt),
+	}
+	switch opt.Protocol {
+	case Native:
+		go ch.startIdleReaper()
+	case HTTP:
+		if opt.Auth.SSHKey != nil {
+			return nil, fmt.Errorf("clickhouse: SSH key authentication is only supported by the native protocol")
+		}
+		close(ch.done)
+		ch.http = dialHttp(opt, ch.hosts)
+	default:
+		return nil, fmt.Errorf("clickhouse: unsupported protocol %s", opt.Protocol)
+	}
+	return ch, nil
 }
 
 type clickhouse struct {
-	opt    *Options
-	idle   chan *connect
-	open   chan struct{}
-	connID int64
+	stats     poolStats // first for the 64-bit alignment of its counters
+	opt       *Options
+	idle      chan *connect
+	open      chan struct{}
+	exit      chan struct{} // closed by Close to stop the idle reaper
+	done      chan struct{} // closed once the idle reaper has stopped
+	closeOnce sync.Once
+	hosts     *hostSet
+	http      *httpConnect // only set with the HTTP protocol, the native pool is unused then
+	connID    int64
 }
 
-func (clickhouse) Contributors() []string {
+func (*clickhouse) Contributors() []string {
 	list := contributors.List
 	if len(list[len(list)-1]) == 0 {
 		return list[:len(list)-1]
@@ -94,56 +122,117 @@ func (clickhouse) Contributors() []string {
 	return list
 }
 
-func (ch *clickhouse) ServerVersion() (*driver.ServerVersion, error) {
-	var (
-		ctx, cancel = context.WithTimeout(context.Background(), ch.opt.DialTimeout)
-		conn, err   = ch.acquire(ctx)
-	)
+func (ch *clickhouse) ServerVersion() (version *driver.ServerVersion, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ch.opt.DialTimeout)
 	defer cancel()
+	err = ch.retry(ctx, func() error {
+		if ch.http != nil {
+			version, err = ch.http.serverVersion(ctx)
+			return err
+		}
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		ch.release(conn, nil)
+		version = &conn.server
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	ch.release(conn, nil)
-	return &conn.server, nil
+	return version, nil
 }
 
 func (ch *clickhouse) Query(ctx context.Context, query string, args ...interface{}) (rows driver.Rows, err error) {
-	conn, err := ch.acquire(ctx)
+	err = ch.retry(ctx, func() error {
+		if ch.http != nil {
+			r, err := ch.http.query(ctx, query, args...)
+			if err != nil {
+				return err
+			}
+			rows = r
+			return nil
+		}
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		r, err := conn.query(ctx, ch.release, query, args...)
+		if err != nil {
+			return ch.failed(ctx, conn, err)
+		}
+		rows = r
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return conn.query(ctx, ch.release, query, args...)
+	return rows, nil
 }
 
 func (ch *clickhouse) QueryRow(ctx context.Context, query string, args ...interface{}) (rows driver.Row) {
-	conn, err := ch.acquire(ctx)
-	if err != nil {
-		return &row{
-			err: err,
+	var r *row
+	ch.retry(ctx, func() error {
+		if ch.http != nil {
+			r = ch.http.queryRow(ctx, query, args...)
+			return r.err
 		}
-	}
-	return conn.queryRow(ctx, ch.release, query, args...)
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			r = &row{
+				err: err,
+			}
+			return err
+		}
+		if r = conn.queryRow(ctx, ch.release, query, args...); r.err != nil {
+			return ch.failed(ctx, conn, r.err)
+		}
+		return nil
+	})
+	return r
 }
 
 func (ch *clickhouse) Exec(ctx context.Context, query string, args ...interface{}) error {
-	conn, err := ch.acquire(ctx)
-	if err != nil {
-		return err
-	}
-	if err := conn.exec(ctx, query, args...); err != nil {
-		ch.release(conn, err)
-		return err
-	}
-	ch.release(conn, nil)
-	return nil
+	return ch.retry(ctx, func() error {
+		if ch.http != nil {
+			return ch.http.exec(ctx, query, args...)
+		}
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if err := conn.exec(ctx, query, args...); err != nil {
+			ch.release(conn, err)
+			return ch.failed(ctx, conn, err)
+		}
+		ch.release(conn, nil)
+		return nil
+	})
 }
 
-func (ch *clickhouse) PrepareBatch(ctx context.Context, query string) (driver.Batch, error) {
-	conn, err := ch.acquire(ctx)
-	if err != nil {
-		return nil, err
-	}
-	batch, err := conn.prepareBatch(ctx, query, ch.release)
+// PrepareBatch is retried until the batch is prepared, sending it is never retried
+func (ch *clickhouse) PrepareBatch(ctx context.Context, query string) (batch driver.Batch, err error) {
+	err = ch.retry(ctx, func() error {
+		if ch.http != nil {
+			b, err := ch.http.prepareBatch(ctx, query)
+			if err != nil {
+				return err
+			}
+			batch = b
+			return nil
+		}
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		b, err := conn.prepareBatch(ctx, query, ch.release)
+		if err != nil {
+			return ch.failed(ctx, conn, err)
+		}
+		batch = b
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -151,51 +240,63 @@ func (ch *clickhouse) PrepareBatch(ctx context.Context, query string) (driver.Ba
 }
 
 func (ch *clickhouse) AsyncInsert(ctx context.Context, query string, wait bool) error {
-	conn, err := ch.acquire(ctx)
-	if err != nil {
-		return err
-	}
-	if err := conn.asyncInsert(ctx, query, wait); err != nil {
-		ch.release(conn, err)
-		return err
-	}
-	ch.release(conn, nil)
-	return nil
+	return ch.retry(ctx, func() error {
+		if ch.http != nil {
+			return ch.http.asyncInsert(ctx, query, wait)
+		}
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if err := conn.asyncInsert(ctx, query, wait); err != nil {
+			ch.release(conn, err)
+			return ch.failed(ctx, conn, err)
+		}
+		ch.release(conn, nil)
+		return nil
+	})
 }
 
 func (ch *clickhouse) Ping(ctx context.Context) (err error) {
-	conn, err := ch.acquire(ctx)
-	if err != nil {
-		return err
-	}
-	if err := conn.ping(ctx); err != nil {
-		ch.release(conn, err)
-		return err
+	return ch.retry(ctx, func() error {
+		if ch.http != nil {
+			return ch.http.ping(ctx)
+		}
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if err := conn.ping(ctx); err != nil {
+			ch.release(conn, err)
+			return ch.failed(ctx, conn, err)
+		}
+		ch.release(conn, nil)
+		return nil
+	})
+}
+
+// failed counts a network failure against the host of the connection, unless it was caused by the context
+func (ch *clickhouse) failed(ctx context.Context, conn *connect, err error) error {
+	if conn.host != nil && ctx.Err() == nil && isNetworkError(err) {
+		ch.hosts.dialed(conn.host, err)
 	}
-	ch.release(conn, nil)
-	return nil
+	return err
 }
 
 func (ch *clickhouse) Stats() driver.Stats {
-	return driver.Stats{
+	stats := driver.Stats{
 		Open:         len(ch.open),
 		Idle:         len(ch.idle),
 		MaxOpenConns: cap(ch.open),
 		MaxIdleConns: cap(ch.idle),
 	}
+	ch.stats.fill(&stats)
+	stats.Hosts = ch.hosts.stats()
+	return stats
 }
 
 func (ch *clickhouse) dial(ctx context.Context) (conn *connect, err error) {
-	connID := int(atomic.AddInt64(&ch.connID, 1))
-	for num := range ch.opt.Addr {
-		if ch.opt.ConnOpenStrategy == ConnOpenRoundRobin {
-			num = int(connID) % len(ch.opt.Addr)
-		}
-		if conn, err = dial(ctx, ch.opt.Addr[num], connID, ch.opt); err == nil {
-			return conn, nil
-		}
-	}
-	return nil, err
+	return ch.hosts.dial(ctx, int(atomic.AddInt64(&ch.connID, 1)), &ch.stats)
 }
 
 func (ch *clickhouse) acquire(ctx context.Context) (conn *connect, err error) {
@@ -207,15 +308,22 @@ func (ch *clickhouse) acquire(ctx context.Context) (conn *connect, err error) {
 	default:
 	}
 	select {
-	case <-timer.C:
-		return nil, ErrAcquireConnTimeout
 	case ch.open <- struct{}{}:
+	default:
+		start := time.Now()
+		select {
+		case <-timer.C:
+			ch.stats.wait(start)
+			return nil, ErrAcquireConnTimeout
+		case ch.open <- struct{}{}:
+			ch.stats.wait(start)
+		}
 	}
 	select {
 	case <-timer.C:
 		return nil, ErrAcquireConnTimeout
 	case conn := <-ch.idle:
-		if conn.isBad() {
+		if ch.expired(conn) || ch.bad(conn) || ch.hosts.down(conn.host) {
 			conn.close()
 			if conn, err = ch.dial(ctx); err != nil {
 				select {
@@ -244,14 +352,42 @@ func (ch *clickhouse) release(conn *connect, err error) {
 		return
 	}
 	conn.released = true
-	select {
-	case <-ch.open:
-	default:
+	if conn.cancelled && !conn.closed {
+		// drain the cancelled query in the background so that the caller gets the context error right away,
+		// the connection keeps its open slot until then
+		go func() {
+			ch.put(conn, conn.drain())
+		}()
+		return
 	}
-	if err != nil || time.Since(conn.connectedAt) >= ch.opt.ConnMaxLifetime {
+	ch.put(conn, err)
+}
+
+// put returns the connection to the idle list before freeing its open slot,
+// so that a caller waiting for the slot finds the connection
+func (ch *clickhouse) put(conn *connect, err error) {
+	defer func() {
+		select {
+		case <-ch.open:
+		default:
+		}
+	}()
+	if err != nil || conn.closed {
+		atomic.AddInt64(&ch.stats.errorClosed, 1)
 		conn.close()
 		return
 	}
+	if ch.lifetimeExpired(conn) {
+		conn.close()
+		return
+	}
+	select {
+	case <-ch.exit:
+		conn.close()
+		return
+	default:
+	}
+	conn.lastUsedIn = time.Now()
 	select {
 	case ch.idle <- conn:
 	default:
@@ -260,6 +396,13 @@ func (ch *clickhouse) release(conn *connect, err error) {
 }
 
 func (ch *clickhouse) Close() error {
+	ch.closeOnce.Do(func() {
+		close(ch.exit)
+	})
+	<-ch.done
+	if ch.http != nil {
+		return ch.http.close()
+	}
 	for {
 		select {
 		case c := <-ch.idle:
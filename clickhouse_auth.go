@@ -0,0 +1,105 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sshKeyMarker prefixes the username of the hello to ask for the SSH challenge, see EncodedUserInfo in the ClickHouse sources
+const sshKeyMarker = " SSH KEY AUTHENTICATION "
+
+// LoadSSHKey reads a private key in the OpenSSH or PEM format for Auth.SSHKey, passphrase is only used by encrypted keys
+func LoadSSHKey(path, passphrase string) (crypto.Signer, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var key interface{}
+	switch {
+	case len(passphrase) != 0:
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(data, []byte(passphrase))
+	default:
+		key, err = ssh.ParseRawPrivateKey(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: SSH key %s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("clickhouse: SSH key %s: unsupported key type %T", path, key)
+	}
+	return signer, nil
+}
+
+// sshSign signs the message with the key, the signature is the SSH wire format of the signature.
+// RSA keys sign with SHA-512 since the SHA-1 signatures are usually disabled by the server.
+func sshSign(key crypto.Signer, message []byte) ([]byte, error) {
+	signer, err := ssh.NewSignerFromSigner(key)
+	if err != nil {
+		return nil, err
+	}
+	var signature *ssh.Signature
+	switch s, ok := signer.(ssh.AlgorithmSigner); {
+	case ok && signer.PublicKey().Type() == ssh.KeyAlgoRSA:
+		signature, err = s.SignWithAlgorithm(rand.Reader, message, ssh.SigAlgoRSASHA2512)
+	default:
+		signature, err = signer.Sign(rand.Reader, message)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ssh.Marshal(signature), nil
+}
+
+// clientCertificate reports whether the TLS config gives a client certificate, which authenticates
+// the user in place of the password
+func clientCertificate(config *tls.Config) bool {
+	return config != nil && (len(config.Certificates) != 0 || config.GetClientCertificate != nil)
+}
+
+// loadTLS adds the client certificate and the root CA read from files to config
+func loadTLS(config *tls.Config, certFile, keyFile, caFile string) error {
+	if len(certFile) != 0 || len(keyFile) != 0 {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		config.Certificates = append(config.Certificates, cert)
+	}
+	if len(caFile) != 0 {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return err
+		}
+		if config.RootCAs == nil {
+			config.RootCAs = x509.NewCertPool()
+		}
+		if !config.RootCAs.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificate found in %s", caFile)
+		}
+	}
+	return nil
+}
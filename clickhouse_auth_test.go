@@ -0,0 +1,298 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/clickhousetest"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHKeyAuth(t *testing.T) {
+	var (
+		_, ed25519Key, _ = ed25519.GenerateKey(rand.Reader)
+		rsaKey, _        = rsa.GenerateKey(rand.Reader, 2048)
+		ecdsaKey, _      = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		keys             = map[string]crypto.Signer{"ed25519": ed25519Key, "rsa": rsaKey, "ecdsa": ecdsaKey}
+		config           = clickhousetest.Config{
+			Users:   map[string]string{},
+			SSHKeys: make(map[string]ssh.PublicKey),
+		}
+	)
+	for name, key := range keys {
+		public, err := ssh.NewPublicKey(key.Public())
+		require.NoError(t, err)
+		config.SSHKeys[name] = public
+	}
+	srv, err := clickhousetest.NewServer(config)
+	require.NoError(t, err)
+	defer srv.Close()
+	ping := func(username string, key crypto.Signer) error {
+		conn, err := Open(&Options{
+			Addr: []string{srv.Addr()},
+			Auth: Auth{Username: username, SSHKey: key},
+		})
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.Ping(context.Background())
+	}
+	for name, key := range keys {
+		assert.NoError(t, ping(name, key), name)
+	}
+	assert.ErrorIs(t, ping("rsa", ed25519Key), ErrAuthenticationFailed)
+	assert.ErrorIs(t, ping("unknown", ed25519Key), ErrAuthenticationFailed)
+
+	_, err = Open(&Options{Protocol: HTTP, Auth: Auth{SSHKey: ed25519Key}})
+	assert.Error(t, err)
+}
+
+// testCertificate returns a certificate for 127.0.0.1 with the common name, signed by the parent or self-signed
+func testCertificate(t *testing.T, name string, parent *tls.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	var (
+		issuer                = template
+		issuerKey interface{} = key
+	)
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+	} else {
+		issuer, issuerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func writePEM(t *testing.T, path, kind string, der []byte) {
+	require.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0600))
+}
+
+func TestTLSClientCertificateAuth(t *testing.T) {
+	var (
+		ca     = testCertificate(t, "ca", nil)
+		server = testCertificate(t, "server", &ca)
+		client = testCertificate(t, "default", &ca)
+		roots  = x509.NewCertPool()
+	)
+	roots.AddCert(ca.Leaf)
+	srv, err := clickhousetest.NewServer(clickhousetest.Config{
+		Users: map[string]string{"default": "secret"},
+		TLS: &tls.Config{
+			Certificates: []tls.Certificate{server},
+			ClientAuth:   tls.VerifyClientCertIfGiven,
+			ClientCAs:    roots,
+		},
+	})
+	require.NoError(t, err)
+	defer srv.Close()
+	ping := func(opt *Options) error {
+		conn, err := Open(opt)
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.Ping(context.Background())
+	}
+	assert.NoError(t, ping(&Options{
+		Addr: []string{srv.Addr()},
+		TLS:  &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{client}},
+	}))
+	assert.ErrorIs(t, ping(&Options{
+		Addr: []string{srv.Addr()},
+		TLS:  &tls.Config{RootCAs: roots},
+	}), ErrAuthenticationFailed, "no certificate and no password")
+
+	// the same from the files of the DSN
+	dir := t.TempDir()
+	clientKey, err := x509.MarshalPKCS8PrivateKey(client.PrivateKey)
+	require.NoError(t, err)
+	_, sshKey, _ := ed25519.GenerateKey(rand.Reader)
+	sshDER, err := x509.MarshalPKCS8PrivateKey(sshKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "client.crt"), "CERTIFICATE", client.Certificate[0])
+	writePEM(t, filepath.Join(dir, "client.key"), "PRIVATE KEY", clientKey)
+	writePEM(t, filepath.Join(dir, "ca.crt"), "CERTIFICATE", ca.Certificate[0])
+	writePEM(t, filepath.Join(dir, "id_ed25519"), "PRIVATE KEY", sshDER)
+	params := url.Values{}
+	params.Set("tls_cert_file", filepath.Join(dir, "client.crt"))
+	params.Set("tls_key_file", filepath.Join(dir, "client.key"))
+	params.Set("tls_ca_file", filepath.Join(dir, "ca.crt"))
+	opt, err := ParseDSN("clickhouse://" + srv.Addr() + "?" + params.Encode())
+	require.NoError(t, err)
+	if assert.NotNil(t, opt.TLS) {
+		assert.Len(t, opt.TLS.Certificates, 1)
+	}
+	assert.NoError(t, ping(opt))
+
+	params = url.Values{}
+	params.Set("ssh_key_file", filepath.Join(dir, "id_ed25519"))
+	opt, err = ParseDSN("clickhouse://127.0.0.1:9000?" + params.Encode())
+	require.NoError(t, err)
+	if assert.NotNil(t, opt.Auth.SSHKey) {
+		assert.Equal(t, sshKey.Public(), opt.Auth.SSHKey.Public())
+	}
+	params.Set("ssh_key_file", filepath.Join(dir, "missing"))
+	_, err = ParseDSN("clickhouse://127.0.0.1:9000?" + params.Encode())
+	assert.Error(t, err)
+}
+
+// rotatingAuth hands out the password it was given until it is refreshed, as a cached secret would
+type rotatingAuth struct {
+	mu       sync.Mutex
+	calls    int
+	password string
+	current  func() string
+}
+
+func (r *rotatingAuth) provide(ctx context.Context, addr string) (Auth, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.calls++; r.calls > 1 {
+		r.password = r.current()
+	}
+	return Auth{Password: r.password}, nil
+}
+
+func (r *rotatingAuth) reset() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := r.calls
+	r.calls = 0
+	return calls
+}
+
+func TestAuthProvider(t *testing.T) {
+	srv, err := clickhousetest.NewServer(clickhousetest.Config{
+		Users: map[string]string{"user": "first"},
+	})
+	require.NoError(t, err)
+	defer srv.Close()
+	var (
+		ctx      = context.Background()
+		password = "second"
+		provider = &rotatingAuth{
+			password: "first",
+			current:  func() string { return password },
+		}
+		opt = func() *Options {
+			return &Options{
+				Addr:         []string{srv.Addr()},
+				Auth:         Auth{Username: "user"},
+				AuthProvider: provider.provide,
+			}
+		}
+	)
+	conn, err := Open(opt())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.Ping(ctx))
+	assert.Equal(t, 1, provider.reset())
+
+	srv.SetPassword("user", "second")
+	srv.DropConnections()
+	assert.NoError(t, conn.Ping(ctx))
+	assert.Equal(t, 2, provider.reset(), "the rejected password is refreshed once")
+
+	srv.DropConnections()
+	srv.SetPassword("user", "third")
+	provider.password = "second"
+	assert.ErrorIs(t, conn.Ping(ctx), ErrAuthenticationFailed)
+	assert.Equal(t, 2, provider.reset(), "a refreshed password is not retried")
+
+	password = "third"
+	provider.password = "second"
+	db := OpenDB(opt())
+	defer db.Close()
+	assert.NoError(t, db.PingContext(ctx))
+	assert.Equal(t, 2, provider.reset())
+
+	failing := opt()
+	failing.AuthProvider = func(ctx context.Context, addr string) (Auth, error) {
+		return Auth{}, errors.New("vault sealed")
+	}
+	conn, err = Open(failing)
+	require.NoError(t, err)
+	assert.EqualError(t, conn.Ping(ctx), "clickhouse: auth provider: vault sealed")
+}
+
+func TestHTTPAuthProvider(t *testing.T) {
+	var (
+		standIn = &httpStandIn{password: "second"}
+		server  = httptest.NewServer(standIn)
+		ctx     = context.Background()
+	)
+	defer server.Close()
+	provider := &rotatingAuth{
+		password: "first",
+		current:  func() string { return "second" },
+	}
+	conn, err := Open(&Options{
+		Protocol:     HTTP,
+		Addr:         []string{strings.TrimPrefix(server.URL, "http://")},
+		Auth:         Auth{Database: "db", Username: "user"},
+		AuthProvider: provider.provide,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.Ping(ctx))
+	assert.Equal(t, 2, provider.reset())
+	if assert.Len(t, standIn.requests, 2) {
+		for i, password := range []string{"first", "second"} {
+			assert.Equal(t, "user", standIn.requests[i].Header.Get("X-ClickHouse-User"))
+			assert.Equal(t, password, standIn.requests[i].Header.Get("X-ClickHouse-Key"))
+		}
+	}
+
+	standIn.password = "third"
+	assert.ErrorIs(t, conn.Ping(ctx), ErrAuthenticationFailed)
+	assert.Equal(t, 2, provider.reset())
+}
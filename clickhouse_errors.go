@@ -0,0 +1,138 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+// ErrorCode is the code of a server exception, the proto.ErrCode constants name all of them and
+// can be given to errors.Is: errors.Is(err, proto.ErrCodeUnknownTable)
+type ErrorCode = proto.ErrorCode
+
+// Sentinel errors of the common server exceptions, errors.Is(err, ErrTableNotFound) matches an
+// Exception with the code, or one of its nested exceptions
+var (
+	ErrTableNotFound              error = proto.ErrCodeUnknownTable
+	ErrDatabaseNotFound           error = proto.ErrCodeUnknownDatabase
+	ErrUnknownIdentifier          error = proto.ErrCodeUnknownIdentifier
+	ErrTableAlreadyExists         error = proto.ErrCodeTableAlreadyExists
+	ErrDatabaseAlreadyExists      error = proto.ErrCodeDatabaseAlreadyExists
+	ErrSyntax                     error = proto.ErrCodeSyntaxError
+	ErrUnknownSetting             error = proto.ErrCodeUnknownSetting
+	ErrReadOnly                   error = proto.ErrCodeReadonly
+	ErrAccessDenied               error = proto.ErrCodeAccessDenied
+	ErrAuthenticationFailed       error = proto.ErrCodeAuthenticationFailed
+	ErrTimeoutExceeded            error = proto.ErrCodeTimeoutExceeded
+	ErrQuotaExceeded              error = proto.ErrCodeQuotaExpired
+	ErrTooManySimultaneousQueries error = proto.ErrCodeTooManySimultaneousQueries
+	ErrMemoryLimitExceeded        error = proto.ErrCodeMemoryLimitExceeded
+	ErrQueryWasCancelled          error = proto.ErrCodeQueryWasCancelled
+)
+
+var (
+	// the query is wrong, running it again gives the same exception
+	userErrorCodes = []int32{
+		int32(proto.ErrCodeCannotParseText),
+		int32(proto.ErrCodeThereIsNoColumn),
+		int32(proto.ErrCodeNoSuchColumnInTable),
+		int32(proto.ErrCodeCannotParseInputAssertionFailed),
+		int32(proto.ErrCodeBadArguments),
+		int32(proto.ErrCodeCannotParseDatetime),
+		int32(proto.ErrCodeNumberOfArgumentsDoesntMatch),
+		int32(proto.ErrCodeIllegalTypeOfArgument),
+		int32(proto.ErrCodeIllegalColumn),
+		int32(proto.ErrCodeUnknownFunction),
+		int32(proto.ErrCodeUnknownIdentifier),
+		int32(proto.ErrCodeUnknownType),
+		int32(proto.ErrCodeTypeMismatch),
+		int32(proto.ErrCodeTableAlreadyExists),
+		int32(proto.ErrCodeUnknownTable),
+		int32(proto.ErrCodeSyntaxError),
+		int32(proto.ErrCodeArgumentOutOfBound),
+		int32(proto.ErrCodeCannotConvertType),
+		int32(proto.ErrCodeCannotParseNumber),
+		int32(proto.ErrCodeUnknownDatabase),
+		int32(proto.ErrCodeDatabaseAlreadyExists),
+		int32(proto.ErrCodeUnknownSetting),
+		int32(proto.ErrCodeIncorrectData),
+		int32(proto.ErrCodeReadonly),
+		int32(proto.ErrCodeAccessDenied),
+	}
+	// a quota or a limit of the user, of the query or of the server was reached
+	quotaCodes = []int32{
+		int32(proto.ErrCodeTooManyRows),
+		int32(proto.ErrCodeQuotaExpired),
+		int32(proto.ErrCodeTooManySimultaneousQueries),
+		int32(proto.ErrCodeMemoryLimitExceeded),
+		int32(proto.ErrCodeTooManyBytes),
+		int32(proto.ErrCodeTooManyRowsOrBytes),
+	}
+	timeoutCodes = []int32{
+		int32(proto.ErrCodeTimeoutExceeded),
+		int32(proto.ErrCodeTooSlow),
+		int32(proto.ErrCodeSocketTimeout),
+	}
+)
+
+// IsRetryable reports whether err is a transient failure: a network error, ErrAcquireConnTimeout
+// or an exception with one of the RetryableCodes
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrAcquireConnTimeout) || isNetworkError(err) || hasCode(err, RetryableCodes)
+}
+
+// IsUserError reports whether err is an exception caused by the query itself, such as a syntax error,
+// an unknown table or a type mismatch, that fails again when it is retried
+func IsUserError(err error) bool {
+	return hasCode(err, userErrorCodes)
+}
+
+// IsQuotaExceeded reports whether err is an exception of an exceeded quota or limit,
+// rows, bytes, memory or simultaneous queries
+func IsQuotaExceeded(err error) bool {
+	return hasCode(err, quotaCodes)
+}
+
+// IsTimeout reports whether err is a server timeout, a network timeout, an expired context deadline or ErrAcquireConnTimeout
+func IsTimeout(err error) bool {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, ErrAcquireConnTimeout),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	}
+	return hasCode(err, timeoutCodes)
+}
+
+// hasCode reports whether err is an exception, or has a nested exception, with one of the codes
+func hasCode(err error, codes []int32) bool {
+	if err == nil {
+		return false
+	}
+	for _, code := range codes {
+		if errors.Is(err, proto.ErrorCode(code)) {
+			return true
+		}
+	}
+	return false
+}
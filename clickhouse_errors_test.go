@@ -0,0 +1,64 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	err := fmt.Errorf("query: %w", &Exception{Code: 60, Message: "table default.t doesn't exist"})
+	assert.True(t, errors.Is(err, ErrTableNotFound))
+	assert.False(t, errors.Is(err, ErrDatabaseNotFound))
+	assert.False(t, errors.Is(errors.New("unknown table"), ErrTableNotFound))
+}
+
+func TestErrorClassification(t *testing.T) {
+	nested := &Exception{
+		Code:   1000, // POCO_EXCEPTION
+		Nested: []Exception{{Code: 241}},
+	}
+	for _, test := range []struct {
+		err                                  error
+		retryable, user, quota, timeoutError bool
+	}{
+		{err: &Exception{Code: 62}, user: true},
+		{err: &Exception{Code: 60}, user: true},
+		{err: &Exception{Code: 202}, retryable: true, quota: true},
+		{err: &Exception{Code: 159}, retryable: true, timeoutError: true},
+		{err: &Exception{Code: 160}, timeoutError: true},
+		{err: nested, quota: true},
+		{err: io.EOF, retryable: true},
+		{err: ErrAcquireConnTimeout, retryable: true, timeoutError: true},
+		{err: context.DeadlineExceeded, timeoutError: true},
+		{err: ErrBindMixedParamsFormats},
+		{err: nil},
+	} {
+		name := fmt.Sprint(test.err)
+		assert.Equal(t, test.retryable, IsRetryable(test.err), name)
+		assert.Equal(t, test.user, IsUserError(test.err), name)
+		assert.Equal(t, test.quota, IsQuotaExceeded(test.err), name)
+		assert.Equal(t, test.timeoutError, IsTimeout(test.err), name)
+	}
+}
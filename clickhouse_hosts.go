@@ -0,0 +1,209 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+)
+
+// hostSet orders the addresses to dial according to the ConnOpenStrategy and keeps
+// the circuit breaker state of each host
+type hostSet struct {
+	opt   *Options
+	mu    sync.Mutex
+	hosts []*host // in the order of Options.Addr
+	rand  *rand.Rand
+}
+
+type host struct {
+	addr     string
+	priority int
+	open     int
+	failures int
+	state    driver.HostState
+	retryAt  time.Time
+	lastErr  error
+}
+
+func newHostSet(opt *Options) *hostSet {
+	s := hostSet{
+		opt:   opt,
+		hosts: make([]*host, 0, len(opt.Addr)),
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, addr := range opt.Addr {
+		s.hosts = append(s.hosts, &host{
+			addr:     addr,
+			priority: opt.AddrPriority[addr],
+		})
+	}
+	return &s
+}
+
+// order returns the hosts to try for the num-th connection. Down hosts come last, soonest to recover first,
+// and a down host whose backoff has elapsed is given to a single caller as a probe.
+func (s *hostSet) order(num int) []*host {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		now       = time.Now()
+		available = make([]*host, 0, len(s.hosts))
+		down      []*host
+	)
+	for _, h := range s.hosts {
+		switch {
+		case h.state == driver.HostUp:
+			available = append(available, h)
+		case now.Before(h.retryAt):
+			down = append(down, h)
+		default:
+			// the probe has to complete within the dial timeout before another caller can probe
+			h.state, h.retryAt = driver.HostProbing, now.Add(s.opt.DialTimeout)
+			available = append(available, h)
+		}
+	}
+	rotate := func(hosts []*host) {
+		if len(hosts) == 0 {
+			return
+		}
+		n := num % len(hosts)
+		rotated := append(hosts[n:len(hosts):len(hosts)], hosts[:n]...)
+		copy(hosts, rotated)
+	}
+	switch s.opt.ConnOpenStrategy {
+	case ConnOpenRoundRobin:
+		rotate(available)
+	case ConnOpenRandom:
+		s.rand.Shuffle(len(available), func(i, j int) {
+			available[i], available[j] = available[j], available[i]
+		})
+	case ConnOpenLeastOpen:
+		sort.SliceStable(available, func(i, j int) bool {
+			return available[i].open < available[j].open
+		})
+	case ConnOpenPriority:
+		sort.SliceStable(available, func(i, j int) bool {
+			return available[i].priority < available[j].priority
+		})
+		for start := 0; start < len(available); {
+			end := start + 1
+			for end < len(available) && available[end].priority == available[start].priority {
+				end++
+			}
+			rotate(available[start:end])
+			start = end
+		}
+	}
+	sort.SliceStable(down, func(i, j int) bool {
+		return down[i].retryAt.Before(down[j].retryAt)
+	})
+	return append(available, down...)
+}
+
+// dial opens a connection to the first host that accepts it, the traffic is counted into stats unless it is nil
+func (s *hostSet) dial(ctx context.Context, num int, stats *poolStats) (conn *connect, err error) {
+	for _, h := range s.order(num) {
+		conn, err = dial(ctx, h.addr, num, s.opt, stats)
+		stats.dial(h.addr, err)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+		s.dialed(h, err)
+		if err == nil {
+			h := h
+			conn.host = h
+			s.mu.Lock()
+			h.open++
+			s.mu.Unlock()
+			conn.onClose = func() {
+				s.closed(h)
+			}
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// dialed records the result of a dial or a network failure on a connection to h, a server exception means that the host
+// is alive, as does an error of the options that fails the connections to every host
+func (s *hostSet) dialed(h *host, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var exception *Exception
+	if err == nil || errors.As(err, &exception) || errors.Is(err, ErrSSHAuthenticationRevision) {
+		h.state, h.failures, h.lastErr = driver.HostUp, 0, nil
+		return
+	}
+	h.failures++
+	h.lastErr = err
+	breaker := s.opt.HostBreaker
+	if breaker.Threshold < 0 || h.failures < breaker.Threshold {
+		if h.state == driver.HostProbing {
+			h.state = driver.HostUp
+		}
+		return
+	}
+	backoff := breaker.Backoff
+	for i := breaker.Threshold; i < h.failures && backoff < breaker.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > breaker.MaxBackoff {
+		backoff = breaker.MaxBackoff
+	}
+	h.state, h.retryAt = driver.HostDown, time.Now().Add(backoff)
+}
+
+// down reports whether the host is skipped by the breaker, h may be nil
+func (s *hostSet) down(h *host) bool {
+	if h == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return h.state == driver.HostDown
+}
+
+// closed is called once for each connection opened to h
+func (s *hostSet) closed(h *host) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h.open--
+}
+
+func (s *hostSet) stats() map[string]driver.HostStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make(map[string]driver.HostStats, len(s.hosts))
+	for _, h := range s.hosts {
+		stats[h.addr] = driver.HostStats{
+			State:     h.state,
+			Open:      h.open,
+			Failures:  h.failures,
+			LastError: h.lastErr,
+			RetryAt:   h.retryAt,
+		}
+	}
+	return stats
+}
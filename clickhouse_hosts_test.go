@@ -0,0 +1,193 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+func addrs(hosts []*host) []string {
+	list := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		list = append(list, h.addr)
+	}
+	return list
+}
+
+func TestHostSetOrder(t *testing.T) {
+	opt := Options{
+		Addr:         []string{"a", "b", "c", "d"},
+		AddrPriority: map[string]int{"a": 1, "c": 1, "d": 2},
+	}
+	opt.setDefaults()
+	hosts := newHostSet(&opt)
+	hosts.hosts[0].open, hosts.hosts[1].open, hosts.hosts[2].open, hosts.hosts[3].open = 3, 2, 0, 2
+	for strategy, expected := range map[ConnOpenStrategy][]string{
+		ConnOpenInOrder:    {"a", "b", "c", "d"},
+		ConnOpenRoundRobin: {"b", "c", "d", "a"},
+		ConnOpenLeastOpen:  {"c", "b", "d", "a"},
+		ConnOpenPriority:   {"b", "c", "a", "d"},
+	} {
+		opt.ConnOpenStrategy = strategy
+		assert.Equal(t, expected, addrs(hosts.order(1)), "strategy %d", strategy)
+	}
+	opt.ConnOpenStrategy = ConnOpenRandom
+	assert.ElementsMatch(t, opt.Addr, addrs(hosts.order(1)))
+	// down hosts come last, the soonest to recover first
+	opt.ConnOpenStrategy = ConnOpenInOrder
+	hosts.hosts[0].state, hosts.hosts[0].retryAt = driver.HostDown, time.Now().Add(time.Minute)
+	hosts.hosts[1].state, hosts.hosts[1].retryAt = driver.HostDown, time.Now().Add(time.Second)
+	assert.Equal(t, []string{"c", "d", "b", "a"}, addrs(hosts.order(1)))
+}
+
+func TestHostBreakerOptionsError(t *testing.T) {
+	opt := Options{Addr: []string{"a"}}
+	opt.setDefaults()
+	hosts := newHostSet(&opt)
+	// the error is the one of the options, the host is not to blame
+	hosts.dialed(hosts.hosts[0], fmt.Errorf("handshake: %w", ErrSSHAuthenticationRevision))
+	assert.Equal(t, driver.HostUp, hosts.hosts[0].state)
+	assert.Zero(t, hosts.hosts[0].failures)
+	hosts.dialed(hosts.hosts[0], errors.New("connection refused"))
+	assert.Equal(t, driver.HostDown, hosts.hosts[0].state)
+}
+
+func TestHostPriorityDSN(t *testing.T) {
+	opt, err := ParseDSN("clickhouse://a:9000,b:9000,c:9000?connection_open_strategy=priority&addr_priority=1,0,1")
+	require.NoError(t, err)
+	assert.Equal(t, ConnOpenPriority, opt.ConnOpenStrategy)
+	assert.Equal(t, map[string]int{"a:9000": 1, "b:9000": 0, "c:9000": 1}, opt.AddrPriority)
+	opt.setDefaults()
+	assert.Equal(t, []string{"b:9000", "a:9000", "c:9000"}, addrs(newHostSet(opt).order(0)))
+
+	_, err = ParseDSN("clickhouse://a:9000,b:9000?addr_priority=1")
+	assert.EqualError(t, err, "clickhouse [dsn parse]: addr priority: 1 priorities for 2 hosts")
+	_, err = ParseDSN("clickhouse://a:9000,b:9000?addr_priority=1,first")
+	assert.Error(t, err)
+}
+
+// standInServer accepts native connections and answers the handshake, then it answers pings
+// or drops the connection on the first one
+func standInServer(t *testing.T, pong bool) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				buf := make([]byte, 1024)
+				if _, err := conn.Read(buf); err != nil {
+					return
+				}
+				encoder := binary.NewEncoder(conn)
+				encoder.Byte(proto.ServerHello)
+				encoder.String("ClickHouse")
+				encoder.Uvarint(22)
+				encoder.Uvarint(3)
+				encoder.Uvarint(proto.DBMS_MIN_REVISION_WITH_CLIENT_INFO)
+				encoder.Flush()
+				for {
+					n, err := conn.Read(buf)
+					if err != nil {
+						return
+					}
+					for _, b := range buf[:n] {
+						if b == proto.ClientPing {
+							if !pong {
+								return
+							}
+							encoder.Byte(proto.ServerPong)
+							encoder.Flush()
+						}
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestHostBreaker(t *testing.T) {
+	refused, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	refused.Close()
+	hanging, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer hanging.Close()
+	healthy := standInServer(t, true)
+	opt := Options{
+		Addr:        []string{refused.Addr().String(), hanging.Addr().String(), healthy},
+		DialTimeout: 200 * time.Millisecond,
+		HostBreaker: HostBreaker{
+			Backoff: 300 * time.Millisecond,
+		},
+	}
+	opt.setDefaults()
+	var (
+		ctx   = context.Background()
+		hosts = newHostSet(&opt)
+		start = time.Now()
+	)
+	conn, err := hosts.dial(ctx, 1, nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.ping(ctx))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(opt.DialTimeout))
+	stats := hosts.stats()
+	assert.Equal(t, driver.HostDown, stats[opt.Addr[0]].State)
+	assert.Equal(t, driver.HostDown, stats[opt.Addr[1]].State)
+	assert.Error(t, stats[opt.Addr[1]].LastError)
+	assert.Equal(t, driver.HostUp, stats[healthy].State)
+	assert.Equal(t, 1, stats[healthy].Open)
+	// the down hosts are skipped until their backoff elapses
+	start = time.Now()
+	second, err := hosts.dial(ctx, 2, nil)
+	require.NoError(t, err)
+	assert.Less(t, int64(time.Since(start)), int64(opt.DialTimeout))
+	assert.Equal(t, 2, hosts.stats()[healthy].Open)
+	conn.close()
+	second.close()
+	assert.Equal(t, 0, hosts.stats()[healthy].Open)
+	// then they are probed, a failed probe doubles the backoff
+	time.Sleep(opt.HostBreaker.Backoff)
+	probed := time.Now()
+	conn, err = hosts.dial(ctx, 3, nil)
+	require.NoError(t, err)
+	defer conn.close()
+	stats = hosts.stats()
+	for _, addr := range opt.Addr[:2] {
+		assert.Equal(t, driver.HostDown, stats[addr].State)
+		assert.Equal(t, 2, stats[addr].Failures)
+		assert.True(t, stats[addr].RetryAt.After(probed.Add(opt.HostBreaker.Backoff)))
+	}
+}
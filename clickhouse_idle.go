@@ -0,0 +1,144 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	minReapInterval = 100 * time.Millisecond
+	maxReapInterval = time.Minute
+)
+
+// lifetimeExpired reports whether the connection is older than ConnMaxLifetime
+func (ch *clickhouse) lifetimeExpired(conn *connect) bool {
+	if time.Since(conn.connectedAt) >= ch.opt.ConnMaxLifetime {
+		atomic.AddInt64(&ch.stats.maxLifetimeClosed, 1)
+		return true
+	}
+	return false
+}
+
+// expired reports whether the idle connection must be closed, either because of
+// ConnMaxIdleTime or ConnMaxLifetime
+func (ch *clickhouse) expired(conn *connect) bool {
+	if ch.opt.ConnMaxIdleTime > 0 && time.Since(conn.lastUsedIn) >= ch.opt.ConnMaxIdleTime {
+		atomic.AddInt64(&ch.stats.maxIdleTimeClosed, 1)
+		return true
+	}
+	return ch.lifetimeExpired(conn)
+}
+
+func (ch *clickhouse) bad(conn *connect) bool {
+	if conn.isBad() {
+		atomic.AddInt64(&ch.stats.badClosed, 1)
+		return true
+	}
+	return false
+}
+
+// reapInterval is how often the idle connections are checked, a fraction of the shortest
+// configured duration so that a connection does not outlive its limit by much
+func (ch *clickhouse) reapInterval() time.Duration {
+	interval := maxReapInterval
+	for _, d := range []time.Duration{ch.opt.ConnMaxIdleTime / 2, ch.opt.ConnPingInterval / 2, ch.opt.ConnMaxLifetime / 2} {
+		if d > 0 && d < interval {
+			interval = d
+		}
+	}
+	if interval < minReapInterval {
+		return minReapInterval
+	}
+	return interval
+}
+
+// startIdleReaper closes expired idle connections and pings the others until Close is called
+func (ch *clickhouse) startIdleReaper() {
+	defer close(ch.done)
+	ticker := time.NewTicker(ch.reapInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ch.exit:
+			return
+		case <-ticker.C:
+			ch.reapIdle()
+		}
+	}
+}
+
+// reapIdle takes each connection that is idle right now out of the pool once
+func (ch *clickhouse) reapIdle() {
+	for n := len(ch.idle); n > 0; n-- {
+		var conn *connect
+		select {
+		case conn = <-ch.idle:
+		default:
+			return
+		}
+		if ch.expired(conn) {
+			conn.debugf("[idle] close expired connection")
+			conn.close()
+			continue
+		}
+		if ch.pingDue(conn) {
+			if err := ch.keepAlive(conn); err != nil {
+				conn.debugf("[idle] keep-alive ping failed: %v", err)
+				atomic.AddInt64(&ch.stats.keepAliveFailures, 1)
+				conn.close()
+				continue
+			}
+		}
+		select {
+		case <-ch.exit:
+			conn.close()
+			return
+		default:
+		}
+		select {
+		case ch.idle <- conn:
+		default:
+			conn.close()
+		}
+	}
+}
+
+func (ch *clickhouse) pingDue(conn *connect) bool {
+	if ch.opt.ConnPingInterval <= 0 {
+		return false
+	}
+	last := conn.lastUsedIn
+	if conn.lastPingIn.After(last) {
+		last = conn.lastPingIn
+	}
+	return time.Since(last) >= ch.opt.ConnPingInterval
+}
+
+func (ch *clickhouse) keepAlive(conn *connect) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ch.opt.DialTimeout)
+	defer cancel()
+	atomic.AddInt64(&ch.stats.keepAlivePings, 1)
+	if err := conn.ping(ctx); err != nil {
+		return err
+	}
+	conn.lastPingIn = time.Now()
+	return nil
+}
@@ -19,6 +19,7 @@ package clickhouse
 
 import (
 	"context"
+	"crypto"
 	"crypto/tls"
 	"fmt"
 	"net"
@@ -30,25 +31,62 @@ import (
 	"github.com/supresu/clickhouse-go/v2/lib/compress"
 )
 
-var CompressionLZ4 compress.Method = compress.LZ4
+var (
+	CompressionNone = compress.NONE
+	CompressionLZ4  = compress.LZ4
+	CompressionZSTD = compress.ZSTD
+)
 
 type Auth struct { // has_control_character
 	Database string
 	Username string
 	Password string
+	// SSHKey authenticates the user with the SSH challenge of the server in place of the password, native protocol only.
+	// The server takes SSH keys from the protocol revision 54466 (DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION).
+	// The user can also be authenticated by the client certificate of Options.TLS, with an empty password.
+	SSHKey crypto.Signer
 }
 
 type Compression struct {
 	Method compress.Method
 }
 
+type Protocol int
+
+const (
+	Native Protocol = iota
+	HTTP
+)
+
+func (p Protocol) String() string {
+	switch p {
+	case Native:
+		return "native"
+	case HTTP:
+		return "http"
+	}
+	return fmt.Sprintf("unknown protocol %d", int(p))
+}
+
 type ConnOpenStrategy uint8
 
 const (
 	ConnOpenInOrder ConnOpenStrategy = iota
 	ConnOpenRoundRobin
+	ConnOpenRandom
+	ConnOpenLeastOpen // the host with the fewest open connections, in order on ties
+	ConnOpenPriority  // round robin over the hosts with the lowest AddrPriority, the next group when they are down
 )
 
+// HostBreaker stops dialing a host after consecutive failures. The host is probed again after Backoff,
+// which doubles on each failed probe up to MaxBackoff. When every host is down they are still tried,
+// soonest to recover first.
+type HostBreaker struct {
+	Threshold  int           // consecutive failures that mark a host down, default 1, negative disables the breaker
+	Backoff    time.Duration // default 1 second
+	MaxBackoff time.Duration // default 1 minute
+}
+
 func ParseDSN(dsn string) (*Options, error) {
 	opt := &Options{}
 	if err := opt.fromDSN(dsn); err != nil {
@@ -58,6 +96,7 @@ func ParseDSN(dsn string) (*Options, error) {
 }
 
 type Options struct {
+	Protocol         Protocol // default Native
 	TLS              *tls.Config
 	Addr             []string
 	Auth             Auth
@@ -66,11 +105,26 @@ type Options struct {
 	Debugf           func(format string, v ...interface{}) // only works when Debug is true
 	Settings         Settings
 	Compression      *Compression
+	CompressionLevel int           // level of the ZSTD compression, default 1
+	SkipChecksum     bool          // the checksums of the compressed blocks are not verified, for benchmarking only
 	DialTimeout      time.Duration // default 1 second
 	MaxOpenConns     int           // default MaxIdleConns + 5
 	MaxIdleConns     int           // default 5
 	ConnMaxLifetime  time.Duration // default 1 hour
+	ConnMaxIdleTime  time.Duration // idle connections are closed after this duration, no limit by default
+	ConnPingInterval time.Duration // idle connections are pinged at this interval, disabled by default
 	ConnOpenStrategy ConnOpenStrategy
+	AddrPriority     map[string]int // priority group of the addresses with ConnOpenPriority, lower first, default 0
+	HostBreaker      HostBreaker
+	RetryPolicy      *RetryPolicy // nil disables retries, see WithRetry for a single query
+	ServerLogs       *ServerLogs  // server logs of every query, see WithServerLogs for a single query
+	// AuthProvider gives the credentials of each new connection to addr (of each request over HTTP) in place of Auth,
+	// the empty Database and Username are taken from Auth. It is called again once when the server rejects the credentials.
+	AuthProvider func(ctx context.Context, addr string) (Auth, error)
+	// Proxy tunnels the connections of both protocols through a socks5, socks5h, http or https (CONNECT) proxy, TLS runs
+	// over the tunnel. It is not used with DialContext.
+	Proxy                *url.URL
+	ProxyFromEnvironment bool // the proxy of HTTPS_PROXY or ALL_PROXY when Proxy is nil, except for NO_PROXY
 }
 
 func (o *Options) fromDSN(in string) error {
@@ -86,10 +140,24 @@ func (o *Options) fromDSN(in string) error {
 		o.Auth.Password, _ = dsn.User.Password()
 	}
 	o.Addr = append(o.Addr, strings.Split(dsn.Host, ",")...)
+	switch dsn.Scheme {
+	case "http", "https":
+		o.Protocol = HTTP
+	}
 	var (
-		secure     bool
-		params     = dsn.Query()
-		skipVerify bool
+		secure       bool
+		params       = dsn.Query()
+		skipVerify   bool
+		addrPriority []string
+		sshKey       struct {
+			file       string
+			passphrase string
+		}
+		tlsFiles struct {
+			cert string
+			key  string
+			ca   string
+		}
 	)
 	o.Auth.Database = strings.TrimPrefix(dsn.Path, "/")
 	for v := range params {
@@ -108,11 +176,36 @@ func (o *Options) fromDSN(in string) error {
 			}
 		case "debug":
 			o.Debug, _ = strconv.ParseBool(params.Get(v))
+		case "skip_checksum":
+			o.SkipChecksum, _ = strconv.ParseBool(params.Get(v))
 		case "compress":
-			if on, _ := strconv.ParseBool(params.Get(v)); on {
+			switch method := strings.ToLower(params.Get(v)); method {
+			case "lz4":
 				o.Compression = &Compression{
 					Method: CompressionLZ4,
 				}
+			case "zstd":
+				o.Compression = &Compression{
+					Method: CompressionZSTD,
+				}
+			case "none":
+				o.Compression = &Compression{
+					Method: CompressionNone,
+				}
+			default:
+				on, err := strconv.ParseBool(method)
+				if err != nil {
+					return fmt.Errorf("clickhouse [dsn parse]: compress: unsupported compression method %q", method)
+				}
+				if on {
+					o.Compression = &Compression{
+						Method: CompressionLZ4,
+					}
+				}
+			}
+		case "compress_level":
+			if o.CompressionLevel, err = strconv.Atoi(params.Get(v)); err != nil {
+				return fmt.Errorf("clickhouse [dsn parse]: compress level: %s", err)
 			}
 		case "dial_timeout":
 			duration, err := time.ParseDuration(params.Get(v))
@@ -124,13 +217,42 @@ func (o *Options) fromDSN(in string) error {
 			secure = true
 		case "skip_verify":
 			skipVerify = true
+		case "ssh_key_file":
+			sshKey.file = params.Get(v)
+		case "ssh_key_passphrase":
+			sshKey.passphrase = params.Get(v)
+		case "tls_cert_file":
+			tlsFiles.cert = params.Get(v)
+		case "tls_key_file":
+			tlsFiles.key = params.Get(v)
+		case "tls_ca_file":
+			tlsFiles.ca = params.Get(v)
+		case "proxy":
+			if o.Proxy, err = url.Parse(params.Get(v)); err != nil {
+				return fmt.Errorf("clickhouse [dsn parse]: proxy: %s", err)
+			}
+			switch o.Proxy.Scheme {
+			case "socks5", "socks5h", "http", "https":
+			default:
+				return fmt.Errorf("clickhouse [dsn parse]: proxy: unsupported scheme %q", o.Proxy.Scheme)
+			}
+		case "proxy_from_environment":
+			o.ProxyFromEnvironment, _ = strconv.ParseBool(params.Get(v))
 		case "connection_open_strategy":
 			switch params.Get(v) {
 			case "in_order":
 				o.ConnOpenStrategy = ConnOpenInOrder
 			case "round_robin":
 				o.ConnOpenStrategy = ConnOpenRoundRobin
+			case "random":
+				o.ConnOpenStrategy = ConnOpenRandom
+			case "least_open":
+				o.ConnOpenStrategy = ConnOpenLeastOpen
+			case "priority":
+				o.ConnOpenStrategy = ConnOpenPriority
 			}
+		case "addr_priority":
+			addrPriority = strings.Split(params.Get(v), ",")
 		default:
 			switch p := strings.ToLower(params.Get(v)); p {
 			case "true":
@@ -144,14 +266,60 @@ func (o *Options) fromDSN(in string) error {
 			}
 		}
 	}
+	if len(addrPriority) != 0 {
+		// one priority for each host of the DSN, in the same order
+		hosts := strings.Split(dsn.Host, ",")
+		if len(addrPriority) != len(hosts) {
+			return fmt.Errorf("clickhouse [dsn parse]: addr priority: %d priorities for %d hosts", len(addrPriority), len(hosts))
+		}
+		if o.AddrPriority == nil {
+			o.AddrPriority = make(map[string]int, len(hosts))
+		}
+		for i, host := range hosts {
+			priority, err := strconv.Atoi(strings.TrimSpace(addrPriority[i]))
+			if err != nil {
+				return fmt.Errorf("clickhouse [dsn parse]: addr priority: %s", err)
+			}
+			o.AddrPriority[host] = priority
+		}
+	}
+	if len(sshKey.file) != 0 {
+		if o.Auth.SSHKey, err = LoadSSHKey(sshKey.file, sshKey.passphrase); err != nil {
+			return fmt.Errorf("clickhouse [dsn parse]: ssh key: %s", err)
+		}
+	}
+	if dsn.Scheme == "https" || len(tlsFiles.cert) != 0 || len(tlsFiles.ca) != 0 {
+		secure = true
+	}
 	if secure {
 		o.TLS = &tls.Config{
 			InsecureSkipVerify: skipVerify,
 		}
+		if err := loadTLS(o.TLS, tlsFiles.cert, tlsFiles.key, tlsFiles.ca); err != nil {
+			return fmt.Errorf("clickhouse [dsn parse]: tls: %s", err)
+		}
 	}
 	return nil
 }
 
+// auth returns the credentials of a connection to addr
+func (o *Options) auth(ctx context.Context, addr string) (Auth, error) {
+	if o.AuthProvider == nil {
+		return o.Auth, nil
+	}
+	auth, err := o.AuthProvider(ctx, addr)
+	if err != nil {
+		return Auth{}, fmt.Errorf("clickhouse: auth provider: %w", err)
+	}
+	if len(auth.Database) == 0 {
+		auth.Database = o.Auth.Database
+	}
+	if len(auth.Username) == 0 {
+		auth.Username = o.Auth.Username
+	}
+	return auth, nil
+}
+
 func (o *Options) setDefaults() {
 	if len(o.Auth.Database) == 0 {
 		o.Auth.Database = "default"
@@ -171,4 +339,13 @@ func (o *Options) setDefaults() {
 	if o.ConnMaxLifetime == 0 {
 		o.ConnMaxLifetime = time.Hour
 	}
+	if o.HostBreaker.Threshold == 0 {
+		o.HostBreaker.Threshold = 1
+	}
+	if o.HostBreaker.Backoff <= 0 {
+		o.HostBreaker.Backoff = time.Second
+	}
+	if o.HostBreaker.MaxBackoff <= 0 {
+		o.HostBreaker.MaxBackoff = time.Minute
+	}
 }
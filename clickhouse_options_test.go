@@ -0,0 +1,51 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+func TestCompressionSettings(t *testing.T) {
+	opt, err := ParseDSN("clickhouse://127.0.0.1:9000?compress=zstd&compress_level=3")
+	require.NoError(t, err)
+	assert.Equal(t, &Compression{CompressionZSTD}, opt.Compression)
+	assert.Equal(t, 3, opt.CompressionLevel)
+	assert.False(t, opt.SkipChecksum)
+	skipping, err := ParseDSN("clickhouse://127.0.0.1:9000?compress=lz4&skip_checksum=true")
+	require.NoError(t, err)
+	assert.True(t, skipping.SkipChecksum)
+	c := connect{
+		opt:         opt,
+		revision:    proto.DBMS_TCP_PROTOCOL_VERSION,
+		compression: true,
+	}
+	assert.Equal(t, []proto.Setting{
+		{Key: "network_compression_method", Value: "ZSTD"},
+		{Key: "network_zstd_compression_level", Value: 3},
+	}, c.settings(nil))
+
+	c.opt = &Options{Compression: &Compression{CompressionLZ4}, CompressionLevel: 3}
+	assert.Equal(t, []proto.Setting{
+		{Key: "network_compression_method", Value: "LZ4"},
+	}, c.settings(nil), "the level is only used by zstd")
+}
@@ -0,0 +1,217 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package clickhouse
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+// proxy returns the proxy of the connections to addr: Options.Proxy, else the proxy of the environment when
+// ProxyFromEnvironment is set, HTTPS_PROXY then ALL_PROXY unless addr matches NO_PROXY. nil dials addr directly
+func (o *Options) proxy(addr string) (*url.URL, error) {
+	if o.Proxy != nil || !o.ProxyFromEnvironment {
+		return o.Proxy, nil
+	}
+	config := httpproxy.Config{
+		HTTPSProxy: getenv("HTTPS_PROXY", "https_proxy"),
+		NoProxy:    getenv("NO_PROXY", "no_proxy"),
+	}
+	if len(config.HTTPSProxy) == 0 {
+		config.HTTPSProxy = getenv("ALL_PROXY", "all_proxy")
+	}
+	return config.ProxyFunc()(&url.URL{Scheme: "https", Host: addr})
+}
+
+func getenv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); len(v) != 0 {
+			return v
+		}
+	}
+	return ""
+}
+
+// dialTCP opens the connection to addr through the proxy if any, TLS is layered on top of the tunnel
+func (o *Options) dialTCP(ctx context.Context, addr string) (net.Conn, error) {
+	if o.TLS == nil {
+		return o.dialPlain(ctx, addr)
+	}
+	proxyURL, err := o.proxy(addr)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil {
+		return tls.DialWithDialer(&net.Dialer{Timeout: o.DialTimeout}, "tcp", addr, o.TLS)
+	}
+	if o.DialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.DialTimeout)
+		defer cancel()
+	}
+	conn, err := o.tunnel(ctx, proxyURL, addr)
+	if err != nil {
+		return nil, err
+	}
+	config := o.TLS
+	if len(config.ServerName) == 0 {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		config = config.Clone()
+		config.ServerName = host
+	}
+	tlsConn := tls.Client(conn, config)
+	if err := handshake(ctx, conn, tlsConn.Handshake); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// dialPlain opens the connection to addr through the proxy if any, without TLS.
+// The HTTP transport dials with it and adds TLS itself, so both protocols take the same proxy
+func (o *Options) dialPlain(ctx context.Context, addr string) (net.Conn, error) {
+	proxyURL, err := o.proxy(addr)
+	if err != nil {
+		return nil, err
+	}
+	if o.DialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.DialTimeout)
+		defer cancel()
+	}
+	if proxyURL == nil {
+		return (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	return o.tunnel(ctx, proxyURL, addr)
+}
+
+func (o *Options) tunnel(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	conn, err := dialProxy(ctx, proxyURL, addr)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: proxy %s: %w", proxyURL.Redacted(), err)
+	}
+	return conn, nil
+}
+
+// dialProxy opens a tunnel to addr with a socks5 or socks5h proxy (the name is resolved by the proxy in both cases)
+// or the CONNECT method of an http or https proxy
+func dialProxy(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		dialer, err := proxy.FromURL(proxyURL, &net.Dialer{})
+		if err != nil {
+			return nil, err
+		}
+		return dialer.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", proxyURL.Scheme)
+	}
+	proxyAddr := proxyURL.Host
+	if len(proxyURL.Port()) == 0 {
+		port := "80"
+		if proxyURL.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+	}
+	var resp *http.Response
+	err = handshake(ctx, conn, func() error {
+		req := &http.Request{
+			Method: http.MethodConnect,
+			URL:    &url.URL{Opaque: addr},
+			Host:   addr,
+			Header: make(http.Header),
+		}
+		if user := proxyURL.User; user != nil {
+			password, _ := user.Password()
+			req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user.Username()+":"+password)))
+		}
+		if err := req.Write(conn); err != nil {
+			return err
+		}
+		reader := bufio.NewReader(conn)
+		if resp, err = http.ReadResponse(reader, req); err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if reader.Buffered() != 0 {
+			// the server speaks after the client hello, nothing may follow the response of the proxy
+			return fmt.Errorf("unexpected %d bytes after the CONNECT response", reader.Buffered())
+		}
+		return nil
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("CONNECT %s: %s", addr, resp.Status)
+	}
+	return conn, nil
+}
+
+// handshake runs fn on conn until the deadline of ctx, the connection is closed when ctx is canceled
+func handshake(ctx context.Context, conn net.Conn, fn func() error) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+	done, stopped := make(chan struct{}), make(chan struct{})
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+	if err := fn(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
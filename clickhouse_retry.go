@@ -0,0 +1,139 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+// RetryPolicy retries the operations that failed before the server returned any data: a network failure,
+// a retryable exception code or ErrAcquireConnTimeout. A network failure marks the host as failed for the
+// HostBreaker, so the next attempt goes to another host when there is one.
+//
+// Rows are never retried once they were returned to the caller, nor are batches once they were prepared.
+// Exec and AsyncInsert are retried as well, so enable the policy only for statements that are safe to run twice.
+type RetryPolicy struct {
+	MaxRetries int           // retries after the first attempt, 0 disables the policy
+	Backoff    time.Duration // the delay doubles on each retry and is picked at random below it, default 100 milliseconds
+	MaxBackoff time.Duration // default 5 seconds
+	Codes      []int32       // retryable exception codes, default RetryableCodes
+}
+
+// RetryableCodes are the exception codes of transient failures
+var RetryableCodes = []int32{
+	int32(proto.ErrCodeUnexpectedEndOfFile),
+	int32(proto.ErrCodeTimeoutExceeded),
+	int32(proto.ErrCodeTooManySimultaneousQueries),
+	int32(proto.ErrCodeNoFreeConnection),
+	int32(proto.ErrCodeSocketTimeout),
+	int32(proto.ErrCodeNetworkError),
+	int32(proto.ErrCodeTableIsReadOnly),
+	int32(proto.ErrCodeSystemError),
+	int32(proto.ErrCodeKeeperException),
+}
+
+func WithRetry(policy RetryPolicy) QueryOption {
+	return func(o *QueryOptions) error {
+		o.retry = &policy
+		return nil
+	}
+}
+
+// retryPolicy returns the policy of the query, or the one of the connection
+func (ch *clickhouse) retryPolicy(ctx context.Context) *RetryPolicy {
+	if o, ok := ctx.Value(_contextOptionKey).(QueryOptions); ok && o.retry != nil {
+		return o.retry
+	}
+	return ch.opt.RetryPolicy
+}
+
+// retry runs fn until it succeeds, fails with an error that is not retryable or the policy gives up
+func (ch *clickhouse) retry(ctx context.Context, fn func() error) error {
+	policy := ch.retryPolicy(ctx)
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || policy == nil || attempt >= policy.MaxRetries || ctx.Err() != nil || !policy.retryable(err) {
+			return err
+		}
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	switch {
+	case errors.Is(err, ErrEndOfStream):
+		// the statement has run to its end
+		return false
+	case p.Codes == nil:
+		return IsRetryable(err)
+	}
+	return errors.Is(err, ErrAcquireConnTimeout) || isNetworkError(err) || hasCode(err, p.Codes)
+}
+
+// backoff returns a random delay below Backoff * 2^attempt, the "full jitter" keeps clients that failed together apart
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	var (
+		backoff    = p.Backoff
+		maxBackoff = p.MaxBackoff
+	)
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	for i := 0; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
+
+// isNetworkError reports whether err is a failure of the connection, the context errors are not
+// even though context.DeadlineExceeded implements net.Error
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded), errors.Is(err, ErrEndOfStream):
+		return false
+	case errors.As(err, &netErr),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.EPIPE):
+		return true
+	}
+	return false
+}
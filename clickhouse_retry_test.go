@@ -0,0 +1,176 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/clickhousetest"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+func TestRetryable(t *testing.T) {
+	var policy RetryPolicy
+	for err, expected := range map[error]bool{
+		ErrAcquireConnTimeout: true,
+		io.EOF:                true,
+		fmt.Errorf("read: %w", io.ErrUnexpectedEOF): true,
+		&Exception{Code: 210}:                       true,
+		&Exception{Code: 60}:                        false,
+		ErrBindMixedParamsFormats:                   false,
+		context.Canceled:                            false,
+		ErrEndOfStream:                              false,
+	} {
+		assert.Equal(t, expected, policy.retryable(err), err.Error())
+	}
+	policy.Codes = []int32{60}
+	assert.True(t, policy.retryable(&Exception{Code: 60}))
+	assert.False(t, policy.retryable(&Exception{Code: 210}))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		Backoff:    10 * time.Millisecond,
+		MaxBackoff: 50 * time.Millisecond,
+	}
+	for attempt, limit := range []time.Duration{10, 20, 40, 50, 50} {
+		for i := 0; i < 100; i++ {
+			backoff := policy.backoff(attempt)
+			assert.Greater(t, int64(backoff), int64(0))
+			assert.LessOrEqual(t, int64(backoff), int64(limit*time.Millisecond))
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var (
+		calls int
+		ch    = clickhouse{
+			opt: &Options{
+				RetryPolicy: &RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond},
+			},
+		}
+		failing = func(err error) func() error {
+			calls = 0
+			return func() error {
+				calls++
+				return err
+			}
+		}
+		ctx = context.Background()
+	)
+	assert.Error(t, ch.retry(ctx, failing(&Exception{Code: 210})))
+	assert.Equal(t, 3, calls)
+	assert.Error(t, ch.retry(ctx, failing(&Exception{Code: 60})))
+	assert.Equal(t, 1, calls)
+	assert.Error(t, ch.retry(Context(ctx, WithRetry(RetryPolicy{})), failing(io.EOF)))
+	assert.Equal(t, 1, calls)
+	assert.Error(t, ch.retry(Context(ctx, WithRetry(RetryPolicy{MaxRetries: 5, Backoff: time.Millisecond})), failing(io.EOF)))
+	assert.Equal(t, 6, calls)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(t, ch.retry(cancelled, failing(io.EOF)))
+	assert.Equal(t, 1, calls)
+	calls = 0
+	assert.Error(t, ch.retry(ctx, func() error {
+		if calls++; calls < 2 {
+			return errors.New("not retryable")
+		}
+		return nil
+	}))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryOnAnotherHost(t *testing.T) {
+	var (
+		dropping = standInServer(t, false)
+		healthy  = standInServer(t, true)
+	)
+	conn, err := Open(&Options{
+		Addr:        []string{dropping, healthy},
+		RetryPolicy: &RetryPolicy{MaxRetries: 1, Backoff: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+	require.NoError(t, conn.Ping(ctx))
+	stats := conn.Stats()
+	assert.Equal(t, driver.HostDown, stats.Hosts[dropping].State)
+	assert.Equal(t, driver.HostUp, stats.Hosts[healthy].State)
+	assert.Equal(t, int64(1), stats.ErrorClosed)
+	assert.NoError(t, conn.Ping(Context(ctx, WithRetry(RetryPolicy{}))), "the idle connection to the healthy host is reused")
+}
+
+func TestRetryDroppedQuery(t *testing.T) {
+	srv, err := clickhousetest.NewServer(clickhousetest.Config{})
+	require.NoError(t, err)
+	defer srv.Close()
+	var block proto.Block
+	require.NoError(t, block.AddColumn("n", "UInt8"))
+	require.NoError(t, block.Append(uint8(1)))
+	calls := 0
+	srv.Handle("SELECT 1", func(*clickhousetest.Query) *clickhousetest.Response {
+		if calls++; calls == 1 {
+			return &clickhousetest.Response{Drop: true}
+		}
+		return &clickhousetest.Response{Blocks: []*proto.Block{&block}}
+	})
+	srv.Respond("SELECT overloaded", clickhousetest.Exception(proto.ErrCodeTooManySimultaneousQueries, "too many simultaneous queries"))
+	conn, err := Open(&Options{
+		Addr:        []string{srv.Addr()},
+		RetryPolicy: &RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+	var n uint8
+	require.NoError(t, conn.QueryRow(ctx, "SELECT 1").Scan(&n))
+	assert.Equal(t, uint8(1), n)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, srv.Accepted(), "the dropped connection is replaced")
+	assert.ErrorIs(t, conn.Exec(ctx, "SELECT overloaded"), ErrTooManySimultaneousQueries)
+	assert.Len(t, srv.Queries(), 5, "the overloaded query is tried three times")
+}
+
+func TestRetryEndOfStream(t *testing.T) {
+	srv, err := clickhousetest.NewServer(clickhousetest.Config{})
+	require.NoError(t, err)
+	defer srv.Close()
+	srv.Respond("SELECT nothing", &clickhousetest.Response{})
+	conn, err := Open(&Options{
+		Addr:        []string{srv.Addr()},
+		RetryPolicy: &RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+	_, err = conn.Query(ctx, "SELECT nothing")
+	assert.ErrorIs(t, err, ErrEndOfStream)
+	assert.Len(t, srv.Queries(), 1, "the statement is not run again")
+	require.NoError(t, conn.Ping(ctx))
+	assert.Equal(t, 1, srv.Accepted(), "the connection is reused")
+	assert.Equal(t, driver.HostUp, conn.Stats().Hosts[srv.Addr()].State)
+}
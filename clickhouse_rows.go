@@ -19,6 +19,7 @@ package clickhouse
 
 import (
 	"database/sql"
+	"fmt"
 	"io"
 
 	"github.com/supresu/clickhouse-go/v2/lib/proto"
@@ -29,6 +30,7 @@ type rows struct {
 	row       int
 	block     *proto.Block
 	totals    *proto.Block
+	extremes  *proto.Block
 	errors    chan error
 	stream    chan *proto.Block
 	columns   []string
@@ -57,9 +59,14 @@ next:
 			if block == nil {
 				return false
 			}
-			if block.Packet == proto.ServerTotals {
-				r.row, r.block, r.totals = 0, nil, block
-				return false
+			// totals and extremes come after the data
+			switch block.Packet {
+			case proto.ServerTotals:
+				r.totals = block
+				goto next
+			case proto.ServerExtremes:
+				r.extremes = block
+				goto next
 			}
 			r.row, r.block = 0, block
 		}
@@ -90,6 +97,25 @@ func (r *rows) Totals(dest ...interface{}) error {
 	return scan(r.totals, 1, dest...)
 }
 
+// Extremes scans the minimums of the columns into the first half of dest and their maximums into the second half,
+// they are sent with the extremes setting once all the rows were read
+func (r *rows) Extremes(dest ...interface{}) error {
+	if r.extremes == nil {
+		return sql.ErrNoRows
+	}
+	n := len(r.extremes.Columns)
+	if len(dest) != 2*n {
+		return &OpError{
+			Op:  "Extremes",
+			Err: fmt.Errorf("expected %d destination arguments in Extremes (the minimums then the maximums), not %d", 2*n, len(dest)),
+		}
+	}
+	if err := scan(r.extremes, 1, dest[:n]...); err != nil {
+		return err
+	}
+	return scan(r.extremes, 2, dest[n:]...)
+}
+
 func (r *rows) Columns() []string {
 	return r.columns
 }
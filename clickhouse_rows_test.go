@@ -0,0 +1,115 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+// streamRows returns rows reading the blocks as the native protocol sends them: data, totals then extremes
+func streamRows(t *testing.T, packets ...byte) *rows {
+	var (
+		stream = make(chan *proto.Block, len(packets))
+		errors = make(chan error)
+		values = map[byte][][]interface{}{
+			proto.ServerData:     {{uint64(1), "a"}, {uint64(2), "b"}},
+			proto.ServerTotals:   {{uint64(3), ""}},
+			proto.ServerExtremes: {{uint64(1), "a"}, {uint64(2), "b"}},
+		}
+	)
+	newBlock := func(packet byte, values [][]interface{}) *proto.Block {
+		block := &proto.Block{Packet: packet}
+		require.NoError(t, block.AddColumn("n", "UInt64"))
+		require.NoError(t, block.AddColumn("s", "String"))
+		for _, v := range values {
+			require.NoError(t, block.Append(v...))
+		}
+		return block
+	}
+	for _, packet := range packets {
+		stream <- newBlock(packet, values[packet])
+	}
+	close(stream)
+	close(errors)
+	return &rows{
+		block:   newBlock(proto.ServerData, nil),
+		stream:  stream,
+		errors:  errors,
+		columns: []string{"n", "s"},
+	}
+}
+
+func TestRowsExtremes(t *testing.T) {
+	r := streamRows(t, proto.ServerData, proto.ServerTotals, proto.ServerExtremes)
+	var count int
+	for r.Next() {
+		count++
+	}
+	require.NoError(t, r.Err())
+	assert.Equal(t, 2, count)
+	var (
+		n          uint64
+		s          string
+		minN, maxN uint64
+		minS, maxS string
+	)
+	if assert.NoError(t, r.Totals(&n, &s)) {
+		assert.Equal(t, uint64(3), n)
+	}
+	if assert.NoError(t, r.Extremes(&minN, &minS, &maxN, &maxS)) {
+		assert.Equal(t, uint64(1), minN)
+		assert.Equal(t, "a", minS)
+		assert.Equal(t, uint64(2), maxN)
+		assert.Equal(t, "b", maxS)
+	}
+	assert.Error(t, r.Extremes(&minN, &maxN))
+
+	r = streamRows(t, proto.ServerData)
+	for r.Next() {
+	}
+	assert.Equal(t, sql.ErrNoRows, r.Extremes(&minN, &minS, &maxN, &maxS))
+}
+
+func TestStdRowsExtremes(t *testing.T) {
+	var (
+		std  = &stdRows{rows: streamRows(t, proto.ServerData, proto.ServerTotals, proto.ServerExtremes)}
+		dest = make([]sqldriver.Value, 2)
+		read = func() (values [][]sqldriver.Value) {
+			for std.Next(dest) == nil {
+				values = append(values, []sqldriver.Value{dest[0], dest[1]})
+			}
+			return values
+		}
+	)
+	assert.Equal(t, [][]sqldriver.Value{{uint64(1), "a"}, {uint64(2), "b"}}, read())
+	require.True(t, std.HasNextResultSet())
+	require.NoError(t, std.NextResultSet())
+	assert.Equal(t, [][]sqldriver.Value{{uint64(3), ""}}, read())
+	require.True(t, std.HasNextResultSet())
+	require.NoError(t, std.NextResultSet())
+	assert.Equal(t, [][]sqldriver.Value{{uint64(1), "a"}, {uint64(2), "b"}}, read())
+	assert.False(t, std.HasNextResultSet())
+	assert.Equal(t, io.EOF, std.NextResultSet())
+}
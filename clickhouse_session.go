@@ -0,0 +1,410 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+)
+
+var (
+	ErrSessionClosed = errors.New("clickhouse: session is closed")
+	ErrSessionBusy   = errors.New("clickhouse: session is busy. close the rows or send the batch first")
+)
+
+var (
+	sessionSetRe       = regexp.MustCompile(`(?is)^\s*SET\s+(.*?)\s*;?\s*$`)
+	sessionSetNameRe   = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*=`)
+	sessionUseRe       = regexp.MustCompile(`(?is)^\s*USE\s+`)
+	sessionTempTableRe = regexp.MustCompile(`(?is)^\s*CREATE\s+TEMPORARY\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([^\s(]+)`)
+	sessionRoleRe      = regexp.MustCompile(`(?is)^\s*SET\s+ROLE\s`)
+)
+
+// Acquire pins a connection until the session is closed, so that SET, USE and temporary tables
+// apply to the following statements. A session is not safe for concurrent use and is never retried.
+//
+// Over the native protocol the session keeps an open slot of the pool. Close drops the temporary tables,
+// restores the settings and database that were changed, and returns the connection to the pool.
+// The connection is closed instead when that is not possible. Over HTTP, the session uses a new
+// session_id and the server drops its state after session_timeout.
+func (ch *clickhouse) Acquire(ctx context.Context) (driver.Session, error) {
+	if ch.http != nil {
+		return &session{
+			ch: ch,
+			id: uuid.New().String(),
+		}, nil
+	}
+	var conn *connect
+	err := ch.retry(ctx, func() (err error) {
+		conn, err = ch.acquire(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &session{
+		ch:   ch,
+		conn: conn,
+	}, nil
+}
+
+type session struct {
+	ch       *clickhouse
+	conn     *connect // nil over HTTP
+	id       string   // the session_id over HTTP
+	busy     int32
+	closed   bool
+	settings Settings
+	// the server state changed by the session, restored by Close
+	state struct {
+		dirty      bool // changed in a way that cannot be restored
+		database   *string
+		settings   map[string]string
+		tempTables []string
+	}
+}
+
+// SetSettings adds settings sent with every statement of the session, the settings of the query take precedence
+func (s *session) SetSettings(settings map[string]interface{}) {
+	if s.settings == nil {
+		s.settings = make(Settings, len(settings))
+	}
+	for k, v := range settings {
+		s.settings[k] = v
+	}
+}
+
+// context adds the session settings and id to the options of the query
+func (s *session) context(ctx context.Context) context.Context {
+	o, ok := ctx.Value(_contextOptionKey).(QueryOptions)
+	if !ok {
+		o = QueryOptions{}
+	}
+	settings := make(Settings, len(s.settings)+len(o.settings))
+	for k, v := range s.settings {
+		settings[k] = v
+	}
+	for k, v := range o.settings {
+		settings[k] = v
+	}
+	o.settings, o.sessionID = settings, s.id
+	return context.WithValue(ctx, _contextOptionKey, o)
+}
+
+func (s *session) begin() error {
+	if s.closed || s.conn.closed {
+		return ErrSessionClosed
+	}
+	if !atomic.CompareAndSwapInt32(&s.busy, 0, 1) {
+		return ErrSessionBusy
+	}
+	return nil
+}
+
+// release returns a function that ends the current statement once, the connection
+// methods may call it more than once
+func (s *session) release() func(*connect, error) {
+	var once sync.Once
+	return func(conn *connect, err error) {
+		once.Do(func() {
+			s.check(err)
+			atomic.StoreInt32(&s.busy, 0)
+		})
+	}
+}
+
+// check closes the connection when the error leaves it unusable, a server exception does not
+func (s *session) check(err error) {
+	if err == nil {
+		return
+	}
+	var exception *Exception
+	switch {
+	case s.conn.cancelled:
+		if s.conn.drain() == nil {
+			return
+		}
+	case errors.As(err, &exception):
+		return
+	}
+	s.conn.close()
+}
+
+// prepare binds the arguments and saves the state that the statement changes, the arguments
+// that are bound by the server are returned to be sent with the statement
+func (s *session) prepare(ctx context.Context, query string, args []interface{}) (string, []interface{}, error) {
+	body := query
+	if options := queryOptions(ctx); !serverBinding(query, &options, args) {
+		var err error
+		if body, err = bind(s.conn.server.Timezone, query, args...); err != nil {
+			return "", nil, err
+		}
+		args = nil
+	}
+	switch {
+	case sessionRoleRe.MatchString(body):
+		s.state.dirty = true
+	case sessionSetRe.MatchString(body):
+		return body, args, s.saveSettings(ctx, sessionSetRe.FindStringSubmatch(body)[1])
+	case sessionUseRe.MatchString(body):
+		if s.state.database == nil {
+			var database string
+			if err := s.queryRow(ctx, "SELECT currentDatabase()").Scan(&database); err != nil {
+				return "", nil, err
+			}
+			s.state.database = &database
+		}
+	}
+	return body, args, nil
+}
+
+// done records the temporary table created by a successful statement
+func (s *session) done(body string) {
+	if match := sessionTempTableRe.FindStringSubmatch(body); match != nil {
+		s.state.tempTables = append(s.state.tempTables, match[1])
+	}
+}
+
+// saveSettings keeps the values of the settings before the first SET that changes them
+func (s *session) saveSettings(ctx context.Context, assignments string) error {
+	var names []string
+	for _, assignment := range splitAssignments(assignments) {
+		match := sessionSetNameRe.FindStringSubmatch(assignment)
+		if match == nil {
+			s.state.dirty = true
+			return nil
+		}
+		if _, found := s.state.settings[match[1]]; !found {
+			names = append(names, match[1])
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if s.state.settings == nil {
+		s.state.settings = make(map[string]string)
+	}
+	rows, err := s.conn.query(ctx, s.releaseOwn, "SELECT name, value FROM system.settings WHERE name IN ("+format(nil, names)+")")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return err
+		}
+		s.state.settings[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, found := s.state.settings[name]; !found {
+			// custom settings are not listed in system.settings
+			s.state.dirty = true
+		}
+	}
+	return nil
+}
+
+// releaseOwn is the release of the statements run by the session itself
+func (s *session) releaseOwn(_ *connect, err error) {
+	s.check(err)
+}
+
+func (s *session) queryRow(ctx context.Context, query string) *row {
+	return s.conn.queryRow(ctx, s.releaseOwn, query)
+}
+
+func (s *session) Query(ctx context.Context, query string, args ...interface{}) (driver.Rows, error) {
+	ctx = s.context(ctx)
+	if s.conn == nil {
+		if s.closed {
+			return nil, ErrSessionClosed
+		}
+		return s.ch.http.query(ctx, query, args...)
+	}
+	if err := s.begin(); err != nil {
+		return nil, err
+	}
+	release := s.release()
+	body, args, err := s.prepare(ctx, query, args)
+	if err != nil {
+		release(s.conn, err)
+		return nil, err
+	}
+	rows, err := s.conn.query(ctx, release, body, args...)
+	if err != nil {
+		return nil, err
+	}
+	s.done(body)
+	return rows, nil
+}
+
+func (s *session) QueryRow(ctx context.Context, query string, args ...interface{}) driver.Row {
+	r, err := s.Query(ctx, query, args...)
+	if err != nil {
+		return &row{
+			err: err,
+		}
+	}
+	return &row{
+		rows: r.(*rows),
+	}
+}
+
+func (s *session) Exec(ctx context.Context, query string, args ...interface{}) error {
+	ctx = s.context(ctx)
+	if s.conn == nil {
+		if s.closed {
+			return ErrSessionClosed
+		}
+		return s.ch.http.exec(ctx, query, args...)
+	}
+	if err := s.begin(); err != nil {
+		return err
+	}
+	release := s.release()
+	body, args, err := s.prepare(ctx, query, args)
+	if err == nil {
+		if err = s.conn.exec(ctx, body, args...); err == nil {
+			s.done(body)
+		}
+	}
+	release(s.conn, err)
+	return err
+}
+
+func (s *session) PrepareBatch(ctx context.Context, query string) (driver.Batch, error) {
+	ctx = s.context(ctx)
+	if s.conn == nil {
+		if s.closed {
+			return nil, ErrSessionClosed
+		}
+		return s.ch.http.prepareBatch(ctx, query)
+	}
+	if err := s.begin(); err != nil {
+		return nil, err
+	}
+	batch, err := s.conn.prepareBatch(ctx, query, s.release())
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func (s *session) Close() error {
+	if s.closed {
+		return nil
+	}
+	if s.conn == nil {
+		s.closed = true
+		return nil
+	}
+	if !s.conn.closed {
+		if err := s.begin(); err != nil {
+			return err
+		}
+	}
+	s.closed = true
+	var err error
+	if !s.conn.closed {
+		if err = s.reset(); err != nil {
+			s.conn.debugf("[session] reset: %v", err)
+		}
+	}
+	s.ch.release(s.conn, err)
+	return nil
+}
+
+var errSessionDirty = errors.New("the session state cannot be restored")
+
+// reset restores the server state changed by the session
+func (s *session) reset() error {
+	if s.state.dirty {
+		return errSessionDirty
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.ch.opt.DialTimeout)
+	defer cancel()
+	var statements []string
+	for _, table := range s.state.tempTables {
+		statements = append(statements, "DROP TEMPORARY TABLE IF EXISTS "+table)
+	}
+	if len(s.state.settings) != 0 {
+		assignments := make([]string, 0, len(s.state.settings))
+		for name, value := range s.state.settings {
+			assignments = append(assignments, name+" = "+format(nil, value))
+		}
+		statements = append(statements, "SET "+strings.Join(assignments, ", "))
+	}
+	if s.state.database != nil {
+		statements = append(statements, "USE "+quoteIdentifier(*s.state.database))
+	}
+	for _, statement := range statements {
+		if err := s.conn.exec(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name) + "`"
+}
+
+// splitAssignments splits the assignments of a SET statement on the commas that are not quoted or nested
+func splitAssignments(s string) []string {
+	var (
+		parts   []string
+		depth   int
+		quote   rune
+		escaped bool
+		start   int
+	)
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			switch r {
+			case '\\':
+				escaped = true
+			case quote:
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
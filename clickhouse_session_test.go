@@ -0,0 +1,73 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitAssignments(t *testing.T) {
+	for assignments, expected := range map[string][]string{
+		"max_threads = 1":                         {"max_threads = 1"},
+		"a = 1, b = 'x, y'":                       {"a = 1", " b = 'x, y'"},
+		`a = 'it\'s, ok', b = [1, 2], c = (1, 2)`: {`a = 'it\'s, ok'`, " b = [1, 2]", " c = (1, 2)"},
+	} {
+		assert.Equal(t, expected, splitAssignments(assignments))
+	}
+}
+
+func TestSessionStatements(t *testing.T) {
+	assert.Equal(t, "max_threads = 1, a = 2", sessionSetRe.FindStringSubmatch("  set max_threads = 1, a = 2;")[1])
+	assert.True(t, sessionUseRe.MatchString("USE db"))
+	assert.True(t, sessionRoleRe.MatchString("SET ROLE admin"))
+	assert.False(t, sessionSetRe.MatchString("SELECT 1"))
+	assert.Equal(t, "tmp", sessionTempTableRe.FindStringSubmatch("CREATE TEMPORARY TABLE IF NOT EXISTS tmp (id UInt64)")[1])
+	assert.Nil(t, sessionTempTableRe.FindStringSubmatch("CREATE TABLE tmp (id UInt64)"))
+}
+
+func TestHTTPSession(t *testing.T) {
+	standIn, conn := openHTTP(t, nil)
+	ctx := context.Background()
+	first, err := conn.Acquire(ctx)
+	require.NoError(t, err)
+	first.SetSettings(Settings{"max_threads": 2})
+	require.NoError(t, first.Exec(ctx, "CREATE TEMPORARY TABLE tmp (id UInt64)"))
+	require.NoError(t, first.Exec(Context(ctx, WithSettings(Settings{"max_threads": 4})), "CREATE TEMPORARY TABLE tmp2 (id UInt64)"))
+	second, err := conn.Acquire(ctx)
+	require.NoError(t, err)
+	require.NoError(t, second.Exec(ctx, "CREATE TEMPORARY TABLE tmp (id UInt64)"))
+	require.NoError(t, first.Close())
+	assert.ErrorIs(t, first.Exec(ctx, "CREATE TEMPORARY TABLE tmp (id UInt64)"), ErrSessionClosed)
+	require.NoError(t, second.Close())
+	require.Len(t, standIn.requests, 3)
+	params := [3]map[string][]string{
+		standIn.requests[0].URL.Query(),
+		standIn.requests[1].URL.Query(),
+		standIn.requests[2].URL.Query(),
+	}
+	assert.NotEmpty(t, params[0]["session_id"])
+	assert.Equal(t, params[0]["session_id"], params[1]["session_id"])
+	assert.NotEqual(t, params[0]["session_id"], params[2]["session_id"])
+	assert.Equal(t, []string{"2"}, params[0]["max_threads"])
+	assert.Equal(t, []string{"4"}, params[1]["max_threads"])
+	assert.Empty(t, params[2]["max_threads"])
+}
@@ -0,0 +1,116 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/supresu/clickhouse-go/v2/lib/compress"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+)
+
+// poolStats holds the counters of the native pool, the int64 fields come first for their 64-bit alignment
+type poolStats struct {
+	maxIdleTimeClosed int64
+	maxLifetimeClosed int64
+	keepAlivePings    int64
+	keepAliveFailures int64
+	errorClosed       int64
+	badClosed         int64
+	waitCount         int64
+	waitDuration      int64
+	bytesRead         int64
+	bytesWritten      int64
+	compress          compress.Stats
+	mu                sync.Mutex
+	dials             map[string]*driver.DialStats
+}
+
+func (s *poolStats) dial(addr string, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dials == nil {
+		s.dials = make(map[string]*driver.DialStats)
+	}
+	d, found := s.dials[addr]
+	if !found {
+		d = &driver.DialStats{}
+		s.dials[addr] = d
+	}
+	d.Attempts++
+	if err != nil {
+		d.Failures++
+	}
+}
+
+func (s *poolStats) wait(start time.Time) {
+	atomic.AddInt64(&s.waitCount, 1)
+	atomic.AddInt64(&s.waitDuration, int64(time.Since(start)))
+}
+
+// wrap counts the bytes read from and written to rw, it returns rw as is for a nil receiver
+func (s *poolStats) wrap(rw io.ReadWriter) io.ReadWriter {
+	if s == nil {
+		return rw
+	}
+	return &statsReadWriter{rw: rw, stats: s}
+}
+
+func (s *poolStats) fill(stats *driver.Stats) {
+	stats.MaxIdleTimeClosed = atomic.LoadInt64(&s.maxIdleTimeClosed)
+	stats.MaxLifetimeClosed = atomic.LoadInt64(&s.maxLifetimeClosed)
+	stats.KeepAlivePings = atomic.LoadInt64(&s.keepAlivePings)
+	stats.KeepAliveFailures = atomic.LoadInt64(&s.keepAliveFailures)
+	stats.ErrorClosed = atomic.LoadInt64(&s.errorClosed)
+	stats.BadClosed = atomic.LoadInt64(&s.badClosed)
+	stats.WaitCount = atomic.LoadInt64(&s.waitCount)
+	stats.WaitDuration = time.Duration(atomic.LoadInt64(&s.waitDuration))
+	stats.BytesRead = atomic.LoadInt64(&s.bytesRead)
+	stats.BytesWritten = atomic.LoadInt64(&s.bytesWritten)
+	stats.CompressedBytes = atomic.LoadInt64(&s.compress.Compressed)
+	stats.UncompressedBytes = atomic.LoadInt64(&s.compress.Uncompressed)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats.Dials = make(map[string]driver.DialStats, len(s.dials))
+	for addr, d := range s.dials {
+		stats.Dials[addr] = *d
+	}
+}
+
+type statsReadWriter struct {
+	rw    io.ReadWriter
+	stats *poolStats
+}
+
+func (s *statsReadWriter) Read(p []byte) (int, error) {
+	n, err := s.rw.Read(p)
+	atomic.AddInt64(&s.stats.bytesRead, int64(n))
+	return n, err
+}
+
+func (s *statsReadWriter) Write(p []byte) (int, error) {
+	n, err := s.rw.Write(p)
+	atomic.AddInt64(&s.stats.bytesWritten, int64(n))
+	return n, err
+}
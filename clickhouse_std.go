@@ -34,8 +34,9 @@ import (
 var globalConnID int64
 
 type stdConnOpener struct {
-	err error
-	opt *Options
+	err   error
+	opt   *Options
+	hosts *hostSet
 }
 
 func (o *stdConnOpener) Driver() driver.Driver {
@@ -46,21 +47,13 @@ func (o *stdConnOpener) Connect(ctx context.Context) (_ driver.Conn, err error)
 	if o.err != nil {
 		return nil, o.err
 	}
-	var (
-		conn   *connect
-		connID = int(atomic.AddInt64(&globalConnID, 1))
-	)
-	for num := range o.opt.Addr {
-		if o.opt.ConnOpenStrategy == ConnOpenRoundRobin {
-			num = int(connID) % len(o.opt.Addr)
-		}
-		if conn, err = dial(ctx, o.opt.Addr[num], connID, o.opt); err == nil {
-			return &stdDriver{
-				conn: conn,
-			}, nil
-		}
+	conn, err := o.hosts.dial(ctx, int(atomic.AddInt64(&globalConnID, 1)), nil)
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	return &stdDriver{
+		conn: conn,
+	}, nil
 }
 
 func init() {
@@ -78,14 +71,23 @@ func OpenDB(opt *Options) *sql.DB {
 	if opt.ConnMaxLifetime > 0 {
 		settings = append(settings, "SetConnMaxLifetime")
 	}
+	if opt.ConnMaxIdleTime > 0 {
+		settings = append(settings, "SetConnMaxIdleTime")
+	}
 	if len(settings) != 0 {
 		return sql.OpenDB(&stdConnOpener{
 			err: fmt.Errorf("cannot connect. invalid settings. use %s (see https://pkg.go.dev/database/sql)", strings.Join(settings, ",")),
 		})
 	}
+	if opt.Protocol != Native {
+		return sql.OpenDB(&stdConnOpener{
+			err: fmt.Errorf("cannot connect. the %s protocol is only supported by clickhouse.Open", opt.Protocol),
+		})
+	}
 	opt.setDefaults()
 	return sql.OpenDB(&stdConnOpener{
-		opt: opt,
+		opt:   opt,
+		hosts: newHostSet(opt),
 	})
 }
 
@@ -99,11 +101,17 @@ func (d *stdDriver) Open(dsn string) (_ driver.Conn, err error) {
 	if err := opt.fromDSN(dsn); err != nil {
 		return nil, err
 	}
+	if opt.Protocol != Native {
+		return nil, fmt.Errorf("cannot connect. the %s protocol is only supported by clickhouse.Open", opt.Protocol)
+	}
 	opt.setDefaults()
-	return (&stdConnOpener{opt: &opt}).Connect(context.Background())
+	return (&stdConnOpener{opt: &opt, hosts: newHostSet(&opt)}).Connect(context.Background())
 }
 
 func (std *stdDriver) ResetSession(ctx context.Context) error {
+	if err := std.conn.drain(); err != nil {
+		return driver.ErrBadConn
+	}
 	if std.conn.isBad() {
 		return driver.ErrBadConn
 	}
@@ -266,17 +274,22 @@ func (r *stdRows) Next(dest []driver.Value) error {
 }
 
 func (r *stdRows) HasNextResultSet() bool {
-	return r.rows.totals != nil
+	return r.rows.totals != nil || r.rows.extremes != nil
 }
 
+// NextResultSet moves to the totals and then to the extremes (the minimums then the maximums)
 func (r *stdRows) NextResultSet() error {
 	switch {
 	case r.rows.totals != nil:
 		r.rows.block = r.rows.totals
 		r.rows.totals = nil
+	case r.rows.extremes != nil:
+		r.rows.block = r.rows.extremes
+		r.rows.extremes = nil
 	default:
 		return io.EOF
 	}
+	r.rows.row = 0
 	return nil
 }
 
@@ -0,0 +1,482 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhousetest
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+	"github.com/supresu/clickhouse-go/v2/lib/compress"
+	"github.com/supresu/clickhouse-go/v2/lib/io"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshKeyMarker prefixes the username of the clients that authenticate with an SSH key
+const sshKeyMarker = " SSH KEY AUTHENTICATION "
+
+// interactiveDelay is the interval of the progress packets sent while a query is delayed, so the client can cancel it
+const interactiveDelay = 100 * time.Millisecond
+
+var (
+	errDropped        = errors.New("clickhousetest: connection dropped")
+	errAuthentication = errors.New("clickhousetest: authentication failed")
+)
+
+// conn serves a client connection, see TCPHandler in the ClickHouse sources
+type conn struct {
+	srv         *Server
+	conn        net.Conn
+	stream      *io.Stream
+	encoder     *binary.Encoder
+	decoder     *binary.Decoder
+	revision    uint64
+	compression bool // whether the data blocks of the current query are compressed
+}
+
+func newConn(srv *Server, nc net.Conn) *conn {
+	stream := io.NewStream(nc, compress.LZ4, 0)
+	return &conn{
+		srv:     srv,
+		conn:    nc,
+		stream:  stream,
+		encoder: binary.NewEncoder(stream),
+		decoder: binary.NewDecoder(stream),
+	}
+}
+
+func (c *conn) close() error {
+	return c.conn.Close()
+}
+
+func (c *conn) serve() {
+	defer c.close()
+	if err := c.handshake(); err != nil {
+		return
+	}
+	for {
+		packet, err := c.decoder.Uvarint()
+		if err != nil {
+			return
+		}
+		switch packet {
+		case proto.ClientPing:
+			err = c.send(proto.ServerPong)
+		case proto.ClientQuery:
+			err = c.query()
+		case proto.ClientCancel:
+			// the query has already ended
+		default:
+			err = fmt.Errorf("clickhousetest: unexpected packet %d", packet)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *conn) handshake() error {
+	packet, err := c.decoder.Uvarint()
+	if err != nil {
+		return err
+	}
+	if packet != proto.ClientHello {
+		return fmt.Errorf("clickhousetest: unexpected packet %d in handshake", packet)
+	}
+	if _, err := c.decoder.String(); err != nil { // client name
+		return err
+	}
+	for i := 0; i < 2; i++ { // client version major and minor
+		if _, err := c.decoder.Uvarint(); err != nil {
+			return err
+		}
+	}
+	if c.revision, err = c.decoder.Uvarint(); err != nil {
+		return err
+	}
+	var database, username, password string
+	for _, v := range []*string{&database, &username, &password} {
+		if *v, err = c.decoder.String(); err != nil {
+			return err
+		}
+	}
+	var ok bool
+	switch {
+	case strings.HasPrefix(username, sshKeyMarker):
+		username = strings.TrimPrefix(username, sshKeyMarker)
+		if c.revision < proto.DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION {
+			response := Exception(proto.ErrCodeUnsupportedMethod,
+				"Cannot authenticate user with SSH key, because client version is too old",
+			)
+			if err := c.exception(response.Exception); err != nil {
+				return err
+			}
+			return errAuthentication
+		}
+		if ok, err = c.sshChallenge(c.revision, database, username); err != nil {
+			return err
+		}
+	case c.certificate(username):
+		ok = true
+	default:
+		ok = c.srv.authenticate(username, password)
+	}
+	if !ok {
+		response := Exception(proto.ErrCodeAuthenticationFailed,
+			fmt.Sprintf("%s: Authentication failed: password is incorrect, or there is no user with such name.", username),
+		)
+		if err := c.exception(response.Exception); err != nil {
+			return err
+		}
+		return errAuthentication
+	}
+	if c.revision > c.srv.config.Revision {
+		c.revision = c.srv.config.Revision
+	}
+	hello := proto.ServerHandshake{
+		Name:        "ClickHouse",
+		DisplayName: "clickhousetest",
+		Revision:    c.revision,
+		Timezone:    c.srv.tz,
+	}
+	hello.Version.Major, hello.Version.Minor, hello.Version.Patch = 22, 3, 1
+	if err := c.encoder.Byte(proto.ServerHello); err != nil {
+		return err
+	}
+	if err := hello.Encode(c.encoder); err != nil {
+		return err
+	}
+	if err := c.encoder.Flush(); err != nil {
+		return err
+	}
+	if c.revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_ADDENDUM {
+		if _, err := c.decoder.String(); err != nil { // quota key
+			return err
+		}
+	}
+	return nil
+}
+
+// sshChallenge sends a challenge to the client and verifies its signature with the key of the user
+func (c *conn) sshChallenge(revision uint64, database, username string) (bool, error) {
+	packet, err := c.decoder.Uvarint()
+	if err != nil {
+		return false, err
+	}
+	if packet != proto.ClientSSHChallengeRequest {
+		return false, fmt.Errorf("clickhousetest: expected the SSH challenge request, got %d", packet)
+	}
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return false, err
+	}
+	challenge := hex.EncodeToString(random)
+	if err := c.encoder.Byte(proto.ServerSSHChallenge); err != nil {
+		return false, err
+	}
+	if err := c.encoder.String(challenge); err != nil {
+		return false, err
+	}
+	if err := c.encoder.Flush(); err != nil {
+		return false, err
+	}
+	if packet, err = c.decoder.Uvarint(); err != nil {
+		return false, err
+	}
+	if packet != proto.ClientSSHChallengeResponse {
+		return false, fmt.Errorf("clickhousetest: expected the SSH challenge response, got %d", packet)
+	}
+	blob, err := c.decoder.String()
+	if err != nil {
+		return false, err
+	}
+	key, found := c.srv.config.SSHKeys[username]
+	if !found {
+		return false, nil
+	}
+	var signature ssh.Signature
+	if err := ssh.Unmarshal([]byte(blob), &signature); err != nil {
+		return false, nil
+	}
+	return key.Verify(proto.SSHChallengeMessage(revision, database, username, challenge), &signature) == nil, nil
+}
+
+// certificate reports whether the TLS client certificate has the username as common name
+func (c *conn) certificate(username string) bool {
+	tc, ok := c.conn.(*tls.Conn)
+	if !ok {
+		return false
+	}
+	certs := tc.ConnectionState().PeerCertificates
+	return len(certs) != 0 && certs[0].Subject.CommonName == username
+}
+
+func (c *conn) query() error {
+	var q proto.Query
+	if err := q.Decode(c.decoder, c.revision); err != nil {
+		return err
+	}
+	c.compression = q.Compression
+	query := Query{
+		ID:         q.ID,
+		Body:       q.Body,
+		QuotaKey:   q.QuotaKey,
+		Settings:   settings(q.Settings),
+		Parameters: parameters(q.Parameters),
+		External:   make(map[string]*proto.Block),
+	}
+	// the external tables come first, then an empty block
+	for {
+		name, block, err := c.readData()
+		if err != nil {
+			return err
+		}
+		if len(block.Columns) == 0 {
+			break
+		}
+		query.External[name] = block
+	}
+	if match := insertRe.FindStringSubmatch(q.Body); match != nil {
+		return c.insert(&query, match[1], match[2])
+	}
+	c.srv.received(&query)
+	fn := c.srv.handler(q.Body)
+	if fn == nil {
+		return c.respond(Exception(proto.ErrCodeNotImplemented, fmt.Sprintf("clickhousetest: no response for query %q", q.Body)))
+	}
+	return c.respond(fn(&query))
+}
+
+// insert sends the header of the table and receives the data until an empty block
+func (c *conn) insert(query *Query, name, list string) error {
+	columns, found := c.srv.table(name)
+	if !found {
+		c.srv.received(query)
+		return c.respond(Exception(proto.ErrCodeUnknownTable, fmt.Sprintf("Table %s doesn't exist", name)))
+	}
+	columns, err := insertColumns(columns, list)
+	if err != nil {
+		c.srv.received(query)
+		return c.respond(Exception(proto.ErrCodeNoSuchColumnInTable, err.Error()))
+	}
+	var header proto.Block
+	for _, col := range columns {
+		if err := header.AddColumn(col.Name, col.Type); err != nil {
+			return err
+		}
+	}
+	if err := c.sendData(proto.ServerData, &header); err != nil {
+		return err
+	}
+	for {
+		_, block, err := c.readData()
+		if err != nil {
+			return err
+		}
+		if block.Rows() == 0 {
+			break
+		}
+		query.Data = append(query.Data, block)
+	}
+	c.srv.received(query)
+	var response *Response
+	if fn := c.srv.handler(query.Body); fn != nil {
+		response = fn(query)
+	}
+	if response == nil || (response.Exception == nil && !response.Drop) {
+		c.srv.insert(name, query.Data)
+	}
+	return c.respond(response)
+}
+
+func (c *conn) respond(r *Response) error {
+	if c.revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_TIMEZONE_UPDATES {
+		// the session_timezone of the query, not set
+		if err := c.encoder.Byte(proto.ServerTimezoneUpdate); err != nil {
+			return err
+		}
+		if err := c.encoder.String(""); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return c.send(proto.ServerEndOfStream)
+	}
+	if r.Delay > 0 {
+		cancelled, err := c.wait(r.Delay)
+		if err != nil {
+			return err
+		}
+		if cancelled {
+			return c.send(proto.ServerEndOfStream)
+		}
+	}
+	for i := range r.Progress {
+		if err := c.progress(&r.Progress[i]); err != nil {
+			return err
+		}
+	}
+	if len(r.Blocks) != 0 {
+		var header proto.Block
+		for i, name := range r.Blocks[0].ColumnsNames() {
+			if err := header.AddColumn(name, r.Blocks[0].Columns[i].Type()); err != nil {
+				return err
+			}
+		}
+		if err := c.sendData(proto.ServerData, &header); err != nil {
+			return err
+		}
+	}
+	for _, block := range r.Blocks {
+		if block.Rows() == 0 {
+			continue
+		}
+		if err := c.sendData(proto.ServerData, block); err != nil {
+			return err
+		}
+	}
+	if r.Totals != nil {
+		if err := c.sendData(proto.ServerTotals, r.Totals); err != nil {
+			return err
+		}
+	}
+	if r.Extremes != nil {
+		if err := c.sendData(proto.ServerExtremes, r.Extremes); err != nil {
+			return err
+		}
+	}
+	if r.ProfileInfo != nil {
+		if err := c.encoder.Byte(proto.ServerProfileInfo); err != nil {
+			return err
+		}
+		if err := r.ProfileInfo.Encode(c.encoder, c.revision); err != nil {
+			return err
+		}
+	}
+	switch {
+	case r.Exception != nil:
+		return c.exception(r.Exception)
+	case r.Drop:
+		c.encoder.Flush()
+		return errDropped
+	}
+	return c.send(proto.ServerEndOfStream)
+}
+
+// wait delays the query, it reports whether the client cancelled it in the meantime
+func (c *conn) wait(delay time.Duration) (cancelled bool, err error) {
+	defer c.conn.SetReadDeadline(time.Time{})
+	for end := time.Now().Add(delay); time.Now().Before(end); {
+		deadline := time.Now().Add(interactiveDelay)
+		if deadline.After(end) {
+			deadline = end
+		}
+		c.conn.SetReadDeadline(deadline)
+		packet, err := c.decoder.Uvarint()
+		switch {
+		case err == nil && packet == proto.ClientCancel:
+			return true, nil
+		case err == nil:
+			return false, fmt.Errorf("clickhousetest: unexpected packet %d during a query", packet)
+		}
+		if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+			return false, err
+		}
+		if time.Now().Before(end) {
+			if err := c.progress(&proto.Progress{}); err != nil {
+				return false, err
+			}
+			if err := c.encoder.Flush(); err != nil {
+				return false, err
+			}
+		}
+	}
+	return false, nil
+}
+
+func (c *conn) progress(p *proto.Progress) error {
+	if err := c.encoder.Byte(proto.ServerProgress); err != nil {
+		return err
+	}
+	return p.Encode(c.encoder, c.revision)
+}
+
+func (c *conn) exception(e *proto.Exception) error {
+	if err := c.encoder.Byte(proto.ServerException); err != nil {
+		return err
+	}
+	if err := e.Encode(c.encoder); err != nil {
+		return err
+	}
+	return c.encoder.Flush()
+}
+
+func (c *conn) send(packet byte) error {
+	if err := c.encoder.Byte(packet); err != nil {
+		return err
+	}
+	return c.encoder.Flush()
+}
+
+func (c *conn) sendData(packet byte, block *proto.Block) error {
+	if err := c.encoder.Byte(packet); err != nil {
+		return err
+	}
+	if err := c.encoder.String(""); err != nil {
+		return err
+	}
+	if c.compression {
+		c.stream.Compress(true)
+		defer func() {
+			c.stream.Compress(false)
+			c.encoder.Flush()
+		}()
+	}
+	return block.Encode(c.encoder, c.revision)
+}
+
+// readData reads a data packet of the client, an empty block ends the external tables and the data of an INSERT
+func (c *conn) readData() (string, *proto.Block, error) {
+	packet, err := c.decoder.Uvarint()
+	if err != nil {
+		return "", nil, err
+	}
+	if packet != proto.ClientData {
+		return "", nil, fmt.Errorf("clickhousetest: expected a data packet, got %d", packet)
+	}
+	name, err := c.decoder.String()
+	if err != nil {
+		return "", nil, err
+	}
+	if c.compression {
+		c.stream.Compress(true)
+		defer c.stream.Compress(false)
+	}
+	var block proto.Block
+	if err := block.Decode(c.decoder, c.revision); err != nil {
+		return "", nil, err
+	}
+	return name, &block, nil
+}
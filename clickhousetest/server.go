@@ -0,0 +1,367 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package clickhousetest provides an in-process ClickHouse server speaking the native protocol, for the tests
+// that do not need a real server. It answers the handshake and pings, accepts inserts into in-memory tables
+// and answers the other queries with the canned or scripted responses of the test, which can also inject
+// exceptions, delays, progress packets and dropped connections.
+package clickhousetest
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/supresu/clickhouse-go/v2/lib/column"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+	"github.com/supresu/clickhouse-go/v2/lib/timezone"
+	"golang.org/x/crypto/ssh"
+)
+
+type Config struct {
+	Revision uint64                   // default proto.DBMS_TCP_PROTOCOL_VERSION, the client revision when it is lower
+	Timezone string                   // default UTC
+	Users    map[string]string        // the passwords by username, any user is accepted when nil
+	SSHKeys  map[string]ssh.PublicKey // the keys of the users that authenticate with an SSH key
+	// TLS serves the connections over TLS, a client certificate whose common name is the username authenticates the user
+	TLS *tls.Config
+}
+
+// Column is a column of an in-memory table
+type Column struct {
+	Name string
+	Type column.Type
+}
+
+// Query is a query received by the server
+type Query struct {
+	ID         string
+	Body       string
+	QuotaKey   string
+	Settings   map[string]string
+	Parameters map[string]string
+	External   map[string]*proto.Block // the external tables by name
+	Data       []*proto.Block          // the blocks of an INSERT
+}
+
+// Response is the answer to a query. The server waits for Delay, sending an empty progress packet every
+// 100 milliseconds as the real server does, then it sends Progress, Blocks (after a header block built from
+// the columns of the first one), Totals, Extremes and ProfileInfo. The query ends with Exception,
+// a dropped connection with Drop, or the end of stream. A query cancelled during Delay ends at once.
+type Response struct {
+	Delay       time.Duration
+	Progress    []proto.Progress
+	Blocks      []*proto.Block
+	Totals      *proto.Block
+	Extremes    *proto.Block
+	ProfileInfo *proto.ProfileInfo
+	Exception   *proto.Exception
+	Drop        bool
+}
+
+// HandlerFunc answers a query, a nil response ends it without data
+type HandlerFunc func(*Query) *Response
+
+// Exception returns a response that fails the query with the exception of code
+func Exception(code proto.ErrorCode, message string) *Response {
+	return &Response{
+		Exception: &proto.Exception{
+			Code:    int32(code),
+			Name:    "DB::Exception",
+			Message: message,
+		},
+	}
+}
+
+type table struct {
+	columns []Column
+	blocks  []*proto.Block
+}
+
+type Server struct {
+	config   Config
+	tz       *time.Location
+	listener net.Listener
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	tables   map[string]*table
+	users    map[string]string
+	queries  []Query
+	conns    map[*conn]struct{}
+	accepted int
+	closed   bool
+}
+
+// NewServer starts a server listening on a local port, see Addr
+func NewServer(config Config) (*Server, error) {
+	if config.Revision == 0 {
+		config.Revision = proto.DBMS_TCP_PROTOCOL_VERSION
+	}
+	if len(config.Timezone) == 0 {
+		config.Timezone = "UTC"
+	}
+	tz, err := timezone.Load(config.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	if config.TLS != nil {
+		listener = tls.NewListener(listener, config.TLS)
+	}
+	s := &Server{
+		config:   config,
+		tz:       tz,
+		listener: listener,
+		handlers: make(map[string]HandlerFunc),
+		tables:   make(map[string]*table),
+		users:    config.Users,
+		conns:    make(map[*conn]struct{}),
+	}
+	s.wg.Add(1)
+	go s.accept()
+	return s, nil
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		nc, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			nc.Close()
+			return
+		}
+		c := newConn(s, nc)
+		s.conns[c] = struct{}{}
+		s.accepted++
+		s.wg.Add(1)
+		s.mu.Unlock()
+		go func() {
+			defer s.wg.Done()
+			c.serve()
+			s.mu.Lock()
+			delete(s.conns, c)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// Addr is the address of the server, for Options.Addr
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Handle answers the queries that start with prefix with fn, case and leading spaces aside.
+// The longest matching prefix wins, the queries without a handler fail with NOT_IMPLEMENTED.
+// The INSERT into a table of CreateTable gets the response of its handler once the data is received,
+// the data is only stored when the response has no exception and does not drop the connection.
+func (s *Server) Handle(prefix string, fn HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[strings.ToUpper(strings.TrimSpace(prefix))] = fn
+}
+
+// Respond answers the queries that start with prefix with the same response, see Handle
+func (s *Server) Respond(prefix string, response *Response) {
+	s.Handle(prefix, func(*Query) *Response {
+		return response
+	})
+}
+
+func (s *Server) handler(body string) HandlerFunc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		query   = strings.ToUpper(strings.TrimSpace(body))
+		longest = -1
+		fn      HandlerFunc
+	)
+	for prefix, h := range s.handlers {
+		if strings.HasPrefix(query, prefix) && len(prefix) > longest {
+			longest, fn = len(prefix), h
+		}
+	}
+	return fn
+}
+
+// SetPassword sets the password of the user, as a rotation would. Until the first call any user is accepted
+// unless Config.Users is set.
+func (s *Server) SetPassword(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make(map[string]string, len(s.users)+1)
+	for k, v := range s.users {
+		users[k] = v
+	}
+	users[username] = password
+	s.users = users
+}
+
+// authenticate checks the password of the user
+func (s *Server) authenticate(username, password string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expected, found := s.users[username]
+	return s.users == nil || (found && expected == password)
+}
+
+// CreateTable creates the in-memory table name, the INSERT queries into it append their blocks to the table
+func (s *Server) CreateTable(name string, columns ...Column) error {
+	for _, c := range columns {
+		if _, err := c.Type.Column(); err != nil {
+			return fmt.Errorf("clickhousetest: column %s: %w", c.Name, err)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables[name] = &table{columns: columns}
+	return nil
+}
+
+// Table returns the blocks inserted into the table name
+func (s *Server) Table(name string) []*proto.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, found := s.tables[name]; found {
+		return append([]*proto.Block(nil), t.blocks...)
+	}
+	return nil
+}
+
+func (s *Server) table(name string) ([]Column, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, found := s.tables[name]
+	if !found {
+		return nil, false
+	}
+	return t.columns, true
+}
+
+func (s *Server) insert(name string, blocks []*proto.Block) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, found := s.tables[name]; found {
+		t.blocks = append(t.blocks, blocks...)
+	}
+}
+
+func (s *Server) received(q *Query) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = append(s.queries, *q)
+}
+
+// Queries returns the queries received so far, in order
+func (s *Server) Queries() []Query {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Query(nil), s.queries...)
+}
+
+// Accepted is the number of connections accepted so far
+func (s *Server) Accepted() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accepted
+}
+
+// Open is the number of connections currently open
+func (s *Server) Open() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// DropConnections closes the open connections, as a restarting server would
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.close()
+	}
+}
+
+// Close stops the server and closes its connections
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for c := range s.conns {
+		c.close()
+	}
+	s.mu.Unlock()
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+// settings returns the settings of the query as strings
+func settings(settings proto.Settings) map[string]string {
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		values[s.Key] = fmt.Sprint(s.Value)
+	}
+	return values
+}
+
+func parameters(params proto.Parameters) map[string]string {
+	values := make(map[string]string, len(params))
+	for _, p := range params {
+		values[p.Key] = p.Value
+	}
+	return values
+}
+
+var insertRe = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+([^\s(]+)\s*(?:\(([^)]*)\))?\s*VALUES\s*$`)
+
+// insertColumns returns the columns of the INSERT in the order of the query, all the columns of the table by default
+func insertColumns(columns []Column, list string) ([]Column, error) {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.Trim(strings.TrimSpace(name), "`\""); len(name) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return columns, nil
+	}
+	index := make(map[string]Column, len(columns))
+	for _, c := range columns {
+		index[c.Name] = c
+	}
+	selected := make([]Column, 0, len(names))
+	for _, name := range names {
+		c, found := index[name]
+		if !found {
+			return nil, fmt.Errorf("no such column %s in table", name)
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
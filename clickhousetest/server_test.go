@@ -0,0 +1,244 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhousetest_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+	"github.com/supresu/clickhouse-go/v2/clickhousetest"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+	"golang.org/x/crypto/ssh"
+)
+
+func open(t *testing.T, config clickhousetest.Config, compression *clickhouse.Compression) (*clickhousetest.Server, driver.Conn) {
+	srv, err := clickhousetest.NewServer(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { srv.Close() })
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr:         []string{srv.Addr()},
+		Compression:  compression,
+		MaxOpenConns: 1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return srv, conn
+}
+
+func numbers(t *testing.T, from, to uint64) *proto.Block {
+	var block proto.Block
+	require.NoError(t, block.AddColumn("number", "UInt64"))
+	for n := from; n < to; n++ {
+		require.NoError(t, block.Append(n))
+	}
+	return &block
+}
+
+func TestServerQuery(t *testing.T) {
+	for name, compression := range map[string]*clickhouse.Compression{
+		"none": nil,
+		"lz4":  {Method: clickhouse.CompressionLZ4},
+		"zstd": {Method: clickhouse.CompressionZSTD},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv, conn := open(t, clickhousetest.Config{}, compression)
+			ctx := context.Background()
+			require.NoError(t, conn.Ping(ctx))
+			version, err := conn.ServerVersion()
+			require.NoError(t, err)
+			assert.Equal(t, "ClickHouse", version.Name)
+			srv.Respond("SELECT number", &clickhousetest.Response{
+				Blocks: []*proto.Block{numbers(t, 0, 3), numbers(t, 3, 5)},
+			})
+			rows, err := conn.Query(ctx, "SELECT number FROM numbers(5) WHERE number < {max:UInt8}", clickhouse.Named("max", 5))
+			require.NoError(t, err)
+			var result []uint64
+			for rows.Next() {
+				var n uint64
+				require.NoError(t, rows.Scan(&n))
+				result = append(result, n)
+			}
+			require.NoError(t, rows.Err())
+			assert.Equal(t, []uint64{0, 1, 2, 3, 4}, result)
+			queries := srv.Queries()
+			if assert.Len(t, queries, 1) {
+				assert.Equal(t, map[string]string{"max": "5"}, queries[0].Parameters)
+			}
+			err = conn.Exec(ctx, "DROP TABLE t")
+			assert.True(t, errors.Is(err, proto.ErrCodeNotImplemented), err)
+		})
+	}
+}
+
+func TestServerInsert(t *testing.T) {
+	srv, conn := open(t, clickhousetest.Config{}, &clickhouse.Compression{Method: clickhouse.CompressionLZ4})
+	require.NoError(t, srv.CreateTable("t",
+		clickhousetest.Column{Name: "id", Type: "UInt64"},
+		clickhousetest.Column{Name: "name", Type: "String"},
+	))
+	ctx := context.Background()
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO t (name, id)")
+	require.NoError(t, err)
+	require.NoError(t, batch.Append("a", uint64(1)))
+	require.NoError(t, batch.Append("b", uint64(2)))
+	require.NoError(t, batch.Send())
+	blocks := srv.Table("t")
+	if assert.Len(t, blocks, 1) {
+		assert.Equal(t, []string{"name", "id"}, blocks[0].ColumnsNames())
+		assert.Equal(t, 2, blocks[0].Rows())
+	}
+	_, err = conn.PrepareBatch(ctx, "INSERT INTO unknown")
+	assert.True(t, errors.Is(err, clickhouse.ErrTableNotFound), err)
+
+	srv.Respond("INSERT INTO t", clickhousetest.Exception(proto.ErrCodeTooManyParts, "too many parts"))
+	batch, err = conn.PrepareBatch(ctx, "INSERT INTO t")
+	require.NoError(t, err)
+	require.NoError(t, batch.Append(uint64(3), "c"))
+	assert.Error(t, batch.Send())
+	assert.Len(t, srv.Table("t"), 1, "the rejected data is not stored")
+}
+
+func TestServerFaults(t *testing.T) {
+	srv, conn := open(t, clickhousetest.Config{}, nil)
+	ctx := context.Background()
+	{
+		srv.Respond("SELECT slow", &clickhousetest.Response{
+			Delay:    300 * time.Millisecond,
+			Progress: []proto.Progress{{Rows: 10, TotalRows: 20}},
+			Blocks:   []*proto.Block{numbers(t, 0, 1)},
+		})
+		var (
+			progress []*clickhouse.Progress
+			start    = time.Now()
+			n        uint64
+		)
+		require.NoError(t, conn.QueryRow(clickhouse.Context(ctx, clickhouse.WithProgress(func(p *clickhouse.Progress) {
+			progress = append(progress, p)
+		})), "SELECT slow").Scan(&n))
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(300*time.Millisecond))
+		if assert.NotEmpty(t, progress) {
+			last := progress[len(progress)-1]
+			assert.Equal(t, uint64(10), last.Rows)
+			assert.Equal(t, uint64(20), last.TotalRows)
+		}
+	}
+	{
+		srv.Respond("SELECT forever", &clickhousetest.Response{Delay: time.Hour})
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(200*time.Millisecond, cancel)
+		err := conn.Exec(cancelCtx, "SELECT forever")
+		assert.ErrorIs(t, err, context.Canceled)
+		require.NoError(t, conn.Ping(ctx))
+		assert.Equal(t, 1, srv.Accepted(), "the cancelled query does not cost a connection")
+
+		// the deadline of the query is cleared before the cancelled query is drained
+		deadlineCtx, cancel := context.WithTimeout(ctx, time.Hour)
+		time.AfterFunc(200*time.Millisecond, cancel)
+		_, err = conn.Query(deadlineCtx, "SELECT forever")
+		assert.ErrorIs(t, err, context.Canceled)
+		require.NoError(t, conn.Ping(ctx))
+		assert.Equal(t, 1, srv.Accepted(), "the cancelled query with a deadline does not cost a connection")
+	}
+	{
+		srv.Respond("SELECT dropped", &clickhousetest.Response{
+			Blocks: []*proto.Block{numbers(t, 0, 1)},
+			Drop:   true,
+		})
+		rows, err := conn.Query(ctx, "SELECT dropped")
+		require.NoError(t, err)
+		for rows.Next() {
+		}
+		assert.ErrorIs(t, rows.Err(), io.EOF)
+	}
+	{
+		require.NoError(t, conn.Ping(ctx))
+		accepted := srv.Accepted()
+		srv.DropConnections()
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, conn.Ping(ctx), "the dropped idle connection is replaced")
+		assert.Equal(t, accepted+1, srv.Accepted())
+		assert.Equal(t, 1, srv.Open())
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	srv, err := clickhousetest.NewServer(clickhousetest.Config{
+		Users: map[string]string{"default": "secret"},
+	})
+	require.NoError(t, err)
+	defer srv.Close()
+	for password, ok := range map[string]bool{"secret": true, "wrong": false} {
+		conn, err := clickhouse.Open(&clickhouse.Options{
+			Addr: []string{srv.Addr()},
+			Auth: clickhouse.Auth{Password: password},
+		})
+		require.NoError(t, err)
+		err = conn.Ping(context.Background())
+		if ok {
+			assert.NoError(t, err)
+		} else {
+			assert.True(t, errors.Is(err, clickhouse.ErrAuthenticationFailed), err)
+		}
+		conn.Close()
+	}
+}
+
+func TestServerSSHRevision(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	public, err := ssh.NewPublicKey(key.Public())
+	require.NoError(t, err)
+	srv, err := clickhousetest.NewServer(clickhousetest.Config{
+		SSHKeys:  map[string]ssh.PublicKey{"default": public},
+		Revision: proto.DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION,
+	})
+	require.NoError(t, err)
+	defer srv.Close()
+	// hello sends the hello of a client of the revision with an SSH key and returns the first packet of the server
+	hello := func(revision uint64) byte {
+		conn, err := net.Dial("tcp", srv.Addr())
+		require.NoError(t, err)
+		defer conn.Close()
+		encoder := binary.NewEncoder(conn)
+		require.NoError(t, encoder.Byte(proto.ClientHello))
+		require.NoError(t, encoder.String("test"))
+		require.NoError(t, encoder.Uvarint(1))
+		require.NoError(t, encoder.Uvarint(0))
+		require.NoError(t, encoder.Uvarint(revision))
+		for _, v := range []string{"default", " SSH KEY AUTHENTICATION default", ""} {
+			require.NoError(t, encoder.String(v))
+		}
+		require.NoError(t, encoder.Uvarint(proto.ClientSSHChallengeRequest))
+		require.NoError(t, encoder.Flush())
+		packet, err := binary.NewDecoder(conn).ReadByte()
+		require.NoError(t, err)
+		return packet
+	}
+	assert.Equal(t, byte(proto.ServerException), hello(proto.DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS))
+	assert.Equal(t, byte(proto.ServerSSHChallenge), hello(proto.DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION))
+}
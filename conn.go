@@ -19,7 +19,7 @@ package clickhouse
 
 import (
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -31,7 +31,25 @@ import (
 	"github.com/supresu/clickhouse-go/v2/lib/proto"
 )
 
-func dial(ctx context.Context, addr string, num int, opt *Options) (*connect, error) {
+// dial opens a connection to addr, the traffic is counted into stats unless it is nil.
+// The credentials of an AuthProvider are asked for again once when the server rejects them.
+func dial(ctx context.Context, addr string, num int, opt *Options, stats *poolStats) (*connect, error) {
+	if opt.AuthProvider == nil {
+		return dialAuth(ctx, addr, num, opt, stats, opt.Auth)
+	}
+	for refreshed := false; ; refreshed = true {
+		auth, err := opt.auth(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := dialAuth(ctx, addr, num, opt, stats, auth)
+		if err == nil || refreshed || !errors.Is(err, ErrAuthenticationFailed) {
+			return conn, err
+		}
+	}
+}
+
+func dialAuth(ctx context.Context, addr string, num int, opt *Options, stats *poolStats, auth Auth) (*connect, error) {
 	var (
 		err    error
 		conn   net.Conn
@@ -41,12 +59,7 @@ func dial(ctx context.Context, addr string, num int, opt *Options) (*connect, er
 	case opt.DialContext != nil:
 		conn, err = opt.DialContext(ctx, addr)
 	default:
-		switch {
-		case opt.TLS != nil:
-			conn, err = tls.DialWithDialer(&net.Dialer{Timeout: opt.DialTimeout}, "tcp", addr, opt.TLS)
-		default:
-			conn, err = net.DialTimeout("tcp", addr, opt.DialTimeout)
-		}
+		conn, err = opt.dialTCP(ctx, addr)
 	}
 	if err != nil {
 		return nil, err
@@ -58,12 +71,18 @@ func dial(ctx context.Context, addr string, num int, opt *Options) (*connect, er
 			debugf = log.New(os.Stdout, fmt.Sprintf("[clickhouse][conn=%d][%s]", num, conn.RemoteAddr()), 0).Printf
 		}
 	}
-	var compression bool
+	var (
+		compression bool
+		method      = CompressionLZ4
+	)
 	if opt.Compression != nil {
-		compression = opt.Compression.Method == CompressionLZ4
+		switch opt.Compression.Method {
+		case CompressionNone, CompressionLZ4, CompressionZSTD:
+			compression, method = true, opt.Compression.Method
+		}
 	}
 	var (
-		stream  = io.NewStream(conn)
+		stream  = io.NewStream(stats.wrap(conn), method, opt.CompressionLevel)
 		connect = &connect{
 			opt:         opt,
 			conn:        conn,
@@ -77,7 +96,12 @@ func dial(ctx context.Context, addr string, num int, opt *Options) (*connect, er
 			connectedAt: time.Now(),
 		}
 	)
-	if err := connect.handshake(opt.Auth.Database, opt.Auth.Username, opt.Auth.Password); err != nil {
+	stream.SkipChecksum(opt.SkipChecksum)
+	if stats != nil {
+		stream.SetStats(&stats.compress)
+	}
+	if err := connect.handshake(auth); err != nil {
+		conn.Close()
 		return nil, err
 	}
 	return connect, nil
@@ -91,18 +115,35 @@ type connect struct {
 	server      ServerVersion
 	stream      *io.Stream
 	closed      bool
+	cancelled   bool // the query was cancelled and its packets must be drained
 	encoder     *binary.Encoder
 	decoder     *binary.Decoder
 	released    bool
 	revision    uint64
 	structMap   *structMap
 	compression bool
-	// lastUsedIn  time.Time
+	lastUsedIn  time.Time // when the connection was returned to the idle list
+	lastPingIn  time.Time // when the idle connection was last pinged
 	connectedAt time.Time
+	host        *host  // the pool host the connection was dialed to
+	onClose     func() // called once when the connection is closed
 }
 
 func (c *connect) settings(querySettings Settings) []proto.Setting {
-	settings := make([]proto.Setting, 0, len(c.opt.Settings)+len(querySettings))
+	settings := make([]proto.Setting, 0, len(c.opt.Settings)+len(querySettings)+2)
+	// ask the server to answer with the same compression method, string settings require a newer protocol revision
+	if c.compression && c.revision >= proto.DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS {
+		settings = append(settings, proto.Setting{
+			Key:   "network_compression_method",
+			Value: c.opt.Compression.Method.String(),
+		})
+		if c.opt.Compression.Method == CompressionZSTD && c.opt.CompressionLevel != 0 {
+			settings = append(settings, proto.Setting{
+				Key:   "network_zstd_compression_level",
+				Value: c.opt.CompressionLevel,
+			})
+		}
+	}
 	for k, v := range c.opt.Settings {
 		settings = append(settings, proto.Setting{
 			Key:   k,
@@ -134,6 +175,9 @@ func (c *connect) close() error {
 		return nil
 	}
 	c.closed = true
+	if c.onClose != nil {
+		c.onClose()
+	}
 	c.encoder = nil
 	c.decoder = nil
 	c.stream.Close()
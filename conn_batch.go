@@ -30,7 +30,59 @@ import (
 	"github.com/supresu/clickhouse-go/v2/lib/proto"
 )
 
-var splitInsertRe = regexp.MustCompile(`(?i)\sVALUES\s*\(`)
+var (
+	splitInsertRe  = regexp.MustCompile(`(?i)\sVALUES\s*\(`)
+	insertRe       = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+([^\s(]+)\s*(?:\(([^)]*)\))?`)
+	insertFormatRe = regexp.MustCompile(`(?is)(?:^|\s+)(?:VALUES|FORMAT\s+\w+)\s*$`)
+)
+
+// structColumns returns the columns of the block that AppendStruct fills from v. The columns that v does not have
+// are left to the server when they have a default expression, MATERIALIZED and ALIAS columns are always left out.
+// omitted reports whether some columns of the block were left out.
+func structColumns(m *structMap, block []string, described []ColumnDescription, v interface{}) (columns []string, omitted bool) {
+	index, ok := m.fields(v)
+	if !ok || len(described) == 0 {
+		return block, false
+	}
+	kinds := make(map[string]string, len(described))
+	for _, c := range described {
+		kinds[c.Name] = c.DefaultKind
+	}
+	columns = make([]string, 0, len(block))
+	for _, name := range block {
+		_, found := index[name]
+		switch kind := kinds[name]; {
+		case kind == "MATERIALIZED", kind == "ALIAS", !found && len(kind) != 0:
+			omitted = true
+		default:
+			columns = append(columns, name)
+		}
+	}
+	return columns, omitted
+}
+
+// insertTail returns what follows the table and the columns of the INSERT query without its format,
+// the SETTINGS clause when there is one
+func insertTail(query string) string {
+	match := insertRe.FindStringIndex(query)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimSpace(insertFormatRe.ReplaceAllString(query[match[1]:], ""))
+}
+
+// insertQuery returns the INSERT statement of the columns followed by the tail of the original query, without its format
+func insertQuery(table string, columns []string, tail string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, name := range columns {
+		quoted = append(quoted, "`"+strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name)+"`")
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s)", table, strings.Join(quoted, ", "))
+	if len(tail) != 0 {
+		query += " " + tail
+	}
+	return query
+}
 
 func (c *connect) prepareBatch(ctx context.Context, query string, release func(*connect, error)) (*batch, error) {
 	query = splitInsertRe.Split(query, -1)[0]
@@ -40,24 +92,43 @@ func (c *connect) prepareBatch(ctx context.Context, query string, release func(*
 	options := queryOptions(ctx)
 	if deadline, ok := ctx.Deadline(); ok {
 		c.conn.SetDeadline(deadline)
-		defer c.conn.SetDeadline(time.Time{})
+		release = clearDeadline(release)
 	}
 	if err := c.sendQuery(query, &options); err != nil {
 		release(c, err)
 		return nil, err
 	}
 	var (
-		onProcess  = options.onProcess()
-		block, err = c.firstBlock(ctx, onProcess)
+		columns   []ColumnDescription
+		onProcess = options.onProcess()
 	)
+	onProcess.tableColumns = func(info *proto.TableColumns) {
+		if len(info.First) != 0 {
+			return
+		}
+		var err error
+		if columns, err = info.Columns(); err != nil {
+			c.debugf("[table columns] %v", err)
+		}
+	}
+	block, err := c.firstBlock(ctx, onProcess)
 	if err != nil {
 		release(c, err)
 		return nil, err
 	}
+	onProcess.tableColumns = nil
+	c.conn.SetDeadline(time.Time{})
+	var table string
+	if match := insertRe.FindStringSubmatch(query); match != nil {
+		table = match[1]
+	}
 	return &batch{
-		ctx:   ctx,
-		conn:  c,
-		block: block,
+		ctx:     ctx,
+		conn:    c,
+		block:   block,
+		table:   table,
+		tail:    insertTail(query),
+		columns: columns,
 		release: func(err error) {
 			release(c, err)
 		},
@@ -71,6 +142,10 @@ type batch struct {
 	conn      *connect
 	sent      bool
 	block     *proto.Block
+	table     string // empty when the query could not be parsed
+	tail      string // the SETTINGS of the query, kept when the INSERT is started again
+	columns   []ColumnDescription
+	checked   bool // whether the block columns were checked against the first struct
 	release   func(error)
 	onProcess *onProcess
 }
@@ -98,6 +173,13 @@ func (b *batch) Append(v ...interface{}) error {
 }
 
 func (b *batch) AppendStruct(v interface{}) error {
+	if !b.checked {
+		b.checked = true
+		if err := b.omitColumns(v); err != nil {
+			b.release(err)
+			return err
+		}
+	}
 	values, err := b.conn.structMap.Map("AppendStruct", b.block.ColumnsNames(), v, false)
 	if err != nil {
 		return err
@@ -105,6 +187,41 @@ func (b *batch) AppendStruct(v interface{}) error {
 	return b.Append(values...)
 }
 
+// omitColumns starts the INSERT again without the columns of the block that the server fills for v.
+// The current INSERT is ended with an empty block, which inserts nothing.
+func (b *batch) omitColumns(v interface{}) error {
+	if b.sent || b.block.Rows() != 0 || len(b.table) == 0 {
+		return nil
+	}
+	columns, omitted := structColumns(b.conn.structMap, b.block.ColumnsNames(), b.columns, v)
+	if !omitted {
+		return nil
+	}
+	if err := b.conn.sendData(&proto.Block{}, ""); err != nil {
+		return err
+	}
+	if err := b.conn.encoder.Flush(); err != nil {
+		return err
+	}
+	if err := b.conn.process(b.ctx, b.onProcess); err != nil {
+		return err
+	}
+	options := queryOptions(b.ctx)
+	if err := b.conn.sendQuery(insertQuery(b.table, columns, b.tail)+" VALUES", &options); err != nil {
+		return err
+	}
+	block, err := b.conn.firstBlock(b.ctx, b.onProcess)
+	if err != nil {
+		return err
+	}
+	b.block = block
+	return nil
+}
+
+func (b *batch) Columns() []ColumnDescription {
+	return b.columns
+}
+
 func (b *batch) Column(idx int) driver.BatchColumn {
 	if len(b.block.Columns) <= idx {
 		b.release(nil)
@@ -116,7 +233,9 @@ func (b *batch) Column(idx int) driver.BatchColumn {
 		}
 	}
 	return &batchColumn{
-		batch:  b,
+		sent: func() bool {
+			return b.sent
+		},
 		column: b.block.Columns[idx],
 		release: func(err error) {
 			b.err = err
@@ -155,19 +274,21 @@ func (b *batch) Send() (err error) {
 
 type batchColumn struct {
 	err     error
-	batch   *batch
+	sent    func() bool
 	column  column.Interface
 	release func(error)
 }
 
 func (b *batchColumn) Append(v interface{}) (err error) {
-	if b.batch.sent {
-		return ErrBatchAlreadySent
-	}
 	if b.err != nil {
-		b.release(b.err)
+		if b.release != nil {
+			b.release(b.err)
+		}
 		return b.err
 	}
+	if b.sent() {
+		return ErrBatchAlreadySent
+	}
 	if _, err = b.column.Append(v); err != nil {
 		b.release(err)
 		return err
@@ -0,0 +1,41 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertQuery(t *testing.T) {
+	for query, tail := range map[string]string{
+		"INSERT INTO t VALUES":                                   "",
+		"INSERT INTO t (a, b)":                                   "",
+		"INSERT INTO t SETTINGS async_insert=1 VALUES":           "SETTINGS async_insert=1",
+		"insert into t (a) settings async_insert = 1 values":     "settings async_insert = 1",
+		"INSERT INTO t SETTINGS insert_null_as_default=1":        "SETTINGS insert_null_as_default=1",
+		"INSERT INTO t (a) SETTINGS max_threads=1 FORMAT Native": "SETTINGS max_threads=1",
+		"SELECT 1": "",
+	} {
+		assert.Equal(t, tail, insertTail(query), query)
+	}
+	assert.Equal(t, "INSERT INTO t (`a`, `b`) SETTINGS async_insert=1",
+		insertQuery("t", []string{"a", "b"}, insertTail("INSERT INTO t SETTINGS async_insert=1 VALUES")))
+	assert.Equal(t, "INSERT INTO t (`a`)", insertQuery("t", []string{"a"}, ""))
+}
@@ -25,7 +25,7 @@ import (
 func (c *connect) exec(ctx context.Context, query string, args ...interface{}) error {
 	var (
 		options   = queryOptions(ctx)
-		body, err = bind(c.server.Timezone, query, args...)
+		body, err = c.bind(query, &options, args...)
 	)
 	if err != nil {
 		return err
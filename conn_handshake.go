@@ -24,7 +24,11 @@ import (
 	"github.com/supresu/clickhouse-go/v2/lib/proto"
 )
 
-func (c *connect) handshake(database, username, password string) error {
+func (c *connect) handshake(auth Auth) error {
+	if auth.SSHKey != nil && c.revision < proto.DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION {
+		// the server refuses the SSH key of the older clients
+		return ErrSSHAuthenticationRevision
+	}
 	c.debugf("[handshake] -> %s", proto.ClientHandshake{})
 	c.conn.SetDeadline(time.Now().Add(c.opt.DialTimeout))
 	defer c.conn.SetDeadline(time.Time{})
@@ -34,13 +38,22 @@ func (c *connect) handshake(database, username, password string) error {
 			return err
 		}
 		{
-			if err := c.encoder.String(database); err != nil {
+			username := auth.Username
+			if auth.SSHKey != nil {
+				username = sshKeyMarker + username
+			}
+			if err := c.encoder.String(auth.Database); err != nil {
 				return err
 			}
 			if err := c.encoder.String(username); err != nil {
 				return err
 			}
-			if err := c.encoder.String(password); err != nil {
+			if err := c.encoder.String(auth.Password); err != nil {
+				return err
+			}
+		}
+		if auth.SSHKey != nil {
+			if err := c.sshChallenge(auth); err != nil {
 				return err
 			}
 		}
@@ -72,13 +85,50 @@ func (c *connect) handshake(database, username, password string) error {
 	}
 	if c.revision > c.server.Revision {
 		c.revision = c.server.Revision
-		c.debugf("[handshake] downgrade client proto")
-	}
-	// TODO
-	if c.revision > proto.DBMS_MIN_REVISION_WITH_QUOTA_KEY_IN_CLIENT_INFO {
-		c.revision = proto.DBMS_MIN_REVISION_WITH_QUOTA_KEY_IN_CLIENT_INFO
-		c.debugf("[handshake] test client proto, revision=%d", c.revision)
+		c.debugf("[handshake] downgrade client proto, revision=%d", c.revision)
 	}
 	c.debugf("[handshake] <- %s", c.server)
+	if c.revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_ADDENDUM {
+		// the addendum only holds the quota key, which is sent with each query
+		if err := c.encoder.String(""); err != nil {
+			return err
+		}
+		return c.encoder.Flush()
+	}
 	return nil
 }
+
+// sshChallenge asks the server for a challenge and answers it with the signature of the SSH key
+func (c *connect) sshChallenge(auth Auth) error {
+	c.debugf("[handshake] -> ssh challenge request")
+	if err := c.encoder.Uvarint(proto.ClientSSHChallengeRequest); err != nil {
+		return err
+	}
+	if err := c.encoder.Flush(); err != nil {
+		return err
+	}
+	packet, err := c.decoder.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch packet {
+	case proto.ServerException:
+		return c.exception()
+	case proto.ServerSSHChallenge:
+	default:
+		return fmt.Errorf("[handshake] unexpected packet [%d] from server, expected the SSH challenge", packet)
+	}
+	challenge, err := c.decoder.String()
+	if err != nil {
+		return err
+	}
+	signature, err := sshSign(auth.SSHKey, proto.SSHChallengeMessage(proto.ClientTCPProtocolVersion, auth.Database, auth.Username, challenge))
+	if err != nil {
+		return fmt.Errorf("[handshake] SSH challenge: %w", err)
+	}
+	c.debugf("[handshake] -> ssh challenge response")
+	if err := c.encoder.Uvarint(proto.ClientSSHChallengeResponse); err != nil {
+		return err
+	}
+	return c.encoder.String(string(signature))
+}
@@ -0,0 +1,370 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package clickhouse
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+	"github.com/supresu/clickhouse-go/v2/lib/compress"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+// the Native format over HTTP is written without block info, as for a client with revision 0
+const httpRevision = 0
+
+func dialHttp(opt *Options, hosts *hostSet) *httpConnect {
+	debugf := func(format string, v ...interface{}) {}
+	if opt.Debug {
+		if opt.Debugf != nil {
+			debugf = opt.Debugf
+		} else {
+			debugf = log.New(os.Stdout, "[clickhouse][http]", 0).Printf
+		}
+	}
+	transport := &http.Transport{
+		Proxy: nil, // the connections are tunneled by Options.dialPlain, the proxy of the environment is opt-in
+		DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+			return opt.dialPlain(ctx, addr)
+		},
+		TLSClientConfig:     opt.TLS,
+		MaxIdleConnsPerHost: opt.MaxIdleConns,
+		MaxConnsPerHost:     opt.MaxOpenConns,
+		IdleConnTimeout:     opt.ConnMaxLifetime,
+	}
+	if opt.ConnMaxIdleTime > 0 {
+		transport.IdleConnTimeout = opt.ConnMaxIdleTime
+	}
+	if opt.DialContext != nil {
+		transport.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+			return opt.DialContext(ctx, addr)
+		}
+	}
+	var compression bool
+	if opt.Compression != nil {
+		switch opt.Compression.Method {
+		case CompressionNone, CompressionLZ4, CompressionZSTD:
+			compression = true
+		}
+	}
+	return &httpConnect{
+		opt:         opt,
+		debugf:      debugf,
+		client:      &http.Client{Transport: transport},
+		hosts:       hosts,
+		structMap:   &structMap{},
+		compression: compression,
+	}
+}
+
+// httpConnect runs queries over the ClickHouse HTTP interface using the Native format,
+// so blocks are decoded by the same column codecs as the native protocol.
+// Server logs and profile events are not available over HTTP.
+type httpConnect struct {
+	opt         *Options
+	debugf      func(format string, v ...interface{})
+	client      *http.Client
+	hosts       *hostSet
+	requestID   int64
+	structMap   *structMap
+	compression bool
+	server      struct {
+		sync.Mutex
+		version *ServerVersion
+	}
+}
+
+func (h *httpConnect) scheme() string {
+	if h.opt.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// params returns the URL parameters shared by all the requests: query id, quota key and settings, the database is set by send
+func (h *httpConnect) params(o *QueryOptions) url.Values {
+	params := url.Values{}
+	if len(o.queryID) != 0 {
+		params.Set("query_id", o.queryID)
+	}
+	if len(o.quotaKey) != 0 {
+		params.Set("quota_key", o.quotaKey)
+	}
+	if len(o.sessionID) != 0 {
+		params.Set("session_id", o.sessionID)
+	}
+	for k, v := range h.opt.Settings {
+		params.Set(k, fmt.Sprint(v))
+	}
+	for k, v := range o.settings {
+		params.Set(k, fmt.Sprint(v))
+	}
+	for k, v := range o.serverParams {
+		params.Set("param_"+k, v)
+	}
+	if o.events.progress != nil {
+		params.Set("send_progress_in_http_headers", "1")
+	}
+	return params
+}
+
+// do sends the request to the first server that accepts the connection. Servers are tried in the order
+// given by the ConnOpenStrategy, ConnOpenLeastOpen does not track HTTP connections and keeps the order of Options.Addr.
+// The credentials of an AuthProvider are asked for again once when the server rejects them.
+func (h *httpConnect) do(ctx context.Context, method, path string, params url.Values, body []byte, o *QueryOptions) (*http.Response, error) {
+	var stats *QueryStats
+	if o != nil {
+		stats = o.stats
+		stats.begin(o.queryID)
+	}
+	var resp *http.Response
+	for refreshed := false; ; refreshed = true {
+		var err error
+		if resp, err = h.send(ctx, method, path, params, body, o); err != nil {
+			stats.finish()
+			return nil, err
+		}
+		stats.setQueryID(resp.Header.Get("X-ClickHouse-Query-Id"))
+		if resp.StatusCode == http.StatusOK {
+			break
+		}
+		err = h.exception(resp)
+		resp.Body.Close()
+		if refreshed || h.opt.AuthProvider == nil || !errors.Is(err, ErrAuthenticationFailed) {
+			stats.finish()
+			return nil, err
+		}
+	}
+	if o != nil {
+		if err := h.progress(resp.Header, o.onProcess()); err != nil {
+			resp.Body.Close()
+			stats.finish()
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (h *httpConnect) send(ctx context.Context, method, path string, params url.Values, body []byte, o *QueryOptions) (*http.Response, error) {
+	var (
+		err  error
+		resp *http.Response
+		num  = int(atomic.AddInt64(&h.requestID, 1))
+	)
+	for _, host := range h.hosts.order(num) {
+		var (
+			addr = host.addr
+			auth Auth
+		)
+		if auth, err = h.opt.auth(ctx, addr); err != nil {
+			return nil, err
+		}
+		if auth.SSHKey != nil {
+			return nil, errors.New("clickhouse: SSH key authentication is only supported by the native protocol")
+		}
+		if params != nil {
+			params.Set("database", auth.Database)
+		}
+		u := url.URL{
+			Scheme:   h.scheme(),
+			Host:     addr,
+			Path:     path,
+			RawQuery: params.Encode(),
+		}
+		var req *http.Request
+		if req, err = http.NewRequest(method, u.String(), bytes.NewReader(body)); err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("X-ClickHouse-User", auth.Username)
+		switch {
+		case len(auth.Password) != 0:
+			req.Header.Set("X-ClickHouse-Key", auth.Password)
+		case clientCertificate(h.opt.TLS):
+			req.Header.Set("X-ClickHouse-SSL-Certificate-Auth", "on")
+		}
+		if o != nil && o.span.IsValid() {
+			req.Header.Set("traceparent", fmt.Sprintf("00-%s-%s-%02x", o.span.TraceID(), o.span.SpanID(), byte(o.span.TraceFlags())))
+			if state := o.span.TraceState().String(); len(state) != 0 {
+				req.Header.Set("tracestate", state)
+			}
+		}
+		h.debugf("[%s %s] %s", method, addr, path)
+		if resp, err = h.client.Do(req); err == nil {
+			h.hosts.dialed(host, nil)
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		h.hosts.dialed(host, err)
+	}
+	return nil, err
+}
+
+var httpExceptionRe = regexp.MustCompile(`^Code: (\d+)\. (?:(\S+?): )?`)
+
+// exception converts an error response to Exception. The text looks like
+// "Code: 60. DB::Exception: Table default.t doesn't exist. (UNKNOWN_TABLE) (version 22.3.1.1)"
+func (h *httpConnect) exception(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if h.compression {
+		// the server may have compressed the exception as well as the data
+		if text, err := io.ReadAll(compress.NewReader(bytes.NewReader(body))); err == nil {
+			body = text
+		}
+	}
+	var (
+		text    = strings.TrimSpace(string(body))
+		code, _ = strconv.Atoi(resp.Header.Get("X-ClickHouse-Exception-Code"))
+	)
+	if match := httpExceptionRe.FindStringSubmatch(text); match != nil {
+		if code == 0 {
+			code, _ = strconv.Atoi(match[1])
+		}
+		ex := Exception{
+			Code:    int32(code),
+			Name:    match[2],
+			Message: strings.TrimSpace(text[len(match[0]):]),
+		}
+		h.debugf("[exception] %s", ex.Error())
+		return &ex
+	}
+	if code != 0 {
+		return &Exception{
+			Code:    int32(code),
+			Message: text,
+		}
+	}
+	return &OpError{
+		Op:  "http",
+		Err: fmt.Errorf("unexpected status %s: %s", resp.Status, text),
+	}
+}
+
+// progress reports the X-ClickHouse-Progress headers. The headers are cumulative, while the native protocol sends increments
+func (h *httpConnect) progress(header http.Header, on *onProcess) error {
+	var last Progress
+	for _, v := range header.Values("X-ClickHouse-Progress") {
+		var p struct {
+			ReadRows     uint64 `json:"read_rows,string"`
+			ReadBytes    uint64 `json:"read_bytes,string"`
+			WrittenRows  uint64 `json:"written_rows,string"`
+			WrittenBytes uint64 `json:"written_bytes,string"`
+			TotalRows    uint64 `json:"total_rows_to_read,string"`
+		}
+		if err := json.Unmarshal([]byte(v), &p); err != nil {
+			return &OpError{
+				Op:  "http",
+				Err: fmt.Errorf("invalid progress header %q: %w", v, err),
+			}
+		}
+		progress := Progress{
+			Rows:       p.ReadRows - last.Rows,
+			Bytes:      p.ReadBytes - last.Bytes,
+			TotalRows:  p.TotalRows - last.TotalRows,
+			WroteRows:  p.WrittenRows - last.WroteRows,
+			WroteBytes: p.WrittenBytes - last.WroteBytes,
+		}
+		last = Progress{
+			Rows:       p.ReadRows,
+			Bytes:      p.ReadBytes,
+			TotalRows:  p.TotalRows,
+			WroteRows:  p.WrittenRows,
+			WroteBytes: p.WrittenBytes,
+		}
+		h.debugf("[progress] %s", &progress)
+		on.progress(&progress)
+	}
+	return nil
+}
+
+// fullReader makes short reads impossible, as the binary decoder expects
+type fullReader struct {
+	r io.Reader
+}
+
+func (f fullReader) Read(p []byte) (int, error) {
+	return io.ReadFull(f.r, p)
+}
+
+func (h *httpConnect) decoder(resp *http.Response) *binary.Decoder {
+	if h.compression {
+		return binary.NewDecoder(compress.NewReader(bufio.NewReader(resp.Body)))
+	}
+	return binary.NewDecoder(fullReader{bufio.NewReader(resp.Body)})
+}
+
+func (h *httpConnect) readBlock(decoder *binary.Decoder) (*proto.Block, error) {
+	var block proto.Block
+	if err := block.Decode(decoder, httpRevision); err != nil {
+		return nil, err
+	}
+	block.Packet = proto.ServerData
+	h.debugf("[read data] compression=%t. block: columns=%d, rows=%d", h.compression, len(block.Columns), block.Rows())
+	return &block, nil
+}
+
+// sendQuery posts the query text and returns the response with the Native body
+func (h *httpConnect) sendQuery(ctx context.Context, query string, o *QueryOptions) (*http.Response, error) {
+	if len(o.external) != 0 {
+		return nil, &OpError{
+			Op:  "http",
+			Err: errors.New("external tables are not supported over HTTP"),
+		}
+	}
+	params := h.params(o)
+	params.Set("default_format", "Native")
+	if h.compression {
+		params.Set("compress", "1")
+	}
+	h.debugf("[send query] compression=%t %s", h.compression, query)
+	return h.do(ctx, http.MethodPost, "/", params, []byte(query), o)
+}
+
+func (h *httpConnect) timezone(ctx context.Context) (*time.Location, error) {
+	version, err := h.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return version.Timezone, nil
+}
+
+func (h *httpConnect) close() error {
+	h.client.CloseIdleConnections()
+	return nil
+}
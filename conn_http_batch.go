@@ -0,0 +1,237 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+	"github.com/supresu/clickhouse-go/v2/lib/column"
+	"github.com/supresu/clickhouse-go/v2/lib/compress"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+// prepareBatch builds the block from DESCRIBE TABLE since there is no server block to start from,
+// the data is then sent as "INSERT INTO table (columns) FORMAT Native"
+func (h *httpConnect) prepareBatch(ctx context.Context, query string) (*httpBatch, error) {
+	match := insertRe.FindStringSubmatch(splitInsertRe.Split(query, -1)[0])
+	if match == nil {
+		return nil, &OpError{
+			Op:  "PrepareBatch",
+			Err: fmt.Errorf("unable to find the table name of %q", query),
+		}
+	}
+	var (
+		table   = match[1]
+		tail    = insertTail(splitInsertRe.Split(query, -1)[0])
+		columns []string
+	)
+	for _, name := range strings.Split(match[2], ",") {
+		if name = strings.Trim(strings.TrimSpace(name), "`\""); len(name) != 0 {
+			columns = append(columns, name)
+		}
+	}
+	rows, err := h.query(ctx, "DESCRIBE TABLE "+table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var (
+		described []ColumnDescription
+		ordered   []string
+	)
+	for rows.Next() {
+		// DESCRIBE returns name, type, default_type, default_expression, comment, codec_expression, ttl_expression
+		var c ColumnDescription
+		for i, dest := range []interface{}{&c.Name, &c.Type, &c.DefaultKind, &c.DefaultExpression, &c.Comment, &c.Codec, &c.TTL} {
+			if i >= len(rows.block.Columns) {
+				break
+			}
+			if err := rows.block.Columns[i].ScanRow(dest, rows.row-1); err != nil {
+				return nil, err
+			}
+		}
+		described = append(described, c)
+		switch c.DefaultKind {
+		case "MATERIALIZED", "ALIAS":
+		default:
+			ordered = append(ordered, c.Name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		columns = ordered
+	}
+	batch := &httpBatch{
+		ctx:       ctx,
+		conn:      h,
+		table:     table,
+		tail:      tail,
+		described: described,
+	}
+	if err := batch.init(columns); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+type httpBatch struct {
+	err       error
+	ctx       context.Context
+	conn      *httpConnect
+	sent      bool
+	table     string
+	tail      string // the SETTINGS of the query
+	described []ColumnDescription
+	checked   bool // whether the block columns were checked against the first struct
+	block     *proto.Block
+	query     string
+}
+
+// init builds an empty block and the INSERT statement of the columns
+func (b *httpBatch) init(columns []string) error {
+	types := make(map[string]string, len(b.described))
+	for _, c := range b.described {
+		types[c.Name] = c.Type
+	}
+	block := &proto.Block{}
+	for _, name := range columns {
+		chType, found := types[name]
+		if !found {
+			return &OpError{
+				Op:  "PrepareBatch",
+				Err: fmt.Errorf("table %s has no column %q", b.table, name),
+			}
+		}
+		if err := block.AddColumn(name, column.Type(chType)); err != nil {
+			return err
+		}
+	}
+	b.block, b.query = block, insertQuery(b.table, columns, b.tail)+" FORMAT Native"
+	return nil
+}
+
+func (b *httpBatch) Columns() []ColumnDescription {
+	return b.described
+}
+
+func (b *httpBatch) Abort() error {
+	defer func() {
+		b.sent = true
+	}()
+	if b.sent {
+		return ErrBatchAlreadySent
+	}
+	return nil
+}
+
+func (b *httpBatch) Append(v ...interface{}) error {
+	if b.sent {
+		return ErrBatchAlreadySent
+	}
+	if err := b.block.Append(v...); err != nil {
+		b.err = err
+		return err
+	}
+	return nil
+}
+
+func (b *httpBatch) AppendStruct(v interface{}) error {
+	if !b.checked && !b.sent && b.block.Rows() == 0 {
+		b.checked = true
+		if columns, omitted := structColumns(b.conn.structMap, b.block.ColumnsNames(), b.described, v); omitted {
+			if err := b.init(columns); err != nil {
+				return err
+			}
+		}
+	}
+	values, err := b.conn.structMap.Map("AppendStruct", b.block.ColumnsNames(), v, false)
+	if err != nil {
+		return err
+	}
+	return b.Append(values...)
+}
+
+func (b *httpBatch) Column(idx int) driver.BatchColumn {
+	if len(b.block.Columns) <= idx {
+		return &batchColumn{
+			err: &OpError{
+				Op:  "batch.Column",
+				Err: fmt.Errorf("invalid column index %d", idx),
+			},
+		}
+	}
+	return &batchColumn{
+		sent: func() bool {
+			return b.sent
+		},
+		column: b.block.Columns[idx],
+		release: func(err error) {
+			b.err = err
+		},
+	}
+}
+
+func (b *httpBatch) Send() (err error) {
+	defer func() {
+		b.sent = true
+	}()
+	if b.sent {
+		return ErrBatchAlreadySent
+	}
+	if b.err != nil {
+		return b.err
+	}
+	var (
+		options = queryOptions(b.ctx)
+		params  = b.conn.params(&options)
+		body    bytes.Buffer
+		encoder = binary.NewEncoder(&body)
+	)
+	params.Set("query", b.query)
+	if b.conn.compression {
+		params.Set("decompress", "1")
+		writer := compress.NewWriter(&body, b.conn.opt.Compression.Method, b.conn.opt.CompressionLevel)
+		defer writer.Close()
+		encoder = binary.NewEncoder(writer)
+	}
+	if b.block.Rows() != 0 {
+		if err := b.block.Encode(encoder, httpRevision); err != nil {
+			return err
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		return err
+	}
+	b.conn.debugf("[send data] compression=%t %s rows=%d", b.conn.compression, b.query, b.block.Rows())
+	resp, err := b.conn.do(b.ctx, http.MethodPost, "/", params, body.Bytes(), &options)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	options.stats.finish()
+	return nil
+}
+
+var _ (driver.Batch) = (*httpBatch)(nil)
@@ -0,0 +1,225 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+	"github.com/supresu/clickhouse-go/v2/lib/timezone"
+)
+
+func (h *httpConnect) bind(ctx context.Context, query string, o *QueryOptions, args ...interface{}) (string, error) {
+	server := serverBinding(query, o, args)
+	if len(args) == 0 && !server {
+		return bind(nil, query)
+	}
+	tz, err := h.timezone(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !server {
+		return bind(tz, query, args...)
+	}
+	if o.serverParams, err = bindParams(tz, query, o, args); err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
+func (h *httpConnect) query(ctx context.Context, query string, args ...interface{}) (*rows, error) {
+	var (
+		options   = queryOptions(ctx)
+		body, err = h.bind(ctx, query, &options, args...)
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.sendQuery(ctx, body, &options)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		decoder = h.decoder(resp)
+		errors  = make(chan error)
+		stream  = make(chan *proto.Block, 2)
+	)
+	init, err := h.readBlock(decoder)
+	switch {
+	case err == io.EOF:
+		// the result has no rows, the Native format does not send a header block in this case
+		resp.Body.Close()
+		options.stats.finish()
+		close(stream)
+		close(errors)
+		return &rows{
+			block:     &proto.Block{},
+			stream:    stream,
+			errors:    errors,
+			structMap: h.structMap,
+		}, nil
+	case err != nil:
+		resp.Body.Close()
+		options.stats.finish()
+		return nil, err
+	}
+	go func() {
+		defer options.stats.finish()
+		defer resp.Body.Close()
+		for {
+			block, err := h.readBlock(decoder)
+			if err != nil {
+				if err != io.EOF {
+					errors <- err
+				}
+				break
+			}
+			stream <- block
+		}
+		close(stream)
+		close(errors)
+	}()
+	return &rows{
+		block:     init,
+		stream:    stream,
+		errors:    errors,
+		columns:   init.ColumnsNames(),
+		structMap: h.structMap,
+	}, nil
+}
+
+func (h *httpConnect) queryRow(ctx context.Context, query string, args ...interface{}) *row {
+	rows, err := h.query(ctx, query, args...)
+	if err != nil {
+		return &row{
+			err: err,
+		}
+	}
+	return &row{
+		rows: rows,
+	}
+}
+
+func (h *httpConnect) exec(ctx context.Context, query string, args ...interface{}) error {
+	var (
+		options   = queryOptions(ctx)
+		body, err = h.bind(ctx, query, &options, args...)
+	)
+	if err != nil {
+		return err
+	}
+	return h.execBody(ctx, body, &options)
+}
+
+func (h *httpConnect) execBody(ctx context.Context, body string, options *QueryOptions) error {
+	resp, err := h.sendQuery(ctx, body, options)
+	if err != nil {
+		return err
+	}
+	defer options.stats.finish()
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+func (h *httpConnect) asyncInsert(ctx context.Context, query string, wait bool) error {
+	options := queryOptions(ctx)
+	{
+		options.settings["async_insert"] = 1
+		options.settings["wait_for_async_insert"] = 0
+		if wait {
+			options.settings["wait_for_async_insert"] = 1
+		}
+	}
+	return h.execBody(ctx, query, &options)
+}
+
+func (h *httpConnect) ping(ctx context.Context) error {
+	resp, err := h.do(ctx, http.MethodGet, "/ping", nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return err
+	}
+	h.debugf("[ping] <- pong")
+	return nil
+}
+
+// serverVersion asks the version and the timezone of the server once, there is no handshake over HTTP
+func (h *httpConnect) serverVersion(ctx context.Context) (*ServerVersion, error) {
+	h.server.Lock()
+	defer h.server.Unlock()
+	if h.server.version != nil {
+		return h.server.version, nil
+	}
+	options := QueryOptions{
+		settings: make(Settings),
+	}
+	resp, err := h.sendQuery(ctx, "SELECT version(), timezone()", &options)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	block, err := h.readBlock(h.decoder(resp))
+	if err != nil {
+		return nil, err
+	}
+	if block.Rows() != 1 || len(block.Columns) != 2 {
+		return nil, &OpError{
+			Op:  "ServerVersion",
+			Err: fmt.Errorf("unexpected result: columns=%d, rows=%d", len(block.Columns), block.Rows()),
+		}
+	}
+	var (
+		version  string
+		location string
+		server   = ServerVersion{
+			Name:        "ClickHouse",
+			DisplayName: resp.Header.Get("X-ClickHouse-Server-Display-Name"),
+		}
+	)
+	if err := block.Columns[0].ScanRow(&version, 0); err != nil {
+		return nil, err
+	}
+	if err := block.Columns[1].ScanRow(&location, 0); err != nil {
+		return nil, err
+	}
+	for i, v := range strings.Split(version, ".") {
+		n, _ := strconv.ParseUint(v, 10, 64)
+		switch i {
+		case 0:
+			server.Version.Major = n
+		case 1:
+			server.Version.Minor = n
+		case 2:
+			server.Version.Patch = n
+		}
+	}
+	if server.Timezone, err = timezone.Load(location); err != nil {
+		return nil, err
+	}
+	h.debugf("[server version] %s", server)
+	h.server.version = &server
+	return h.server.version, nil
+}
@@ -0,0 +1,276 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package clickhouse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+	"github.com/supresu/clickhouse-go/v2/lib/compress"
+	"github.com/supresu/clickhouse-go/v2/lib/proto"
+)
+
+// httpStandIn answers the requests of the HTTP transport the way the server does for the Native format
+type httpStandIn struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	inserted *proto.Block
+	password string // the X-ClickHouse-Key expected when set
+}
+
+func (s *httpStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+	if len(s.password) != 0 && r.Header.Get("X-ClickHouse-Key") != s.password {
+		w.Header().Set("X-ClickHouse-Exception-Code", "516")
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, "Code: 516. DB::Exception: user: Authentication failed: password is incorrect, or there is no user with such name. (AUTHENTICATION_FAILED) (version 22.3.2.1)\n")
+		return
+	}
+	if r.URL.Path == "/ping" {
+		io.WriteString(w, "Ok.\n")
+		return
+	}
+	var (
+		params = r.URL.Query()
+		query  = params.Get("query")
+	)
+	if id := params.Get("query_id"); len(id) != 0 {
+		w.Header().Set("X-ClickHouse-Query-Id", id)
+	} else {
+		w.Header().Set("X-ClickHouse-Query-Id", "stand-in")
+	}
+	if r.Method == http.MethodPost && len(query) == 0 {
+		body, _ := io.ReadAll(r.Body)
+		query = string(body)
+	}
+	var block proto.Block
+	switch {
+	case strings.HasPrefix(query, "SELECT version()"):
+		block.AddColumn("version()", "String")
+		block.AddColumn("timezone()", "String")
+		block.Append("22.3.2.1", "UTC")
+	case strings.HasPrefix(query, "SELECT number"):
+		block.AddColumn("number", "UInt64")
+		block.AddColumn("name", "String")
+		for i := 0; i < 3; i++ {
+			block.Append(uint64(i), "name")
+		}
+		w.Header().Add("X-ClickHouse-Progress", `{"read_rows":"1","read_bytes":"8","total_rows_to_read":"3"}`)
+		w.Header().Add("X-ClickHouse-Progress", `{"read_rows":"3","read_bytes":"24","total_rows_to_read":"3"}`)
+	case strings.HasPrefix(query, "DESCRIBE TABLE test_http_defaults"):
+		for _, name := range []string{"name", "type", "default_type", "default_expression", "comment"} {
+			block.AddColumn(name, "String")
+		}
+		block.Append("id", "UInt64", "", "", "")
+		block.Append("name", "String", "", "", "")
+		block.Append("note", "String", "DEFAULT", "'none'", "a note")
+		block.Append("upper_name", "String", "MATERIALIZED", "upper(name)", "")
+	case strings.HasPrefix(query, "DESCRIBE TABLE test_http"):
+		for _, name := range []string{"name", "type", "default_type", "default_expression"} {
+			block.AddColumn(name, "String")
+		}
+		block.Append("id", "UInt64", "", "")
+		block.Append("name", "String", "", "")
+		block.Append("upper_name", "String", "MATERIALIZED", "upper(name)")
+	case strings.HasPrefix(query, "INSERT INTO test_http"):
+		var reader io.Reader = r.Body
+		if params.Get("decompress") == "1" {
+			reader = compress.NewReader(r.Body)
+		}
+		s.inserted = &proto.Block{}
+		if err := s.inserted.Decode(binary.NewDecoder(fullReader{reader}), 0); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	case strings.HasPrefix(query, "CREATE"):
+		return
+	default:
+		w.Header().Set("X-ClickHouse-Exception-Code", "60")
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, "Code: 60. DB::Exception: Table default.unknown doesn't exist. (UNKNOWN_TABLE) (version 22.3.2.1)\n")
+		return
+	}
+	var writer io.Writer = w
+	if params.Get("compress") == "1" {
+		cw := compress.NewWriter(w, compress.LZ4, 0)
+		defer cw.Flush()
+		writer = cw
+	}
+	block.Encode(binary.NewEncoder(writer), 0)
+}
+
+func openHTTP(t *testing.T, compression *Compression) (*httpStandIn, Conn) {
+	var (
+		standIn = &httpStandIn{}
+		server  = httptest.NewServer(standIn)
+	)
+	t.Cleanup(server.Close)
+	conn, err := Open(&Options{
+		Protocol: HTTP,
+		Addr:     []string{strings.TrimPrefix(server.URL, "http://")},
+		Auth: Auth{
+			Database: "db",
+			Username: "user",
+			Password: "secret",
+		},
+		Settings: Settings{
+			"max_block_size": 10,
+		},
+		Compression: compression,
+	})
+	require.NoError(t, err)
+	return standIn, conn
+}
+
+func TestHTTPQuery(t *testing.T) {
+	for name, compression := range map[string]*Compression{"plain": nil, "lz4": {Method: CompressionLZ4}} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				standIn, conn = openHTTP(t, compression)
+				progress      []Progress
+				ctx           = Context(context.Background(), WithQueryID("query-1"), WithProgress(func(p *Progress) {
+					progress = append(progress, *p)
+				}))
+			)
+			rows, err := conn.Query(ctx, "SELECT number, name FROM system.numbers LIMIT ?", 3)
+			require.NoError(t, err)
+			var numbers []uint64
+			for rows.Next() {
+				var (
+					number uint64
+					name   string
+				)
+				require.NoError(t, rows.Scan(&number, &name))
+				numbers = append(numbers, number)
+			}
+			require.NoError(t, rows.Close())
+			require.NoError(t, rows.Err())
+			assert.Equal(t, []uint64{0, 1, 2}, numbers)
+			if assert.Len(t, progress, 2) {
+				assert.Equal(t, uint64(1), progress[0].Rows)
+				assert.Equal(t, uint64(2), progress[1].Rows)
+				assert.Equal(t, uint64(16), progress[1].Bytes)
+			}
+			request := standIn.requests[len(standIn.requests)-1]
+			params := request.URL.Query()
+			assert.Equal(t, "query-1", params.Get("query_id"))
+			assert.Equal(t, "db", params.Get("database"))
+			assert.Equal(t, "10", params.Get("max_block_size"))
+			assert.Equal(t, "Native", params.Get("default_format"))
+			assert.Equal(t, "user", request.Header.Get("X-ClickHouse-User"))
+			assert.Equal(t, "secret", request.Header.Get("X-ClickHouse-Key"))
+		})
+	}
+}
+
+func TestHTTPBatch(t *testing.T) {
+	for name, compression := range map[string]*Compression{"plain": nil, "zstd": {Method: CompressionZSTD}} {
+		t.Run(name, func(t *testing.T) {
+			standIn, conn := openHTTP(t, compression)
+			batch, err := conn.PrepareBatch(context.Background(), "INSERT INTO test_http")
+			require.NoError(t, err)
+			for i := 0; i < 5; i++ {
+				require.NoError(t, batch.Append(uint64(i), "name"))
+			}
+			require.NoError(t, batch.Send())
+			assert.ErrorIs(t, batch.Send(), ErrBatchAlreadySent)
+			if assert.NotNil(t, standIn.inserted) {
+				assert.Equal(t, 5, standIn.inserted.Rows())
+				assert.Equal(t, []string{"id", "name"}, standIn.inserted.ColumnsNames())
+			}
+			request := standIn.requests[len(standIn.requests)-1]
+			assert.Equal(t, "INSERT INTO test_http (`id`, `name`) FORMAT Native", request.URL.Query().Get("query"))
+		})
+	}
+}
+
+func TestHTTPException(t *testing.T) {
+	_, conn := openHTTP(t, nil)
+	err := conn.Exec(context.Background(), "SELECT * FROM unknown")
+	var exception *Exception
+	if assert.ErrorAs(t, err, &exception) {
+		assert.Equal(t, int32(60), exception.Code)
+		assert.Equal(t, "DB::Exception", exception.Name)
+		assert.True(t, strings.HasPrefix(exception.Message, "Table default.unknown doesn't exist."))
+	}
+}
+
+func TestHTTPPingAndVersion(t *testing.T) {
+	_, conn := openHTTP(t, nil)
+	require.NoError(t, conn.Ping(context.Background()))
+	require.NoError(t, conn.Exec(context.Background(), "CREATE TABLE t (id UInt64) Engine Memory"))
+	version, err := conn.ServerVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(22), version.Version.Major)
+	assert.Equal(t, uint64(3), version.Version.Minor)
+	assert.Equal(t, "UTC", version.Timezone.String())
+}
+
+func TestHTTPBatchStructDefaults(t *testing.T) {
+	standIn, conn := openHTTP(t, nil)
+	batch, err := conn.PrepareBatch(context.Background(), "INSERT INTO test_http_defaults")
+	require.NoError(t, err)
+	if columns := batch.Columns(); assert.Len(t, columns, 4) {
+		assert.Equal(t, ColumnDescription{Name: "note", Type: "String", DefaultKind: "DEFAULT", DefaultExpression: "'none'", Comment: "a note"}, columns[2])
+	}
+	type row struct {
+		ID   uint64 `ch:"id"`
+		Name string `ch:"name"`
+	}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, batch.AppendStruct(&row{ID: uint64(i), Name: "name"}))
+	}
+	require.NoError(t, batch.Send())
+	if assert.NotNil(t, standIn.inserted) {
+		assert.Equal(t, 3, standIn.inserted.Rows())
+		assert.Equal(t, []string{"id", "name"}, standIn.inserted.ColumnsNames())
+	}
+	request := standIn.requests[len(standIn.requests)-1]
+	assert.Equal(t, "INSERT INTO test_http_defaults (`id`, `name`) FORMAT Native", request.URL.Query().Get("query"))
+
+	batch, err = conn.PrepareBatch(context.Background(), "INSERT INTO test_http_defaults SETTINGS async_insert = 1, wait_for_async_insert = 0 VALUES")
+	require.NoError(t, err)
+	require.NoError(t, batch.AppendStruct(&row{ID: 1, Name: "name"}))
+	require.NoError(t, batch.Send())
+	request = standIn.requests[len(standIn.requests)-1]
+	assert.Equal(t, "INSERT INTO test_http_defaults (`id`, `name`) SETTINGS async_insert = 1, wait_for_async_insert = 0 FORMAT Native", request.URL.Query().Get("query"))
+}
+
+func TestHTTPIgnoresProxyEnvironment(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "http://proxy.invalid:3128")
+	t.Setenv("HTTPS_PROXY", "http://proxy.invalid:3128")
+	for _, opt := range []*Options{
+		{Protocol: HTTP, Addr: []string{"db.example.com:8123"}},
+		{Protocol: HTTP, Addr: []string{"db.example.com:8123"}, ProxyFromEnvironment: true},
+	} {
+		conn := dialHttp(opt, nil)
+		assert.Nil(t, conn.client.Transport.(*http.Transport).Proxy, "the connections are tunneled when they are dialed")
+	}
+	u, err := (&Options{}).proxy("db.example.com:8123")
+	require.NoError(t, err)
+	assert.Nil(t, u, "ProxyFromEnvironment is not set")
+}
@@ -34,6 +34,43 @@ type Log struct {
 	Text      string
 }
 
+// LogLevel is the send_logs_level setting, the lowest priority of the logs the server sends
+type LogLevel string
+
+const (
+	LogLevelFatal       LogLevel = "fatal"
+	LogLevelError       LogLevel = "error"
+	LogLevelWarning     LogLevel = "warning"
+	LogLevelInformation LogLevel = "information"
+	LogLevelDebug       LogLevel = "debug"
+	LogLevelTrace       LogLevel = "trace"
+	LogLevelTest        LogLevel = "test"
+)
+
+// ServerLogs asks the server for its logs from Level and passes them to Handle, SlogLogs builds one writing to
+// a log/slog logger. Logs are only sent over the native protocol.
+type ServerLogs struct {
+	Level  LogLevel
+	Handle func(*Log)
+}
+
+// logsLevel is the send_logs_level setting of the ServerLogs, unless the settings give it
+func (c *connect) logsLevel(o *QueryOptions) (proto.Setting, bool) {
+	if o.serverLogs == nil || len(o.serverLogs.Level) == 0 {
+		return proto.Setting{}, false
+	}
+	if _, found := o.settings["send_logs_level"]; found {
+		return proto.Setting{}, false
+	}
+	if _, found := c.opt.Settings["send_logs_level"]; found {
+		return proto.Setting{}, false
+	}
+	return proto.Setting{
+		Key:   "send_logs_level",
+		Value: string(o.serverLogs.Level),
+	}, true
+}
+
 func (c *connect) logs() ([]Log, error) {
 	block, err := c.readData(proto.ServerLog, false)
 	if err != nil {
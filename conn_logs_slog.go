@@ -0,0 +1,94 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build go1.21
+
+package clickhouse
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SlogLogs writes the server logs from min to logger. The records keep the server time and carry the
+// query_id, thread_id, source and host attributes, the priorities are mapped by SlogLevel.
+func SlogLogs(logger *slog.Logger, min slog.Level) *ServerLogs {
+	return &ServerLogs{
+		Level: slogLogsLevel(min),
+		Handle: func(log *Log) {
+			level := SlogLevel(log.Priority)
+			if level < min || !logger.Enabled(context.Background(), level) {
+				return
+			}
+			record := slog.NewRecord(log.Time.Add(time.Duration(log.TimeMicro)*time.Microsecond), level, log.Text, 0)
+			record.AddAttrs(
+				slog.String("query_id", log.QueryID),
+				slog.Uint64("thread_id", log.ThreadID),
+				slog.String("source", log.Source),
+				slog.String("host", log.Hostname),
+			)
+			logger.Handler().Handle(context.Background(), record)
+		},
+	}
+}
+
+// WithSlog writes the server logs of the query from min to logger, see SlogLogs
+func WithSlog(logger *slog.Logger, min slog.Level) QueryOption {
+	return WithServerLogs(SlogLogs(logger, min))
+}
+
+// SlogLevel maps the priority of a server log to a slog level. Fatal and critical are above error,
+// notice between info and warn, trace and test below debug.
+func SlogLevel(priority int8) slog.Level {
+	switch priority {
+	case 1, 2: // fatal, critical
+		return slog.LevelError + 4
+	case 3: // error
+		return slog.LevelError
+	case 4: // warning
+		return slog.LevelWarn
+	case 5: // notice
+		return slog.LevelInfo + 2
+	case 6: // information
+		return slog.LevelInfo
+	case 7: // debug
+		return slog.LevelDebug
+	case 8: // trace
+		return slog.LevelDebug - 4
+	}
+	return slog.LevelDebug - 8 // test
+}
+
+// slogLogsLevel is the send_logs_level that includes every priority mapped to min or above
+func slogLogsLevel(min slog.Level) LogLevel {
+	switch {
+	case min <= slog.LevelDebug-8:
+		return LogLevelTest
+	case min <= slog.LevelDebug-4:
+		return LogLevelTrace
+	case min <= slog.LevelDebug:
+		return LogLevelDebug
+	case min <= slog.LevelInfo+2: // notice has no level of its own
+		return LogLevelInformation
+	case min <= slog.LevelWarn:
+		return LogLevelWarning
+	case min <= slog.LevelError:
+		return LogLevelError
+	}
+	return LogLevelFatal
+}
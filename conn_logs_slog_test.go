@@ -0,0 +1,80 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build go1.21
+
+package clickhouse
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogLogs(t *testing.T) {
+	var (
+		out     bytes.Buffer
+		logger  = slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug - 8}))
+		logs    = SlogLogs(logger, slog.LevelInfo)
+		options = QueryOptions{serverLogs: logs}
+		at      = time.Date(2022, 4, 1, 10, 0, 0, 0, time.UTC)
+	)
+	assert.Equal(t, LogLevelInformation, logs.Level)
+	options.onProcess().logs([]Log{
+		{Time: at, TimeMicro: 1500, Hostname: "host", QueryID: "query_id", ThreadID: 42, Priority: 6, Source: "executeQuery", Text: "read 1 rows"},
+		{Time: at, Priority: 7, Text: "below the minimum"},
+		{Time: at, Priority: 2, Text: "critical"},
+	})
+	var records []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n")) {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(line, &record))
+		records = append(records, record)
+	}
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, map[string]interface{}{
+			"time":      "2022-04-01T10:00:00.0015Z",
+			"level":     "INFO",
+			"msg":       "read 1 rows",
+			"query_id":  "query_id",
+			"thread_id": float64(42),
+			"source":    "executeQuery",
+			"host":      "host",
+		}, records[0])
+		assert.Equal(t, "ERROR+4", records[1]["level"])
+	}
+}
+
+func TestSlogLogsLevel(t *testing.T) {
+	for min, expected := range map[slog.Level]LogLevel{
+		slog.LevelDebug - 8: LogLevelTest,
+		slog.LevelDebug - 4: LogLevelTrace,
+		slog.LevelDebug:     LogLevelDebug,
+		slog.LevelInfo:      LogLevelInformation,
+		slog.LevelInfo + 2:  LogLevelInformation,
+		slog.LevelWarn:      LogLevelWarning,
+		slog.LevelError:     LogLevelError,
+		slog.LevelError + 4: LogLevelFatal,
+	} {
+		assert.Equal(t, expected, slogLogsLevel(min), min.String())
+	}
+}
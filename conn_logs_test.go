@@ -0,0 +1,40 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerLogsSetting(t *testing.T) {
+	var (
+		c    = connect{opt: &Options{ServerLogs: &ServerLogs{Level: LogLevelDebug}}}
+		opts = QueryOptions{serverLogs: c.opt.ServerLogs}
+	)
+	if setting, ok := c.logsLevel(&opts); assert.True(t, ok) {
+		assert.Equal(t, "send_logs_level", setting.Key)
+		assert.Equal(t, "debug", setting.Value)
+	}
+	opts.settings = Settings{"send_logs_level": "trace"}
+	_, ok := c.logsLevel(&opts)
+	assert.False(t, ok, "the query settings give the level")
+	_, ok = c.logsLevel(&QueryOptions{})
+	assert.False(t, ok)
+}
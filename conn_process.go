@@ -20,21 +20,30 @@ package clickhouse
 import (
 	"context"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/supresu/clickhouse-go/v2/lib/proto"
 )
 
+// cancelTimeout bounds the time to wait for the server to finish a cancelled query
+const cancelTimeout = 2 * time.Second
+
 type onProcess struct {
 	data          func(*proto.Block)
 	logs          func([]Log)
 	progress      func(*Progress)
 	profileInfo   func(*ProfileInfo)
 	profileEvents func([]ProfileEvent)
+	tableColumns  func(*proto.TableColumns) // only set by PrepareBatch
+	stats         *QueryStats               // finished with the query, nil without WithQueryStats
 }
 
-func (c *connect) firstBlock(ctx context.Context, on *onProcess) (*proto.Block, error) {
+func (c *connect) firstBlock(ctx context.Context, on *onProcess) (_ *proto.Block, err error) {
+	defer func() {
+		if err != nil {
+			on.stats.finish()
+		}
+	}()
 	for {
 		select {
 		case <-ctx.Done():
@@ -51,7 +60,7 @@ func (c *connect) firstBlock(ctx context.Context, on *onProcess) (*proto.Block,
 			return c.readData(packet, true)
 		case proto.ServerEndOfStream:
 			c.debugf("[end of stream]")
-			return nil, io.EOF
+			return nil, ErrEndOfStream
 		default:
 			if err := c.handle(packet, on); err != nil {
 				return nil, err
@@ -61,6 +70,7 @@ func (c *connect) firstBlock(ctx context.Context, on *onProcess) (*proto.Block,
 }
 
 func (c *connect) process(ctx context.Context, on *onProcess) error {
+	defer on.stats.finish()
 	for {
 		select {
 		case <-ctx.Done():
@@ -107,7 +117,10 @@ func (c *connect) handle(packet byte, on *onProcess) error {
 		if err := info.Decode(c.decoder, c.revision); err != nil {
 			return err
 		}
-		c.debugf("[table columns]")
+		c.debugf("[table columns] %s", info.First)
+		if on.tableColumns != nil {
+			on.tableColumns(&info)
+		}
 	case proto.ServerProfileEvents:
 		events, err := c.profileEvents()
 		if err != nil {
@@ -127,6 +140,14 @@ func (c *connect) handle(packet byte, on *onProcess) error {
 		}
 		c.debugf("[progress] %s", progress)
 		on.progress(progress)
+	case proto.ServerTimezoneUpdate:
+		// the session_timezone of the query, empty when it is not set. The DateTime columns
+		// without a timezone are read in the local one, so it is only logged
+		tz, err := c.decoder.String()
+		if err != nil {
+			return err
+		}
+		c.debugf("[timezone update] %q", tz)
 	default:
 		return &OpError{
 			Op:  "process",
@@ -136,12 +157,76 @@ func (c *connect) handle(packet byte, on *onProcess) error {
 	return nil
 }
 
+// cancel asks the server to stop the current query. The remaining packets are read by drain
+// before the connection is used again, so a cancelled query does not cost a new connection.
 func (c *connect) cancel() error {
-	c.conn.SetDeadline(time.Now().Add(2 * time.Second))
 	c.debugf("[cancel]")
-	c.closed = true
-	if err := c.encoder.Uvarint(proto.ClientCancel); err == nil {
+	c.conn.SetWriteDeadline(time.Now().Add(cancelTimeout))
+	defer c.conn.SetWriteDeadline(time.Time{})
+	err := c.encoder.Uvarint(proto.ClientCancel)
+	if err == nil {
+		err = c.encoder.Flush()
+	}
+	if err != nil {
+		c.debugf("[cancel] failed: %v", err)
+		c.close()
 		return err
 	}
-	return c.encoder.Flush()
+	c.cancelled = true
+	return nil
+}
+
+// clearDeadline clears the deadline of the query before the connection is released. Deferring it would
+// clear the deadline that drain sets for a cancelled query once the connection is released.
+func clearDeadline(release func(*connect, error)) func(*connect, error) {
+	return func(c *connect, err error) {
+		c.conn.SetDeadline(time.Time{})
+		release(c, err)
+	}
+}
+
+// drain discards the packets of a cancelled query until the server reports its end.
+// The connection is closed if that does not happen within cancelTimeout.
+func (c *connect) drain() (err error) {
+	if !c.cancelled || c.closed {
+		return nil
+	}
+	c.conn.SetDeadline(time.Now().Add(cancelTimeout))
+	defer func() {
+		c.conn.SetDeadline(time.Time{})
+		if err != nil {
+			c.debugf("[cancel] drain failed: %v", err)
+			c.close()
+		}
+	}()
+	on := &onProcess{
+		logs:          func([]Log) {},
+		progress:      func(*Progress) {},
+		profileInfo:   func(*ProfileInfo) {},
+		profileEvents: func([]ProfileEvent) {},
+	}
+	for {
+		packet, err := c.decoder.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch packet {
+		case proto.ServerEndOfStream:
+			c.debugf("[cancel] <- end of stream")
+			c.cancelled = false
+			return nil
+		case proto.ServerException:
+			// the query has ended with the exception, usually QUERY_WAS_CANCELLED
+			if err := c.exception(); err != nil {
+				if _, ok := err.(*Exception); !ok {
+					return err
+				}
+			}
+			c.cancelled = false
+			return nil
+		}
+		if err := c.handle(packet, on); err != nil {
+			return err
+		}
+	}
 }
@@ -28,7 +28,7 @@ func (c *connect) query(ctx context.Context, release func(*connect, error), quer
 	var (
 		options   = queryOptions(ctx)
 		onProcess = options.onProcess()
-		body, err = bind(c.server.Timezone, query, args...)
+		body, err = c.bind(query, &options, args...)
 	)
 
 	if err != nil {
@@ -38,7 +38,7 @@ func (c *connect) query(ctx context.Context, release func(*connect, error), quer
 
 	if deadline, ok := ctx.Deadline(); ok {
 		c.conn.SetDeadline(deadline)
-		defer c.conn.SetDeadline(time.Time{})
+		release = clearDeadline(release)
 	}
 
 	if err = c.sendQuery(body, &options); err != nil {
@@ -48,11 +48,19 @@ func (c *connect) query(ctx context.Context, release func(*connect, error), quer
 
 	init, err := c.firstBlock(ctx, onProcess)
 
-	if err != nil {
+	switch {
+	case err == ErrEndOfStream:
+		// the query is over, the connection can run the next one
+		release(c, nil)
+		return nil, err
+	case err != nil:
 		release(c, err)
 		return nil, err
 	}
 
+	// the rows are read without the deadline, the context is checked between the packets
+	c.conn.SetDeadline(time.Time{})
+
 	var (
 		errors = make(chan error)
 		stream = make(chan *proto.Block, 2)
@@ -18,13 +18,28 @@
 package clickhouse
 
 import (
+	"github.com/google/uuid"
 	"github.com/supresu/clickhouse-go/v2/lib/proto"
 )
 
 // Connection::sendQuery
 // https://github.com/ClickHouse/ClickHouse/blob/master/src/Client/Connection.cpp
 func (c *connect) sendQuery(body string, o *QueryOptions) error {
-	c.debugf("[send query][%d] compression=%t %s", c.revision, c.compression, body)  // TODO
+	c.debugf("[send query][%d] compression=%t %s", c.revision, c.compression, body) // TODO
+	if o.stats != nil {
+		if len(o.queryID) == 0 {
+			// the server does not tell the ID it assigns, so the stats give one
+			o.queryID = uuid.New().String()
+		}
+		o.stats.begin(o.queryID)
+	}
+	if o.serverLogs == nil {
+		o.serverLogs = c.opt.ServerLogs
+	}
+	settings := c.settings(o.settings)
+	if level, ok := c.logsLevel(o); ok {
+		settings = append(settings, level)
+	}
 	if err := c.encoder.Byte(proto.ClientQuery); err != nil {
 		return err
 	}
@@ -35,7 +50,8 @@ func (c *connect) sendQuery(body string, o *QueryOptions) error {
 		QuotaKey:       o.quotaKey,
 		Compression:    c.compression,
 		InitialAddress: c.conn.LocalAddr().String(),
-		Settings:       c.settings(o.settings),
+		Settings:       settings,
+		Parameters:     o.params(),
 	}
 	if err := q.Encode(c.encoder, c.revision); err != nil {
 		return err
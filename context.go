@@ -32,6 +32,9 @@ var _contextOptionKey = &QueryOptions{
 }
 
 type Settings map[string]interface{}
+
+// Parameters are the values of the {name:Type} placeholders, bound by the server
+type Parameters map[string]interface{}
 type (
 	QueryOption  func(*QueryOptions) error
 	QueryOptions struct {
@@ -48,8 +51,16 @@ type (
 			profileInfo   func(*ProfileInfo)
 			profileEvents func([]ProfileEvent)
 		}
-		settings Settings
-		external []*ext.Table
+		serverLogs *ServerLogs
+		stats      *QueryStats
+		settings   Settings
+		// parameters are given with WithParameters, serverParams are the values of the placeholders of the query
+		parameters   Parameters
+		serverParams map[string]string
+		external     []*ext.Table
+		retry        *RetryPolicy
+		// sessionID pins the HTTP requests of a session
+		sessionID string
 	}
 )
 
@@ -81,6 +92,15 @@ func WithSettings(settings Settings) QueryOption {
 	}
 }
 
+// WithParameters binds the {name:Type} placeholders of the query on the server, the values are serialized in the
+// text format of the declared type. Named arguments of a query with such placeholders are bound the same way.
+func WithParameters(params Parameters) QueryOption {
+	return func(o *QueryOptions) error {
+		o.parameters = params
+		return nil
+	}
+}
+
 func WithLogs(fn func(*Log)) QueryOption {
 	return func(o *QueryOptions) error {
 		o.events.logs = fn
@@ -88,6 +108,14 @@ func WithLogs(fn func(*Log)) QueryOption {
 	}
 }
 
+// WithServerLogs asks the server for the logs of the query, in place of Options.ServerLogs
+func WithServerLogs(logs *ServerLogs) QueryOption {
+	return func(o *QueryOptions) error {
+		o.serverLogs = logs
+		return nil
+	}
+}
+
 func WithProgress(fn func(*Progress)) QueryOption {
 	return func(o *QueryOptions) error {
 		o.events.progress = fn
@@ -109,6 +137,14 @@ func WithProfileEvents(fn func([]ProfileEvent)) QueryOption {
 	}
 }
 
+// WithQueryStats fills stats with the progress, the profile info and the profile events of the query
+func WithQueryStats(stats *QueryStats) QueryOption {
+	return func(o *QueryOptions) error {
+		o.stats = stats
+		return nil
+	}
+}
+
 func WithExternalTable(t ...*ext.Table) QueryOption {
 	return func(o *QueryOptions) error {
 		o.external = append(o.external, t...)
@@ -149,24 +185,33 @@ func queryOptions(ctx context.Context) QueryOptions {
 
 func (q *QueryOptions) onProcess() *onProcess {
 	return &onProcess{
+		stats: q.stats,
 		logs: func(logs []Log) {
 			if q.events.logs != nil {
 				for _, l := range logs {
 					q.events.logs(&l)
 				}
 			}
+			if q.serverLogs != nil && q.serverLogs.Handle != nil {
+				for _, l := range logs {
+					q.serverLogs.Handle(&l)
+				}
+			}
 		},
 		progress: func(p *Progress) {
+			q.stats.addProgress(p)
 			if q.events.progress != nil {
 				q.events.progress(p)
 			}
 		},
 		profileInfo: func(p *ProfileInfo) {
+			q.stats.setProfileInfo(p)
 			if q.events.profileInfo != nil {
 				q.events.profileInfo(p)
 			}
 		},
 		profileEvents: func(events []ProfileEvent) {
+			q.stats.addProfileEvents(events)
 			if q.events.profileEvents != nil {
 				q.events.profileEvents(events)
 			}
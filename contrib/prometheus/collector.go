@@ -0,0 +1,138 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package prometheus exports the statistics of the native connection pool as Prometheus metrics.
+// It is a module of its own so that the driver does not depend on the Prometheus client.
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+)
+
+// Stater is implemented by the connection returned by clickhouse.Open
+type Stater interface {
+	Stats() driver.Stats
+}
+
+type collector struct {
+	conn              Stater
+	maxOpen           *prometheus.Desc
+	open              *prometheus.Desc
+	idle              *prometheus.Desc
+	waitCount         *prometheus.Desc
+	waitDuration      *prometheus.Desc
+	dialAttempts      *prometheus.Desc
+	dialFailures      *prometheus.Desc
+	closed            *prometheus.Desc
+	keepAlivePings    *prometheus.Desc
+	bytesRead         *prometheus.Desc
+	bytesWritten      *prometheus.Desc
+	compressedBytes   *prometheus.Desc
+	uncompressedBytes *prometheus.Desc
+	compressionRatio  *prometheus.Desc
+	hostUp            *prometheus.Desc
+	hostOpen          *prometheus.Desc
+}
+
+// NewCollector returns a collector of the pool statistics of conn, the metrics
+// are prefixed with clickhouse_ and labeled with db_name.
+func NewCollector(conn Stater, dbName string) prometheus.Collector {
+	var (
+		labels = prometheus.Labels{"db_name": dbName}
+		desc   = func(name, help string, variableLabels ...string) *prometheus.Desc {
+			return prometheus.NewDesc(prometheus.BuildFQName("clickhouse", "", name), help, variableLabels, labels)
+		}
+	)
+	return &collector{
+		conn:              conn,
+		maxOpen:           desc("max_open_connections", "Maximum number of open connections."),
+		open:              desc("open_connections", "The number of connections in use."),
+		idle:              desc("idle_connections", "The number of idle connections."),
+		waitCount:         desc("wait_count_total", "The total number of acquires that waited for a free connection."),
+		waitDuration:      desc("wait_duration_seconds_total", "The total time spent waiting for a free connection."),
+		dialAttempts:      desc("dial_attempts_total", "The total number of dials by address.", "addr"),
+		dialFailures:      desc("dial_failures_total", "The total number of failed dials by address.", "addr"),
+		closed:            desc("connections_closed_total", "The total number of connections closed by reason.", "reason"),
+		keepAlivePings:    desc("keep_alive_pings_total", "The total number of pings sent to idle connections."),
+		bytesRead:         desc("read_bytes_total", "The total number of bytes read from the network."),
+		bytesWritten:      desc("written_bytes_total", "The total number of bytes written to the network."),
+		compressedBytes:   desc("compressed_bytes_total", "The total size of the compressed blocks read and written."),
+		uncompressedBytes: desc("uncompressed_bytes_total", "The total size of the compressed blocks once decompressed."),
+		compressionRatio:  desc("compression_ratio", "The uncompressed size of the blocks divided by their compressed size."),
+		hostUp:            desc("host_up", "Whether the host accepts connections (1), is down (0) or is being probed (0.5).", "addr"),
+		hostOpen:          desc("host_open_connections", "The number of connections open to the host.", "addr"),
+	}
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+	ch <- c.dialAttempts
+	ch <- c.dialFailures
+	ch <- c.closed
+	ch <- c.keepAlivePings
+	ch <- c.bytesRead
+	ch <- c.bytesWritten
+	ch <- c.compressedBytes
+	ch <- c.uncompressedBytes
+	ch <- c.compressionRatio
+	ch <- c.hostUp
+	ch <- c.hostOpen
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.conn.Stats()
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConns))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.Open))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+	for addr, dial := range stats.Dials {
+		ch <- prometheus.MustNewConstMetric(c.dialAttempts, prometheus.CounterValue, float64(dial.Attempts), addr)
+		ch <- prometheus.MustNewConstMetric(c.dialFailures, prometheus.CounterValue, float64(dial.Failures), addr)
+	}
+	for reason, v := range map[string]int64{
+		"max_idle_time": stats.MaxIdleTimeClosed,
+		"max_lifetime":  stats.MaxLifetimeClosed,
+		"keep_alive":    stats.KeepAliveFailures,
+		"error":         stats.ErrorClosed,
+		"bad":           stats.BadClosed,
+	} {
+		ch <- prometheus.MustNewConstMetric(c.closed, prometheus.CounterValue, float64(v), reason)
+	}
+	ch <- prometheus.MustNewConstMetric(c.keepAlivePings, prometheus.CounterValue, float64(stats.KeepAlivePings))
+	ch <- prometheus.MustNewConstMetric(c.bytesRead, prometheus.CounterValue, float64(stats.BytesRead))
+	ch <- prometheus.MustNewConstMetric(c.bytesWritten, prometheus.CounterValue, float64(stats.BytesWritten))
+	ch <- prometheus.MustNewConstMetric(c.compressedBytes, prometheus.CounterValue, float64(stats.CompressedBytes))
+	ch <- prometheus.MustNewConstMetric(c.uncompressedBytes, prometheus.CounterValue, float64(stats.UncompressedBytes))
+	ch <- prometheus.MustNewConstMetric(c.compressionRatio, prometheus.GaugeValue, stats.CompressionRatio())
+	for addr, host := range stats.Hosts {
+		var up float64
+		switch host.State {
+		case driver.HostUp:
+			up = 1
+		case driver.HostProbing:
+			up = 0.5
+		}
+		ch <- prometheus.MustNewConstMetric(c.hostUp, prometheus.GaugeValue, up, addr)
+		ch <- prometheus.MustNewConstMetric(c.hostOpen, prometheus.GaugeValue, float64(host.Open), addr)
+	}
+}
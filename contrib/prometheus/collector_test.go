@@ -0,0 +1,80 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/driver"
+)
+
+type staticStats driver.Stats
+
+func (s staticStats) Stats() driver.Stats {
+	return driver.Stats(s)
+}
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector(staticStats{
+		MaxOpenConns:      10,
+		Open:              2,
+		Idle:              3,
+		WaitCount:         4,
+		WaitDuration:      1500 * time.Millisecond,
+		ErrorClosed:       1,
+		CompressedBytes:   100,
+		UncompressedBytes: 400,
+		Dials: map[string]driver.DialStats{
+			"127.0.0.1:9000": {Attempts: 5, Failures: 1},
+		},
+		Hosts: map[string]driver.HostStats{
+			"127.0.0.1:9000": {State: driver.HostDown},
+		},
+	}, "default")
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+	expected := `
+# HELP clickhouse_compression_ratio The uncompressed size of the blocks divided by their compressed size.
+# TYPE clickhouse_compression_ratio gauge
+clickhouse_compression_ratio{db_name="default"} 4
+# HELP clickhouse_dial_failures_total The total number of failed dials by address.
+# TYPE clickhouse_dial_failures_total counter
+clickhouse_dial_failures_total{addr="127.0.0.1:9000",db_name="default"} 1
+# HELP clickhouse_host_up Whether the host accepts connections (1), is down (0) or is being probed (0.5).
+# TYPE clickhouse_host_up gauge
+clickhouse_host_up{addr="127.0.0.1:9000",db_name="default"} 0
+# HELP clickhouse_wait_duration_seconds_total The total time spent waiting for a free connection.
+# TYPE clickhouse_wait_duration_seconds_total counter
+clickhouse_wait_duration_seconds_total{db_name="default"} 1.5
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"clickhouse_compression_ratio",
+		"clickhouse_dial_failures_total",
+		"clickhouse_host_up",
+		"clickhouse_wait_duration_seconds_total",
+	))
+	count, err := testutil.GatherAndCount(registry, "clickhouse_connections_closed_total")
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
@@ -0,0 +1,11 @@
+module github.com/supresu/clickhouse-go/v2/contrib/prometheus
+
+go 1.16
+
+require (
+	github.com/prometheus/client_golang v1.12.2
+	github.com/stretchr/testify v1.7.1
+	github.com/supresu/clickhouse-go/v2 v2.0.0
+)
+
+replace github.com/supresu/clickhouse-go/v2 => ../../
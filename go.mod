@@ -5,6 +5,7 @@ go 1.16
 require (
 	github.com/ClickHouse/clickhouse-go v1.5.4
 	github.com/google/uuid v1.3.0
+	github.com/klauspost/compress v1.15.4
 	github.com/mkevac/debugcharts v0.0.0-20191222103121-ae1c48aa8615
 	github.com/paulmach/orb v0.7.1
 	github.com/pierrec/lz4/v4 v4.1.14
@@ -14,5 +15,7 @@ require (
 	github.com/tklauser/go-sysconf v0.3.10 // indirect
 	github.com/yusufpapurcu/wmi v1.2.2 // indirect
 	go.opentelemetry.io/otel/trace v1.7.0
+	golang.org/x/crypto v0.0.0-20220525230936-793ad666bf5e
+	golang.org/x/net v0.0.0-20211112202133-69e39bad7dc2
 	golang.org/x/sys v0.0.0-20220429233432-b5fbb4746d32 // indirect
 )
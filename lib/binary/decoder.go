@@ -35,12 +35,8 @@ type Decoder struct {
 }
 
 func (decoder *Decoder) Raw(b []byte) error {
-	n, err := decoder.input.Read(b)
-	if err != nil {
-		return nil
-	}
-	if n != len(b) {
-		return io.EOF
+	if _, err := io.ReadFull(decoder.input, b); err != nil {
+		return err
 	}
 	return nil
 }
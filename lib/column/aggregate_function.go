@@ -0,0 +1,468 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package column
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+var scanTypeAggregateState = reflect.TypeOf([]byte{})
+
+// AggregateState is a row of an AggregateFunction column. State is the serialized state of the function, it is written
+// back as it is. Value is the decoded state of the functions with a fixed state layout and nil otherwise:
+//
+//	count, uniqExact        uint64 (the count of the distinct values for uniqExact)
+//	sum                     the sum in its result type
+//	avg                     AvgState
+//	min, max, any, anyLast  the value, nil if the function has seen no value
+type AggregateState struct {
+	State []byte
+	Value interface{}
+}
+
+// AvgState is the state of avg, the sum of the values and their count. The sum of the
+// 128 and 256 bit integers is a float64.
+type AvgState struct {
+	Numerator   interface{}
+	Denominator uint64
+}
+
+// stateReader keeps the bytes read from the block, the states of the rows are not length prefixed
+type stateReader struct {
+	decoder *binary.Decoder
+	state   []byte
+}
+
+func (r *stateReader) Read(b []byte) (int, error) {
+	if err := r.decoder.Raw(b); err != nil {
+		return 0, err
+	}
+	r.state = append(r.state, b...)
+	return len(b), nil
+}
+
+// aggregateState reads the state of a row and returns its decoded value
+type aggregateState func(decoder *binary.Decoder) (interface{}, error)
+
+// skip reads n bytes of the state
+func skip(decoder *binary.Decoder, n int) error {
+	return decoder.Raw(make([]byte, n))
+}
+
+// AggregateFunction is an AggregateFunction(name, T...) column of count, sum, avg, min, max, any, anyLast, uniq,
+// uniqExact and the quantile(s) functions, the functions whose states can be read without a length.
+type AggregateFunction struct {
+	chType Type
+	read   aggregateState
+	rows   []AggregateState
+}
+
+func (col *AggregateFunction) parse(t Type) (Interface, error) {
+	col.chType = t
+	elements := splitElements(t.params())
+	if len(elements) != 0 {
+		if _, err := strconv.Atoi(strings.TrimSpace(elements[0])); err == nil {
+			elements = elements[1:] // the version of the state
+		}
+	}
+	if len(elements) == 0 {
+		return nil, &UnsupportedColumnTypeError{
+			t: t,
+		}
+	}
+	name := strings.TrimSpace(elements[0])
+	if i := strings.Index(name, "("); i != -1 {
+		name = name[:i] // the parameters, such as the levels of quantiles
+	}
+	var args []Type
+	for _, arg := range elements[1:] {
+		args = append(args, Type(strings.TrimSpace(arg)))
+	}
+	if col.read = aggregateFunctionState(name, args); col.read == nil {
+		return nil, &UnsupportedColumnTypeError{
+			t: t,
+		}
+	}
+	return col, nil
+}
+
+// aggregateFunctionState returns the reader of the states of the function, nil if the layout is not known
+func aggregateFunctionState(name string, args []Type) aggregateState {
+	switch name {
+	case "count":
+		return func(decoder *binary.Decoder) (interface{}, error) {
+			return decoder.Uvarint()
+		}
+	case "uniq":
+		return func(decoder *binary.Decoder) (interface{}, error) {
+			if _, err := decoder.UInt8(); err != nil { // skip degree
+				return nil, err
+			}
+			n, err := decoder.Uvarint()
+			if err != nil {
+				return nil, err
+			}
+			return nil, skip(decoder, 4*int(n))
+		}
+	case "uniqExact":
+		size := 16 // the values are hashed to UInt128 unless a single number
+		if len(args) == 1 {
+			if n, ok := fixedSize(nullableBase(args[0])); ok {
+				size = n
+			}
+		}
+		return func(decoder *binary.Decoder) (interface{}, error) {
+			n, err := decoder.Uvarint()
+			if err != nil {
+				return nil, err
+			}
+			if err := skip(decoder, size*int(n)); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	}
+	if len(args) != 1 {
+		return nil
+	}
+	arg := nullableBase(args[0])
+	var read aggregateState
+	switch name {
+	case "sum":
+		if t, ok := sumType(arg); ok {
+			read = fixedState(t)
+		}
+	case "avg":
+		if t, ok := avgType(arg); ok {
+			numerator := fixedState(t)
+			read = func(decoder *binary.Decoder) (interface{}, error) {
+				value, err := numerator(decoder)
+				if err != nil {
+					return nil, err
+				}
+				n, err := decoder.Uvarint()
+				if err != nil {
+					return nil, err
+				}
+				return AvgState{
+					Numerator:   value,
+					Denominator: n,
+				}, nil
+			}
+		}
+	case "min", "max", "any", "anyLast":
+		read = singleValueState(arg)
+	case "quantile", "quantiles", "median":
+		if size, ok := fixedSize(arg); ok {
+			read = func(decoder *binary.Decoder) (interface{}, error) {
+				samples, err := decoder.UInt64() // the size of the sample
+				if err != nil {
+					return nil, err
+				}
+				total, err := decoder.UInt64()
+				if err != nil {
+					return nil, err
+				}
+				if total < samples {
+					samples = total
+				}
+				return nil, skip(decoder, size*int(samples))
+			}
+		}
+	}
+	if read == nil || arg == args[0] {
+		return read
+	}
+	// the state of a Nullable argument is a flag of the nested state
+	return func(decoder *binary.Decoder) (interface{}, error) {
+		if flag, err := decoder.Bool(); err != nil || !flag {
+			return nil, err
+		}
+		return read(decoder)
+	}
+}
+
+// singleValueState reads the state of min, max, any and anyLast, a flag and the value or the size of the string
+func singleValueState(t Type) aggregateState {
+	if t == "String" {
+		return func(decoder *binary.Decoder) (interface{}, error) {
+			size, err := decoder.Int32() // with the terminating zero, -1 if the function has seen no value
+			if err != nil || size < 0 {
+				return nil, err
+			}
+			b := make([]byte, size)
+			if err := decoder.Raw(b); err != nil {
+				return nil, err
+			}
+			return string(bytes.TrimSuffix(b, []byte{0})), nil
+		}
+	}
+	value := fixedState(t)
+	if value == nil {
+		return nil
+	}
+	return func(decoder *binary.Decoder) (interface{}, error) {
+		if has, err := decoder.Bool(); err != nil || !has {
+			return nil, err
+		}
+		return value(decoder)
+	}
+}
+
+// fixedState reads a value of a type with a fixed size
+func fixedState(t Type) aggregateState {
+	if _, ok := fixedSize(t); !ok {
+		return nil
+	}
+	return func(decoder *binary.Decoder) (interface{}, error) {
+		col, err := t.Column()
+		if err != nil {
+			return nil, err
+		}
+		if err := col.Decode(decoder, 1); err != nil {
+			return nil, err
+		}
+		return col.Row(0, false), nil
+	}
+}
+
+// fixedSize returns the size of the values of the type if it is fixed
+func fixedSize(t Type) (int, bool) {
+	switch t {
+	case "Int8", "UInt8", "Bool":
+		return 1, true
+	case "Int16", "UInt16", "Date":
+		return 2, true
+	case "Int32", "UInt32", "Float32", "Date32", "IPv4":
+		return 4, true
+	case "Int64", "UInt64", "Float64":
+		return 8, true
+	case "Int128", "UInt128", "UUID", "IPv6":
+		return 16, true
+	case "Int256", "UInt256":
+		return 32, true
+	}
+	switch s := string(t); {
+	case strings.HasPrefix(s, "Enum8("):
+		return 1, true
+	case strings.HasPrefix(s, "Enum16("):
+		return 2, true
+	case strings.HasPrefix(s, "DateTime64("):
+		return 8, true
+	case s == "DateTime" || strings.HasPrefix(s, "DateTime("):
+		return 4, true
+	case strings.HasPrefix(s, "Decimal("):
+		col, err := (&Decimal{}).parse(t)
+		if err != nil {
+			return 0, false
+		}
+		return col.nobits / 8, true
+	}
+	return 0, false
+}
+
+// sumType returns the type of the sum of the values of the type
+func sumType(t Type) (Type, bool) {
+	switch t {
+	case "Int8", "Int16", "Int32", "Int64":
+		return "Int64", true
+	case "UInt8", "UInt16", "UInt32", "UInt64":
+		return "UInt64", true
+	case "Float32", "Float64":
+		return "Float64", true
+	case "Int128", "UInt128", "Int256", "UInt256":
+		return t, true
+	}
+	if strings.HasPrefix(string(t), "Decimal(") {
+		col, err := (&Decimal{}).parse(t)
+		if err != nil {
+			return "", false
+		}
+		if col.nobits == 256 {
+			return Type(fmt.Sprintf("Decimal(76, %d)", col.scale)), true
+		}
+		return Type(fmt.Sprintf("Decimal(38, %d)", col.scale)), true
+	}
+	return "", false
+}
+
+// avgType returns the type of the numerator of avg, the sum type but for the big integers that are summed as Float64
+func avgType(t Type) (Type, bool) {
+	switch t {
+	case "Int128", "UInt128", "Int256", "UInt256":
+		return "Float64", true
+	}
+	return sumType(t)
+}
+
+func nullableBase(t Type) Type {
+	if strings.HasPrefix(string(t), "Nullable(") {
+		return Type(t.params())
+	}
+	return t
+}
+
+func (col *AggregateFunction) Type() Type {
+	return col.chType
+}
+
+func (col *AggregateFunction) ScanType() reflect.Type {
+	return scanTypeAggregateState
+}
+
+func (col *AggregateFunction) Rows() int {
+	return len(col.rows)
+}
+
+func (col *AggregateFunction) Row(i int, ptr bool) interface{} {
+	value := col.rows[i].State
+	if ptr {
+		return &value
+	}
+	return value
+}
+
+// ScanRow scans the state into *[]byte or *AggregateState, or the decoded state into a value of its type
+func (col *AggregateFunction) ScanRow(dest interface{}, row int) error {
+	switch d := dest.(type) {
+	case *[]byte:
+		*d = col.rows[row].State
+		return nil
+	case *AggregateState:
+		*d = col.rows[row]
+		return nil
+	case *interface{}:
+		*d = col.rows[row].State
+		return nil
+	}
+	if value := reflect.ValueOf(col.rows[row].Value); value.IsValid() {
+		if d := reflect.ValueOf(dest); d.Kind() == reflect.Ptr && !d.IsNil() && value.Type().AssignableTo(d.Elem().Type()) {
+			d.Elem().Set(value)
+			return nil
+		}
+	}
+	return &ColumnConverterError{
+		Op:   "ScanRow",
+		To:   fmt.Sprintf("%T", dest),
+		From: string(col.chType),
+	}
+}
+
+func (col *AggregateFunction) Append(v interface{}) (nulls []uint8, err error) {
+	switch v := v.(type) {
+	case [][]byte:
+		for _, state := range v {
+			if err := col.AppendRow(state); err != nil {
+				return nil, err
+			}
+		}
+	case []AggregateState:
+		for _, state := range v {
+			if err := col.AppendRow(state); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	return nil, nil
+}
+
+// AppendRow appends a serialized state, as scanned from a column of the same type
+func (col *AggregateFunction) AppendRow(v interface{}) error {
+	var state []byte
+	switch v := v.(type) {
+	case []byte:
+		state = v
+	case *[]byte:
+		if v != nil {
+			state = *v
+		}
+	case AggregateState:
+		state = v.State
+	case *AggregateState:
+		if v != nil {
+			state = v.State
+		}
+	default:
+		return &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	reader := bytes.NewReader(state)
+	row, err := col.readState(binary.NewDecoder(reader))
+	if err == nil && reader.Len() != 0 {
+		err = fmt.Errorf("%d bytes after the state", reader.Len())
+	}
+	if err != nil {
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        fmt.Errorf("invalid state: %w", err),
+		}
+	}
+	col.rows = append(col.rows, row)
+	return nil
+}
+
+func (col *AggregateFunction) readState(decoder *binary.Decoder) (AggregateState, error) {
+	reader := stateReader{
+		decoder: decoder,
+		state:   []byte{},
+	}
+	value, err := col.read(binary.NewDecoder(&reader))
+	if err != nil {
+		return AggregateState{}, err
+	}
+	return AggregateState{
+		State: reader.state,
+		Value: value,
+	}, nil
+}
+
+func (col *AggregateFunction) Decode(decoder *binary.Decoder, rows int) error {
+	for i := 0; i < rows; i++ {
+		row, err := col.readState(decoder)
+		if err != nil {
+			return err
+		}
+		col.rows = append(col.rows, row)
+	}
+	return nil
+}
+
+func (col *AggregateFunction) Encode(encoder *binary.Encoder) error {
+	for _, row := range col.rows {
+		if err := encoder.Raw(row.State); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ Interface = (*AggregateFunction)(nil)
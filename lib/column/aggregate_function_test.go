@@ -0,0 +1,140 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package column
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// varUInt is a value encoded as a varint in aggregateStates
+type varUInt uint64
+
+// aggregateStates encodes the values of the states of the rows
+func aggregateStates(t *testing.T, states ...[]interface{}) [][]byte {
+	var encoded [][]byte
+	for _, state := range states {
+		var (
+			buf     bytes.Buffer
+			encoder = binary.NewEncoder(&buf)
+		)
+		for _, v := range state {
+			switch v := v.(type) {
+			case uint8:
+				require.NoError(t, encoder.UInt8(v))
+			case int16:
+				require.NoError(t, encoder.Int16(v))
+			case int32:
+				require.NoError(t, encoder.Int32(v))
+			case int64:
+				require.NoError(t, encoder.Int64(v))
+			case uint64:
+				require.NoError(t, encoder.UInt64(v))
+			case float64:
+				require.NoError(t, encoder.Float64(v))
+			case string:
+				require.NoError(t, encoder.Raw([]byte(v)))
+			case varUInt:
+				require.NoError(t, encoder.Uvarint(uint64(v)))
+			}
+		}
+		require.NoError(t, encoder.Flush())
+		encoded = append(encoded, buf.Bytes())
+	}
+	return encoded
+}
+
+func TestAggregateFunction(t *testing.T) {
+	tests := []struct {
+		chType Type
+		states [][]interface{}
+		values []interface{}
+	}{
+		{"AggregateFunction(count)", [][]interface{}{{varUInt(3)}, {varUInt(300)}}, []interface{}{uint64(3), uint64(300)}},
+		{"AggregateFunction(sum, UInt32)", [][]interface{}{{uint64(7)}}, []interface{}{uint64(7)}},
+		{"AggregateFunction(avg, Int32)", [][]interface{}{{int64(-9), varUInt(2)}}, []interface{}{AvgState{Numerator: int64(-9), Denominator: 2}}},
+		{"AggregateFunction(avg, Int128)", [][]interface{}{{float64(-9.5), varUInt(2)}}, []interface{}{AvgState{Numerator: float64(-9.5), Denominator: 2}}},
+		{"AggregateFunction(max, String)", [][]interface{}{{int32(4), "abc\x00"}, {int32(-1)}}, []interface{}{"abc", nil}},
+		{"AggregateFunction(min, Nullable(Int16))", [][]interface{}{{uint8(1), uint8(1), int16(-5)}, {uint8(0)}, {uint8(1), uint8(0)}}, []interface{}{int16(-5), nil, nil}},
+		{"AggregateFunction(uniqExact, String)", [][]interface{}{{varUInt(2), "0123456789abcdef0123456789abcdef"}}, []interface{}{uint64(2)}},
+		{"AggregateFunction(uniqExact, Nullable(UInt16))", [][]interface{}{{varUInt(3), "abcdef"}}, []interface{}{uint64(3)}},
+		{"AggregateFunction(uniq, UInt64)", [][]interface{}{{uint8(0), varUInt(2), "abcdefgh"}}, []interface{}{nil}},
+		{"AggregateFunction(quantiles(0.5, 0.9), Float64)", [][]interface{}{{uint64(8192), uint64(2), float64(1), float64(2)}}, []interface{}{nil}},
+		{"AggregateFunction(1, quantile(0.5), UInt8)", [][]interface{}{{uint64(2), uint64(5), "ab"}}, []interface{}{nil}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.chType), func(t *testing.T) {
+			states := aggregateStates(t, test.states...)
+			col, err := test.chType.Column()
+			require.NoError(t, err)
+			require.NoError(t, col.Decode(binary.NewDecoder(bytes.NewReader(bytes.Join(states, nil))), len(states)))
+			require.Equal(t, len(states), col.Rows())
+			for i, state := range states {
+				var scanned AggregateState
+				require.NoError(t, col.ScanRow(&scanned, i))
+				assert.Equal(t, AggregateState{State: state, Value: test.values[i]}, scanned)
+				assert.Equal(t, state, col.Row(i, false))
+			}
+
+			appended, err := test.chType.Column()
+			require.NoError(t, err)
+			for i := range states {
+				require.NoError(t, appended.AppendRow(col.Row(i, false)))
+			}
+			var buf bytes.Buffer
+			encoder := binary.NewEncoder(&buf)
+			require.NoError(t, appended.Encode(encoder))
+			require.NoError(t, encoder.Flush())
+			assert.Equal(t, bytes.Join(states, nil), buf.Bytes())
+		})
+	}
+}
+
+func TestAggregateFunctionScan(t *testing.T) {
+	col, err := Type("AggregateFunction(avg, Decimal(9, 2))").Column()
+	require.NoError(t, err)
+	state := aggregateStates(t, []interface{}{"0123456789abcdef", varUInt(4)})[0]
+	require.NoError(t, col.AppendRow(state))
+
+	var avg AvgState
+	require.NoError(t, col.ScanRow(&avg, 0))
+	assert.Equal(t, uint64(4), avg.Denominator, "the sum of a Decimal32 is a Decimal128")
+	var raw []byte
+	require.NoError(t, col.ScanRow(&raw, 0))
+	assert.Equal(t, state, raw)
+	var n uint64
+	assert.Error(t, col.ScanRow(&n, 0))
+
+	assert.Error(t, col.AppendRow(state[:10]), "truncated")
+	assert.Error(t, col.AppendRow(append(state, 0)), "trailing bytes")
+	assert.Error(t, col.AppendRow("state"))
+	assert.Equal(t, 1, col.Rows())
+
+	for _, unsupported := range []Type{
+		"AggregateFunction(sumIf, UInt64, UInt8)",
+		"AggregateFunction(groupArray, String)",
+		"AggregateFunction(max, Array(String))",
+		"AggregateFunction()",
+	} {
+		_, err := unsupported.Column()
+		assert.Error(t, err, string(unsupported))
+	}
+}
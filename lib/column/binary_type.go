@@ -0,0 +1,310 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// the codes of the binary encoding of the types with parameters or elements, see DataTypesBinaryEncoding
+const (
+	binaryTypeNothing        byte = 0x00
+	binaryTypeDateTimeZone   byte = 0x12
+	binaryTypeDateTime64     byte = 0x13
+	binaryTypeDateTime64Zone byte = 0x14
+	binaryTypeFixedString    byte = 0x16
+	binaryTypeDecimal32      byte = 0x19
+	binaryTypeDecimal256     byte = 0x1c
+	binaryTypeArray          byte = 0x1e
+	binaryTypeTuple          byte = 0x1f
+	binaryTypeNamedTuple     byte = 0x20
+	binaryTypeNullable       byte = 0x23
+	binaryTypeLowCardinality byte = 0x26
+	binaryTypeMap            byte = 0x27
+)
+
+// binaryTypes are the codes of the types without parameters
+var binaryTypes = map[byte]Type{
+	0x01: "UInt8",
+	0x02: "UInt16",
+	0x03: "UInt32",
+	0x04: "UInt64",
+	0x05: "UInt128",
+	0x06: "UInt256",
+	0x07: "Int8",
+	0x08: "Int16",
+	0x09: "Int32",
+	0x0a: "Int64",
+	0x0b: "Int128",
+	0x0c: "Int256",
+	0x0d: "Float32",
+	0x0e: "Float64",
+	0x0f: "Date",
+	0x10: "Date32",
+	0x11: "DateTime",
+	0x15: "String",
+	0x1d: "UUID",
+	0x28: "IPv4",
+	0x29: "IPv6",
+	0x2d: "Bool",
+}
+
+// binaryType is a type read from its binary encoding, the values of the JSON shared data and of SharedVariant are
+// the binary encoding of their type followed by the value
+type binaryType struct {
+	code   byte
+	chType Type          // the type of the column that reads a value of the types without elements
+	elems  []*binaryType // the nested type of Array, Nullable and LowCardinality, the key and the value of Map, the elements of Tuple
+	names  []string      // the names of the elements of a named Tuple
+}
+
+// readBinaryValue reads a value in its binary encoding, the arrays and the unnamed tuples are []interface{},
+// the maps and the named tuples map[string]interface{}
+func readBinaryValue(data []byte) (interface{}, error) {
+	decoder := binary.NewDecoder(bytes.NewReader(data))
+	t, err := readBinaryType(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return t.read(decoder)
+}
+
+func readBinaryType(decoder *binary.Decoder) (*binaryType, error) {
+	code, err := decoder.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	t := &binaryType{code: code}
+	if chType, found := binaryTypes[code]; found {
+		t.chType = chType
+		return t, nil
+	}
+	switch {
+	case code == binaryTypeNothing:
+	case code == binaryTypeDateTimeZone:
+		tz, err := decoder.String()
+		if err != nil {
+			return nil, err
+		}
+		t.chType = Type(fmt.Sprintf("DateTime('%s')", tz))
+	case code == binaryTypeDateTime64, code == binaryTypeDateTime64Zone:
+		precision, err := decoder.UInt8()
+		if err != nil {
+			return nil, err
+		}
+		t.chType = Type(fmt.Sprintf("DateTime64(%d)", precision))
+		if code == binaryTypeDateTime64Zone {
+			tz, err := decoder.String()
+			if err != nil {
+				return nil, err
+			}
+			t.chType = Type(fmt.Sprintf("DateTime64(%d, '%s')", precision, tz))
+		}
+	case code == binaryTypeFixedString:
+		n, err := decoder.Uvarint()
+		if err != nil {
+			return nil, err
+		}
+		t.chType = Type(fmt.Sprintf("FixedString(%d)", n))
+	case code >= binaryTypeDecimal32 && code <= binaryTypeDecimal256:
+		precision, err := decoder.UInt8()
+		if err != nil {
+			return nil, err
+		}
+		scale, err := decoder.UInt8()
+		if err != nil {
+			return nil, err
+		}
+		t.chType = Type(fmt.Sprintf("Decimal(%d, %d)", precision, scale))
+	case code == binaryTypeArray, code == binaryTypeNullable, code == binaryTypeLowCardinality, code == binaryTypeMap:
+		elems := 1
+		if code == binaryTypeMap {
+			elems = 2
+		}
+		for i := 0; i < elems; i++ {
+			elem, err := readBinaryType(decoder)
+			if err != nil {
+				return nil, err
+			}
+			t.elems = append(t.elems, elem)
+		}
+	case code == binaryTypeTuple, code == binaryTypeNamedTuple:
+		n, err := decoder.Uvarint()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < int(n); i++ {
+			if code == binaryTypeNamedTuple {
+				name, err := decoder.String()
+				if err != nil {
+					return nil, err
+				}
+				t.names = append(t.names, name)
+			}
+			elem, err := readBinaryType(decoder)
+			if err != nil {
+				return nil, err
+			}
+			t.elems = append(t.elems, elem)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported binary encoded type 0x%02x", code)
+	}
+	return t, nil
+}
+
+// read reads a value of the type, the binary encoding of a value is the one of a column of a single row
+// but for the sizes of Array and Map, and the NULL flag of Nullable
+func (t *binaryType) read(decoder *binary.Decoder) (interface{}, error) {
+	switch t.code {
+	case binaryTypeNothing:
+		return nil, nil
+	case binaryTypeNullable:
+		if null, err := decoder.Bool(); err != nil || null {
+			return nil, err
+		}
+		return t.elems[0].read(decoder)
+	case binaryTypeLowCardinality:
+		return t.elems[0].read(decoder)
+	case binaryTypeArray, binaryTypeMap:
+		n, err := decoder.Uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if t.code == binaryTypeArray {
+			values := make([]interface{}, 0, n)
+			for i := 0; i < int(n); i++ {
+				value, err := t.elems[0].read(decoder)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			return values, nil
+		}
+		obj := make(map[string]interface{}, n)
+		for i := 0; i < int(n); i++ {
+			key, err := t.elems[0].read(decoder)
+			if err != nil {
+				return nil, err
+			}
+			value, err := t.elems[1].read(decoder)
+			if err != nil {
+				return nil, err
+			}
+			obj[fmt.Sprint(key)] = value
+		}
+		return obj, nil
+	case binaryTypeTuple, binaryTypeNamedTuple:
+		var (
+			values = make([]interface{}, 0, len(t.elems))
+			obj    = make(map[string]interface{}, len(t.elems))
+		)
+		for i, elem := range t.elems {
+			value, err := elem.read(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if t.code == binaryTypeNamedTuple {
+				obj[t.names[i]] = value
+			}
+			values = append(values, value)
+		}
+		if t.code == binaryTypeNamedTuple {
+			return obj, nil
+		}
+		return values, nil
+	}
+	col, err := t.chType.Column()
+	if err != nil {
+		return nil, err
+	}
+	if err := col.Decode(decoder, 1); err != nil {
+		return nil, err
+	}
+	return col.Row(0, false), nil
+}
+
+// writeBinaryValue writes the binary encoding of the type then the one of the value, for the types inferred
+// from the JSON values: Int64, UInt64, Float64, String, Bool and the Array and Nullable of them.
+// The value has the scan type of the column of the type.
+func writeBinaryValue(encoder *binary.Encoder, t Type, v reflect.Value) error {
+	if err := writeBinaryType(encoder, t); err != nil {
+		return err
+	}
+	return writeBinary(encoder, t, v)
+}
+
+func writeBinaryType(encoder *binary.Encoder, t Type) error {
+	switch {
+	case strings.HasPrefix(string(t), "Array("):
+		if err := encoder.Byte(binaryTypeArray); err != nil {
+			return err
+		}
+		return writeBinaryType(encoder, Type(t.params()))
+	case strings.HasPrefix(string(t), "Nullable("):
+		if err := encoder.Byte(binaryTypeNullable); err != nil {
+			return err
+		}
+		return writeBinaryType(encoder, Type(t.params()))
+	}
+	for code, chType := range binaryTypes {
+		if chType == t {
+			return encoder.Byte(code)
+		}
+	}
+	return fmt.Errorf("no binary encoding for the type %s", t)
+}
+
+func writeBinary(encoder *binary.Encoder, t Type, v reflect.Value) error {
+	switch {
+	case strings.HasPrefix(string(t), "Array("):
+		if err := encoder.Uvarint(uint64(v.Len())); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := writeBinary(encoder, Type(t.params()), v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case strings.HasPrefix(string(t), "Nullable("):
+		if err := encoder.Bool(v.IsNil()); err != nil || v.IsNil() {
+			return err
+		}
+		return writeBinary(encoder, Type(t.params()), v.Elem())
+	}
+	switch t {
+	case "Int64":
+		return encoder.Int64(v.Int())
+	case "UInt64":
+		return encoder.UInt64(v.Uint())
+	case "Float64":
+		return encoder.Float64(v.Float())
+	case "String":
+		return encoder.String(v.String())
+	case "Bool":
+		return encoder.Bool(v.Bool())
+	}
+	return fmt.Errorf("no binary encoding for the type %s", t)
+}
@@ -122,6 +122,8 @@ func (t Type) Column() (Interface, error) {
 		return &Point{}, nil
 	case "String":
 		return &String{}, nil
+	case "Object('json')":
+		return &Object{chType: t}, nil
 	}
 
 	switch strType := string(t); {
@@ -143,8 +145,16 @@ func (t Type) Column() (Interface, error) {
 		return (&FixedString{}).parse(t)
 	case strings.HasPrefix(string(t), "LowCardinality"):
 		return (&LowCardinality{}).parse(t)
+	case strings.HasPrefix(strType, "Variant("):
+		return (&Variant{}).parse(t)
+	case strType == "Dynamic" || strings.HasPrefix(strType, "Dynamic("):
+		return (&Dynamic{}).parse(t)
+	case strType == "JSON" || strings.HasPrefix(strType, "JSON("):
+		return (&JSON{}).parse(t)
 	case strings.HasPrefix(string(t), "SimpleAggregateFunction"):
 		return (&SimpleAggregateFunction{}).parse(t)
+	case strings.HasPrefix(string(t), "AggregateFunction("):
+		return (&AggregateFunction{}).parse(t)
 	case strings.HasPrefix(string(t), "Enum8") || strings.HasPrefix(string(t), "Enum16"):
 		return Enum(t)
 	case strings.HasPrefix(string(t), "DateTime64"):
@@ -152,6 +162,9 @@ func (t Type) Column() (Interface, error) {
 	case strings.HasPrefix(strType, "DateTime") && !strings.HasPrefix(strType, "DateTime64"):
 		return (&DateTime{}).parse(t)
 	}
+	if col, ok, err := registered(t); ok {
+		return col, err
+	}
 	return nil, &UnsupportedColumnTypeError{
 		t: t,
 	}
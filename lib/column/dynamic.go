@@ -0,0 +1,385 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// the versions of the Dynamic structure written first in the state prefix
+const (
+	dynamicV1 uint64 = 1 // with the maximum number of types before the types
+	dynamicV2 uint64 = 2
+)
+
+// sharedVariant holds the values of the types over the limit of the column, each value is
+// the binary encoding of its type followed by the value
+const sharedVariant Type = "SharedVariant"
+
+// dynamicMaxTypes is the max_types of Dynamic without parameters
+const dynamicMaxTypes = 32
+
+// DynamicValue is a value of a Dynamic column with its type, Type is empty for NULL.
+// Appending a DynamicValue sets the type instead of inferring it from the Go value.
+type DynamicValue struct {
+	Type  Type
+	Value interface{}
+}
+
+// Dynamic is a Dynamic or Dynamic(max_types=N) column. The types of a block are listed in the state prefix and the
+// rows are the Variant of these types and SharedVariant, the values of SharedVariant are read as their binary encoding.
+// Appended rows are kept until the block is written since the types are collected from all of them.
+type Dynamic struct {
+	chType   Type
+	maxTypes int
+	variant  *Variant
+	decoded  int
+	values   []DynamicValue
+}
+
+func (col *Dynamic) parse(t Type) (_ Interface, err error) {
+	col.chType, col.maxTypes = t, dynamicMaxTypes
+	if param := strings.TrimSpace(t.params()); strings.HasPrefix(param, "max_types=") {
+		if col.maxTypes, err = strconv.Atoi(strings.TrimPrefix(param, "max_types=")); err != nil {
+			return nil, &Error{
+				ColumnType: string(t),
+				Err:        err,
+			}
+		}
+	}
+	return col, nil
+}
+
+func (col *Dynamic) Type() Type {
+	return col.chType
+}
+
+func (col *Dynamic) ScanType() reflect.Type {
+	return scanTypeVariant
+}
+
+func (col *Dynamic) Rows() int {
+	return col.decoded + len(col.values)
+}
+
+func (col *Dynamic) Row(i int, ptr bool) interface{} {
+	if i >= col.decoded {
+		return col.values[i-col.decoded].Value
+	}
+	return col.variant.Row(i, ptr)
+}
+
+// ScanRow scans the value into *DynamicValue, *interface{} or the type of the value,
+// dest is left unchanged by NULL as with Nullable
+func (col *Dynamic) ScanRow(dest interface{}, row int) error {
+	switch d := dest.(type) {
+	case *DynamicValue:
+		*d = col.value(row)
+		return nil
+	case *interface{}:
+		*d = col.Row(row, false)
+		return nil
+	}
+	if row >= col.decoded {
+		return &ColumnConverterError{
+			Op:   "ScanRow",
+			To:   fmt.Sprintf("%T", dest),
+			From: string(col.chType),
+		}
+	}
+	return col.variant.ScanRow(dest, row)
+}
+
+func (col *Dynamic) value(row int) DynamicValue {
+	if row >= col.decoded {
+		return col.values[row-col.decoded]
+	}
+	var value VariantValue
+	col.variant.ScanRow(&value, row)
+	return DynamicValue{
+		Type:  value.Type,
+		Value: value.Value,
+	}
+}
+
+func (col *Dynamic) Append(v interface{}) (nulls []uint8, err error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "value must be a slice",
+		}
+	}
+	for i := 0; i < value.Len(); i++ {
+		if err := col.AppendRow(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// AppendRow appends the value with the type inferred from its Go type: the numbers, bool, string, time.Time
+// (DateTime64(9)), uuid.UUID, net.IP (IPv6) and the slices and maps of them. A DynamicValue sets the type
+func (col *Dynamic) AppendRow(v interface{}) error {
+	switch value := v.(type) {
+	case DynamicValue:
+		if len(value.Type) == 0 && value.Value != nil {
+			return &Error{
+				ColumnType: string(col.chType),
+				Err:        errors.New("the type of the value is not set"),
+			}
+		}
+		col.values = append(col.values, value)
+		return nil
+	case *DynamicValue:
+		if value != nil {
+			return col.AppendRow(*value)
+		}
+	}
+	value := reflect.ValueOf(v)
+	for value.IsValid() && value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if !value.IsValid() || value.Kind() == reflect.Ptr && value.IsNil() {
+		col.values = append(col.values, DynamicValue{})
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.Int:
+		value = value.Convert(scanTypeInt64)
+	case reflect.Uint:
+		value = value.Convert(scanTypeUInt64)
+	}
+	t, ok := dynamicType(value.Type())
+	if !ok {
+		return &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "use column.DynamicValue to set the type",
+		}
+	}
+	col.values = append(col.values, DynamicValue{
+		Type:  t,
+		Value: value.Interface(),
+	})
+	return nil
+}
+
+// dynamicType returns the type of the column that takes the values of the Go type as they are
+func dynamicType(t reflect.Type) (Type, bool) {
+	switch t {
+	case scanTypeTime:
+		return "DateTime64(9)", true
+	case scanTypeUUID:
+		return "UUID", true
+	case scanTypeIP:
+		return "IPv6", true
+	case scanTypeBool:
+		return "Bool", true
+	case scanTypeString:
+		return "String", true
+	case scanTypeFloat32:
+		return "Float32", true
+	case scanTypeFloat64:
+		return "Float64", true
+	case scanTypeInt8:
+		return "Int8", true
+	case scanTypeInt16:
+		return "Int16", true
+	case scanTypeInt32:
+		return "Int32", true
+	case scanTypeInt64:
+		return "Int64", true
+	case scanTypeUInt8:
+		return "UInt8", true
+	case scanTypeUInt16:
+		return "UInt16", true
+	case scanTypeUInt32:
+		return "UInt32", true
+	case scanTypeUInt64:
+		return "UInt64", true
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		if elem, ok := dynamicType(t.Elem()); ok {
+			return "Array(" + elem + ")", true
+		}
+	case reflect.Map:
+		key, ok := dynamicType(t.Key())
+		if !ok {
+			break
+		}
+		if value, ok := dynamicType(t.Elem()); ok {
+			return "Map(" + key + ", " + value + ")", true
+		}
+	}
+	return "", false
+}
+
+func (col *Dynamic) Decode(decoder *binary.Decoder, rows int) error {
+	if col.variant == nil {
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        errors.New("the state prefix was not read"),
+		}
+	}
+	if err := col.variant.Decode(decoder, rows); err != nil {
+		return err
+	}
+	col.decoded += rows
+	return nil
+}
+
+func (col *Dynamic) Encode(encoder *binary.Encoder) error {
+	switch {
+	case col.Rows() == 0:
+		return nil
+	case len(col.values) != 0:
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        errors.New("the state prefix was not written"),
+		}
+	}
+	return col.variant.Encode(encoder)
+}
+
+func (col *Dynamic) ReadStatePrefix(decoder *binary.Decoder) error {
+	version, err := decoder.UInt64()
+	if err != nil {
+		return err
+	}
+	switch version {
+	case dynamicV1:
+		if _, err := decoder.Uvarint(); err != nil { // max_types
+			return err
+		}
+	case dynamicV2:
+	default:
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        fmt.Errorf("unsupported structure version %d", version),
+		}
+	}
+	n, err := decoder.Uvarint()
+	if err != nil {
+		return err
+	}
+	types := make([]Type, 0, n)
+	for i := 0; i < int(n); i++ {
+		t, err := decoder.String()
+		if err != nil {
+			return err
+		}
+		types = append(types, Type(t))
+	}
+	if col.variant, err = dynamicVariant(types); err != nil {
+		return err
+	}
+	return col.variant.ReadStatePrefix(decoder)
+}
+
+// WriteStatePrefix lists the types of the rows and builds their Variant
+func (col *Dynamic) WriteStatePrefix(encoder *binary.Encoder) error {
+	if col.Rows() == 0 {
+		return nil
+	}
+	var (
+		types  []Type
+		values = make([]DynamicValue, 0, col.Rows())
+		found  = make(map[Type]bool)
+	)
+	for i := 0; i < col.Rows(); i++ {
+		value := col.value(i)
+		if len(value.Type) != 0 && value.Type != sharedVariant && !found[value.Type] {
+			found[value.Type] = true
+			types = append(types, value.Type)
+		}
+		values = append(values, value)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	variant, err := dynamicVariant(types)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if err := variant.appendVariant(VariantValue(value)); err != nil {
+			return err
+		}
+	}
+	col.variant, col.decoded, col.values = variant, len(values), nil
+	if err := encoder.UInt64(dynamicV1); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(uint64(col.maxTypes)); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(uint64(len(types))); err != nil {
+		return err
+	}
+	for _, t := range types {
+		if err := encoder.String(string(t)); err != nil {
+			return err
+		}
+	}
+	return variant.WriteStatePrefix(encoder)
+}
+
+// dynamicVariant returns the Variant of the types and SharedVariant, sorted by name
+func dynamicVariant(types []Type) (*Variant, error) {
+	variant := &Variant{
+		types: append(append(make([]Type, 0, len(types)+1), types...), sharedVariant),
+	}
+	sort.Slice(variant.types, func(i, j int) bool {
+		return variant.types[i] < variant.types[j]
+	})
+	names := make([]string, 0, len(variant.types))
+	for _, t := range variant.types {
+		var (
+			column Interface = &String{}
+			err    error
+		)
+		if t != sharedVariant {
+			if column, err = t.Column(); err != nil {
+				return nil, err
+			}
+		}
+		variant.columns, names = append(variant.columns, column), append(names, string(t))
+	}
+	if len(variant.types) >= int(variantNull) {
+		return nil, fmt.Errorf("clickhouse: too many dynamic types %d", len(types))
+	}
+	variant.chType = Type("Variant(" + strings.Join(names, ", ") + ")")
+	return variant, nil
+}
+
+var (
+	_ Interface           = (*Dynamic)(nil)
+	_ CustomSerialization = (*Dynamic)(nil)
+)
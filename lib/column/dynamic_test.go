@@ -0,0 +1,137 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package column
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+func TestDynamic(t *testing.T) {
+	col, err := Type("Dynamic(max_types=8)").Column()
+	require.NoError(t, err)
+	n := 7
+	for _, v := range []interface{}{
+		"text",
+		int64(42),
+		nil,
+		[]string{"a", "b"},
+		&n,
+		DynamicValue{Type: "LowCardinality(String)", Value: "low"},
+		map[string]uint8{"key": 1},
+		(*string)(nil),
+		true,
+	} {
+		require.NoError(t, col.AppendRow(v))
+	}
+	assert.Error(t, col.AppendRow(struct{}{}), "no type for a struct")
+	assert.Error(t, col.AppendRow(DynamicValue{Value: 1}), "the type of a value must be set")
+
+	decoded, data := roundTrip(t, col)
+	var (
+		decoder  = binary.NewDecoder(bytes.NewReader(data))
+		expected = []string{"Array(String)", "Bool", "Int64", "LowCardinality(String)", "Map(String, UInt8)", "String"}
+	)
+	version, err := decoder.UInt64()
+	require.NoError(t, err)
+	assert.Equal(t, dynamicV1, version)
+	maxTypes, err := decoder.Uvarint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), maxTypes)
+	types, err := decoder.Uvarint()
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(expected)), types, "SharedVariant is not listed")
+	for _, e := range expected {
+		name, err := decoder.String()
+		require.NoError(t, err)
+		assert.Equal(t, e, name)
+	}
+	assert.Equal(t, Type("Variant(Array(String), Bool, Int64, LowCardinality(String), Map(String, UInt8), SharedVariant, String)"), decoded.(*Dynamic).variant.Type())
+
+	values := []DynamicValue{
+		{Type: "String", Value: "text"},
+		{Type: "Int64", Value: int64(42)},
+		{},
+		{Type: "Array(String)", Value: []string{"a", "b"}},
+		{Type: "Int64", Value: int64(7)},
+		{Type: "LowCardinality(String)", Value: "low"},
+		{Type: "Map(String, UInt8)", Value: map[string]uint8{"key": 1}},
+		{},
+		{Type: "Bool", Value: true},
+	}
+	require.Equal(t, len(values), decoded.Rows())
+	for i, v := range values {
+		var value DynamicValue
+		require.NoError(t, decoded.ScanRow(&value, i))
+		assert.Equal(t, v, value, "row %d", i)
+		assert.Equal(t, v.Value, decoded.Row(i, false), "row %d", i)
+	}
+
+	var s string
+	require.NoError(t, decoded.ScanRow(&s, 0))
+	assert.Equal(t, "text", s)
+	require.NoError(t, decoded.ScanRow(&s, 2), "NULL leaves the destination unchanged")
+	assert.Equal(t, "text", s)
+	assert.Error(t, decoded.ScanRow(&s, 1))
+
+	// the decoded rows are written again with their types
+	again, _ := roundTrip(t, decoded)
+	for i, v := range values {
+		assert.Equal(t, v.Value, again.Row(i, false), "row %d", i)
+	}
+}
+
+func TestDynamicServerTypes(t *testing.T) {
+	var (
+		buf     bytes.Buffer
+		encoder = binary.NewEncoder(&buf)
+	)
+	// V1 with max_types, the discriminators index Variant(Int64, SharedVariant, String)
+	require.NoError(t, encoder.UInt64(dynamicV1))
+	require.NoError(t, encoder.Uvarint(32))
+	require.NoError(t, encoder.Uvarint(2))
+	require.NoError(t, encoder.String("String"))
+	require.NoError(t, encoder.String("Int64"))
+	require.NoError(t, encoder.UInt64(variantBasicMode))
+	require.NoError(t, encoder.Raw([]byte{2, 0, variantNull, 1}))
+	require.NoError(t, encoder.Int64(42))
+	require.NoError(t, encoder.String("\x0a\x01")) // a binary encoded UInt8
+	require.NoError(t, encoder.String("text"))
+	require.NoError(t, encoder.Flush())
+
+	col, err := Type("Dynamic").Column()
+	require.NoError(t, err)
+	decoder := binary.NewDecoder(&buf)
+	require.NoError(t, col.(CustomSerialization).ReadStatePrefix(decoder))
+	require.NoError(t, col.Decode(decoder, 4))
+	assert.Equal(t, []interface{}{"text", int64(42), nil, "\x0a\x01"}, []interface{}{col.Row(0, false), col.Row(1, false), col.Row(2, false), col.Row(3, false)})
+	var value DynamicValue
+	require.NoError(t, col.ScanRow(&value, 3))
+	assert.Equal(t, sharedVariant, value.Type)
+
+	var unsupported bytes.Buffer
+	encoder = binary.NewEncoder(&unsupported)
+	require.NoError(t, encoder.UInt64(3))
+	require.NoError(t, encoder.Flush())
+	col, err = Type("Dynamic").Column()
+	require.NoError(t, err)
+	assert.Error(t, col.(CustomSerialization).ReadStatePrefix(binary.NewDecoder(&unsupported)))
+}
@@ -0,0 +1,988 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// the versions of the JSON serialization, written first in the state prefix
+const (
+	jsonV1     uint64 = 0 // with max_dynamic_paths before the dynamic paths
+	jsonString uint64 = 1 // the rows are JSON texts
+	jsonV2     uint64 = 2
+)
+
+// the defaults of the parameters of the JSON type
+const (
+	jsonMaxDynamicPaths = 1024
+	jsonMaxDynamicTypes = 32
+)
+
+var scanTypeJSON = reflect.TypeOf(map[string]interface{}{})
+
+// JSON is the JSON type of the servers from 24.8, JSON(max_dynamic_paths=N, max_dynamic_types=M, path Type,
+// SKIP path, SKIP REGEXP 'regexp'). The paths declared with a type are the typed paths, each one a column of its type.
+// The other paths of the rows are the dynamic paths of the block, listed in the state prefix, each one a Dynamic
+// column. Over max_dynamic_paths the paths and their binary encoded values are in the shared data of the rows.
+// The rows are read as map[string]interface{}, the paths are split on the dots into nested objects and the NULL
+// values are left out. Appended rows are kept until the block is written since the paths are collected from all
+// of them, the numbers are Int64, UInt64 or Float64 and the arrays of objects Array(JSON).
+// The blocks are written in the version 1 of the serialization, the one that the servers from 24.8 read.
+type JSON struct {
+	chType          Type
+	maxDynamicPaths int
+	maxDynamicTypes int
+	typed           []jsonTypedPath // sorted by path
+	skip            []string
+	skipRegexp      []*regexp.Regexp
+	version         uint64
+	paths           []string // the dynamic paths, sorted
+	dynamic         []*Dynamic
+	shared          *jsonShared
+	text            String
+	encoded         int // the rows written to the columns of the paths by WriteStatePrefix
+	rows            []map[string]interface{}
+}
+
+type jsonTypedPath struct {
+	path   string
+	chType Type
+	column Interface
+}
+
+// jsonShared is the shared data of the rows, the paths and their binary encoded values
+// serialized as Array(Tuple(paths String, values String))
+type jsonShared struct {
+	offsets UInt64
+	paths   String
+	values  String
+}
+
+func (col *JSON) parse(t Type) (_ Interface, err error) {
+	col.chType, col.maxDynamicPaths, col.maxDynamicTypes = t, jsonMaxDynamicPaths, jsonMaxDynamicTypes
+	for _, param := range splitElements(t.params()) {
+		switch param = strings.TrimSpace(param); {
+		case strings.HasPrefix(param, "max_dynamic_paths="):
+			col.maxDynamicPaths, err = strconv.Atoi(strings.TrimPrefix(param, "max_dynamic_paths="))
+		case strings.HasPrefix(param, "max_dynamic_types="):
+			col.maxDynamicTypes, err = strconv.Atoi(strings.TrimPrefix(param, "max_dynamic_types="))
+		case strings.HasPrefix(param, "SKIP REGEXP "):
+			var re *regexp.Regexp
+			if re, err = regexp.Compile(unquoteString(strings.TrimPrefix(param, "SKIP REGEXP "))); err == nil {
+				col.skipRegexp = append(col.skipRegexp, re)
+			}
+		case strings.HasPrefix(param, "SKIP "):
+			name, _ := tupleElement(strings.TrimPrefix(param, "SKIP ") + " Nothing")
+			col.skip = append(col.skip, name)
+		default:
+			name, pathType := tupleElement(param)
+			if len(name) == 0 {
+				return nil, &UnsupportedColumnTypeError{
+					t: t,
+				}
+			}
+			col.typed = append(col.typed, jsonTypedPath{
+				path:   name,
+				chType: Type(pathType),
+			})
+		}
+		if err != nil {
+			return nil, &Error{
+				ColumnType: string(t),
+				Err:        err,
+			}
+		}
+	}
+	sort.Slice(col.typed, func(i, j int) bool {
+		return col.typed[i].path < col.typed[j].path
+	})
+	for i := range col.typed {
+		if col.typed[i].column, err = col.typed[i].chType.Column(); err != nil {
+			return nil, err
+		}
+	}
+	return col, nil
+}
+
+// unquoteString returns the string of a literal quoted with single quotes
+func unquoteString(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return s
+	}
+	var unquoted []byte
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' && i+1 < len(s)-1 {
+			i++
+		}
+		unquoted = append(unquoted, s[i])
+	}
+	return string(unquoted)
+}
+
+func (col *JSON) Type() Type {
+	return col.chType
+}
+
+func (col *JSON) ScanType() reflect.Type {
+	return scanTypeJSON
+}
+
+func (col *JSON) Rows() int {
+	return len(col.rows)
+}
+
+func (col *JSON) Row(i int, ptr bool) interface{} {
+	return col.rows[i]
+}
+
+func (col *JSON) ScanRow(dest interface{}, row int) error {
+	return scanJSON(col.chType, col.rows[row], dest)
+}
+
+func (col *JSON) Append(v interface{}) (nulls []uint8, err error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "value must be a slice",
+		}
+	}
+	for i := 0; i < value.Len(); i++ {
+		if err := col.AppendRow(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// AppendRow appends a struct (the paths are named by the ch tags), a map with string keys,
+// or the text of an object as json.RawMessage, []byte or string
+func (col *JSON) AppendRow(v interface{}) error {
+	row, err := jsonObject(col.chType, v)
+	if err != nil {
+		return err
+	}
+	col.rows = append(col.rows, row)
+	return nil
+}
+
+func (col *JSON) Decode(decoder *binary.Decoder, rows int) error {
+	start := len(col.rows)
+	switch {
+	case col.version == jsonString:
+		if err := col.text.Decode(decoder, rows); err != nil {
+			return err
+		}
+		for _, text := range col.text[start:] {
+			row, err := jsonDecode([]byte(text))
+			if err != nil {
+				return &Error{
+					ColumnType: string(col.chType),
+					Err:        err,
+				}
+			}
+			obj, _ := row.(map[string]interface{})
+			col.rows = append(col.rows, obj)
+		}
+		return nil
+	case col.shared == nil:
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        errors.New("the state prefix was not read"),
+		}
+	}
+	for _, p := range col.typed {
+		if err := p.column.Decode(decoder, rows); err != nil {
+			return err
+		}
+	}
+	for _, d := range col.dynamic {
+		if err := d.Decode(decoder, rows); err != nil {
+			return err
+		}
+	}
+	if err := col.shared.decode(decoder, rows); err != nil {
+		return err
+	}
+	for i := start; i < start+rows; i++ {
+		row, err := col.row(i)
+		if err != nil {
+			return &Error{
+				ColumnType: string(col.chType),
+				Err:        err,
+			}
+		}
+		col.rows = append(col.rows, row)
+	}
+	return nil
+}
+
+// row builds the object of a decoded row from its paths
+func (col *JSON) row(i int) (map[string]interface{}, error) {
+	row := make(map[string]interface{})
+	for _, p := range col.typed {
+		setJSONPath(row, p.path, jsonRow(p.column, i))
+	}
+	for j, d := range col.dynamic {
+		value := d.value(i)
+		switch value.Type {
+		case "":
+			continue
+		case sharedVariant:
+			v, err := readBinaryValue([]byte(value.Value.(string)))
+			if err != nil {
+				return nil, fmt.Errorf("path %q: %w", col.paths[j], err)
+			}
+			value.Value = v
+		}
+		v, err := jsonValue(value.Value)
+		if err != nil {
+			return nil, err
+		}
+		setJSONPath(row, col.paths[j], v)
+	}
+	start := uint64(0)
+	if i > 0 {
+		start = col.shared.offsets[i-1]
+	}
+	for k := start; k < col.shared.offsets[i]; k++ {
+		v, err := readBinaryValue([]byte(col.shared.values[k]))
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", col.shared.paths[k], err)
+		}
+		if v, err = jsonValue(v); err != nil {
+			return nil, err
+		}
+		setJSONPath(row, col.shared.paths[k], v)
+	}
+	return row, nil
+}
+
+// jsonValue converts the arrays and the maps of a dynamic value to []interface{} and map[string]interface{},
+// the scalars keep the type of their column
+func jsonValue(v interface{}) (interface{}, error) {
+	switch value := reflect.ValueOf(v); value.Kind() {
+	case reflect.Map, reflect.Array:
+		return jsonNormalize(v)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.Uint8 {
+			return jsonNormalize(v)
+		}
+	}
+	return v, nil
+}
+
+// setJSONPath sets the value of the path split on the dots into nested objects. A path nested in a path
+// that has a value is set as it is in the object of the parent path.
+func setJSONPath(obj map[string]interface{}, path string, value interface{}) {
+	for {
+		i := strings.IndexByte(path, '.')
+		if i < 0 {
+			break
+		}
+		child, found := obj[path[:i]]
+		if !found {
+			child = make(map[string]interface{})
+			obj[path[:i]] = child
+		}
+		nested, ok := child.(map[string]interface{})
+		if !ok {
+			break
+		}
+		obj, path = nested, path[i+1:]
+	}
+	obj[path] = value
+}
+
+func (col *JSON) Encode(encoder *binary.Encoder) error {
+	switch {
+	case len(col.rows) == 0:
+		return nil
+	case col.encoded != len(col.rows):
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        errors.New("the state prefix was not written"),
+		}
+	}
+	for _, p := range col.typed {
+		if err := p.column.Encode(encoder); err != nil {
+			return err
+		}
+	}
+	for _, d := range col.dynamic {
+		if err := d.Encode(encoder); err != nil {
+			return err
+		}
+	}
+	return col.shared.encode(encoder)
+}
+
+func (col *JSON) ReadStatePrefix(decoder *binary.Decoder) (err error) {
+	if col.version, err = decoder.UInt64(); err != nil {
+		return err
+	}
+	switch col.version {
+	case jsonString:
+		return nil
+	case jsonV1:
+		if _, err := decoder.Uvarint(); err != nil { // max_dynamic_paths
+			return err
+		}
+	case jsonV2:
+	default:
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        fmt.Errorf("unsupported serialization version %d", col.version),
+		}
+	}
+	n, err := decoder.Uvarint()
+	if err != nil {
+		return err
+	}
+	col.paths = make([]string, 0, n)
+	for i := 0; i < int(n); i++ {
+		path, err := decoder.String()
+		if err != nil {
+			return err
+		}
+		col.paths = append(col.paths, path)
+	}
+	for _, p := range col.typed {
+		if serialize, ok := p.column.(CustomSerialization); ok {
+			if err := serialize.ReadStatePrefix(decoder); err != nil {
+				return err
+			}
+		}
+	}
+	col.dynamic = make([]*Dynamic, 0, n)
+	for range col.paths {
+		d := col.newDynamic()
+		if err := d.ReadStatePrefix(decoder); err != nil {
+			return err
+		}
+		col.dynamic = append(col.dynamic, d)
+	}
+	col.shared = &jsonShared{}
+	return nil
+}
+
+func (col *JSON) newDynamic() *Dynamic {
+	return &Dynamic{
+		chType:   Type(fmt.Sprintf("Dynamic(max_types=%d)", col.maxDynamicTypes)),
+		maxTypes: col.maxDynamicTypes,
+	}
+}
+
+// WriteStatePrefix collects the paths of the rows: the typed paths, then the other paths in the order of
+// their names, the first max_dynamic_paths ones are the dynamic paths and the others are in the shared data
+func (col *JSON) WriteStatePrefix(encoder *binary.Encoder) error {
+	if len(col.rows) == 0 {
+		return nil
+	}
+	var (
+		rows    = make([]map[string]interface{}, 0, len(col.rows))
+		found   = make(map[string]bool)
+		dynamic []string
+	)
+	for _, row := range col.rows {
+		paths := make(map[string]interface{})
+		if err := col.flatten("", row, paths); err != nil {
+			return &Error{
+				ColumnType: string(col.chType),
+				Err:        err,
+			}
+		}
+		for path := range paths {
+			if !found[path] && !col.isTyped(path) {
+				found[path] = true
+				dynamic = append(dynamic, path)
+			}
+		}
+		rows = append(rows, paths)
+	}
+	sort.Strings(dynamic)
+	var shared []string
+	if max := col.maxDynamicPaths; len(dynamic) > max {
+		dynamic, shared = dynamic[:max], dynamic[max:]
+	}
+	if err := col.build(rows, dynamic, shared); err != nil {
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        err,
+		}
+	}
+	if err := encoder.UInt64(jsonV1); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(uint64(col.maxDynamicPaths)); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(uint64(len(col.paths))); err != nil {
+		return err
+	}
+	for _, path := range col.paths {
+		if err := encoder.String(path); err != nil {
+			return err
+		}
+	}
+	for _, p := range col.typed {
+		if serialize, ok := p.column.(CustomSerialization); ok {
+			if err := serialize.WriteStatePrefix(encoder); err != nil {
+				return err
+			}
+		}
+	}
+	for _, d := range col.dynamic {
+		if err := d.WriteStatePrefix(encoder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// build fills the columns of the paths with the paths of the rows
+func (col *JSON) build(rows []map[string]interface{}, dynamic, shared []string) (err error) {
+	col.version, col.paths, col.dynamic, col.shared = jsonV1, dynamic, make([]*Dynamic, 0, len(dynamic)), &jsonShared{}
+	for i := range col.typed {
+		p := &col.typed[i]
+		if p.column, err = p.chType.Column(); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := appendTypedPath(p.column, row[p.path]); err != nil {
+				return fmt.Errorf("path %q: %w", p.path, err)
+			}
+		}
+	}
+	scanTypes := make(map[Type]reflect.Type)
+	for _, path := range dynamic {
+		d := col.newDynamic()
+		for _, row := range rows {
+			value, err := dynamicJSONValue(scanTypes, row[path])
+			if err != nil {
+				return fmt.Errorf("path %q: %w", path, err)
+			}
+			if err := d.AppendRow(value); err != nil {
+				return fmt.Errorf("path %q: %w", path, err)
+			}
+		}
+		col.dynamic = append(col.dynamic, d)
+	}
+	for _, row := range rows {
+		for _, path := range shared {
+			v, found := row[path]
+			if !found {
+				continue
+			}
+			value, err := dynamicJSONValue(scanTypes, v)
+			if err != nil {
+				return fmt.Errorf("path %q: %w", path, err)
+			}
+			var (
+				buf     bytes.Buffer
+				encoder = binary.NewEncoder(&buf)
+			)
+			if err := writeBinaryValue(encoder, value.Type, reflect.ValueOf(value.Value)); err != nil {
+				return fmt.Errorf("path %q: %w", path, err)
+			}
+			if err := encoder.Flush(); err != nil {
+				return err
+			}
+			col.shared.paths, col.shared.values = append(col.shared.paths, path), append(col.shared.values, buf.String())
+		}
+		col.shared.offsets = append(col.shared.offsets, uint64(len(col.shared.paths)))
+	}
+	col.encoded = len(rows)
+	return nil
+}
+
+// flatten adds the paths of the object to paths with their values. The objects are split into their paths
+// but for the typed paths, the NULL values and the skipped paths are left out.
+func (col *JSON) flatten(prefix string, obj map[string]interface{}, paths map[string]interface{}) error {
+	for name, value := range obj {
+		path := name
+		if len(prefix) != 0 {
+			path = prefix + "." + name
+		}
+		switch {
+		case col.skipped(path):
+			continue
+		case col.isTyped(path):
+			paths[path] = value
+			continue
+		}
+		// the decoded rows have the values of the columns of the paths
+		value, err := jsonNormalize(value)
+		if err != nil {
+			return err
+		}
+		switch value := value.(type) {
+		case nil:
+		case map[string]interface{}:
+			if err := col.flatten(path, value, paths); err != nil {
+				return err
+			}
+		default:
+			paths[path] = value
+		}
+	}
+	return nil
+}
+
+func (col *JSON) isTyped(path string) bool {
+	for _, p := range col.typed {
+		if p.path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (col *JSON) skipped(path string) bool {
+	for _, skip := range col.skip {
+		if path == skip || strings.HasPrefix(path, skip+".") {
+			return true
+		}
+	}
+	for _, re := range col.skipRegexp {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendTypedPath appends the value of a typed path converted to the scan type of its column,
+// a missing path takes the default value of the type
+func appendTypedPath(col Interface, v interface{}) error {
+	scanType := col.ScanType()
+	switch {
+	case v == nil:
+		return col.AppendRow(reflect.Zero(scanType).Interface())
+	case reflect.TypeOf(v) == scanType:
+		return col.AppendRow(v)
+	}
+	dest := reflect.New(scanType).Elem()
+	if err := setJSON(dest, v); err != nil {
+		return col.AppendRow(v)
+	}
+	return col.AppendRow(dest.Interface())
+}
+
+// dynamicJSONValue returns the value of a dynamic path with its inferred type, converted to the scan type
+// of the column of the type
+func dynamicJSONValue(scanTypes map[Type]reflect.Type, v interface{}) (DynamicValue, error) {
+	if v == nil {
+		return DynamicValue{}, nil
+	}
+	t, err := jsonType(v)
+	if err != nil {
+		return DynamicValue{}, err
+	}
+	scanType, found := scanTypes[t]
+	if !found {
+		col, err := t.Column()
+		if err != nil {
+			return DynamicValue{}, err
+		}
+		scanType, scanTypes[t] = col.ScanType(), col.ScanType()
+	}
+	dest := reflect.New(scanType).Elem()
+	if err := setJSON(dest, v); err != nil {
+		return DynamicValue{}, err
+	}
+	return DynamicValue{
+		Type:  t,
+		Value: dest.Interface(),
+	}, nil
+}
+
+// jsonType returns the type of a normalized value as the server infers it from JSON: Int64 (UInt64 over its range),
+// Float64, String, Bool, the Array of the type of the elements and Array(JSON) for the arrays of objects
+func jsonType(v interface{}) (Type, error) {
+	switch v := v.(type) {
+	case int64:
+		return "Int64", nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return "UInt64", nil
+		}
+		return "Int64", nil
+	case float64:
+		return "Float64", nil
+	case string:
+		return "String", nil
+	case bool:
+		return "Bool", nil
+	case map[string]interface{}:
+		return "JSON", nil
+	case []interface{}:
+		var (
+			elem     Type
+			nullable bool
+		)
+		for _, value := range v {
+			if value == nil {
+				nullable = true
+				continue
+			}
+			t, err := jsonType(value)
+			if err != nil {
+				return "", err
+			}
+			switch {
+			case len(elem) == 0, elem == t:
+				elem = t
+			case jsonNumber(elem) && jsonNumber(t):
+				elem = "Float64"
+			default:
+				return "", fmt.Errorf("an array of %s and %s values", elem, t)
+			}
+		}
+		switch {
+		case len(elem) == 0:
+			// an empty array or an array of NULL
+			elem = "String"
+		case elem == "JSON", strings.HasPrefix(string(elem), "Array("):
+			// an object or an array is not Nullable, NULL is an empty one
+		case nullable:
+			elem = "Nullable(" + elem + ")"
+		}
+		return "Array(" + elem + ")", nil
+	}
+	return "", fmt.Errorf("unsupported JSON value of type %T", v)
+}
+
+func jsonNumber(t Type) bool {
+	switch t {
+	case "Int64", "UInt64", "Float64":
+		return true
+	}
+	return false
+}
+
+func (s *jsonShared) decode(decoder *binary.Decoder, rows int) error {
+	if err := s.offsets.Decode(decoder, rows); err != nil {
+		return err
+	}
+	var n int
+	if len(s.offsets) != 0 {
+		n = int(s.offsets[len(s.offsets)-1]) - len(s.paths)
+	}
+	if err := s.paths.Decode(decoder, n); err != nil {
+		return err
+	}
+	return s.values.Decode(decoder, n)
+}
+
+func (s *jsonShared) encode(encoder *binary.Encoder) error {
+	if err := s.offsets.Encode(encoder); err != nil {
+		return err
+	}
+	if err := s.paths.Encode(encoder); err != nil {
+		return err
+	}
+	return s.values.Encode(encoder)
+}
+
+// scanJSON scans the object of a row into a map, *interface{}, its text as json.RawMessage, []byte or string,
+// or a struct the fields of which are named by their ch tag
+func scanJSON(chType Type, value map[string]interface{}, dest interface{}) error {
+	switch d := dest.(type) {
+	case *map[string]interface{}:
+		*d = value
+	case *interface{}:
+		*d = value
+	case *json.RawMessage, *[]byte, *string:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		switch d := d.(type) {
+		case *json.RawMessage:
+			*d = data
+		case *[]byte:
+			*d = data
+		case *string:
+			*d = string(data)
+		}
+	default:
+		if v := reflect.ValueOf(dest); v.Kind() == reflect.Ptr && !v.IsNil() {
+			if err := setJSON(v.Elem(), value); err != nil {
+				return &ColumnConverterError{
+					Op:   "ScanRow",
+					To:   fmt.Sprintf("%T", dest),
+					From: string(chType),
+					Hint: err.Error(),
+				}
+			}
+			return nil
+		}
+		return &ColumnConverterError{
+			Op:   "ScanRow",
+			To:   fmt.Sprintf("%T", dest),
+			From: string(chType),
+		}
+	}
+	return nil
+}
+
+// jsonObject returns the object of an appended row: a struct (the paths are named by the ch tags),
+// a map with string keys, or the text of an object as json.RawMessage, []byte or string
+func jsonObject(chType Type, v interface{}) (map[string]interface{}, error) {
+	switch text := v.(type) {
+	case string:
+		v = json.RawMessage(text)
+	case []byte:
+		v = json.RawMessage(text)
+	}
+	value, err := jsonNormalize(v)
+	if err != nil {
+		return nil, &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   string(chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: err.Error(),
+		}
+	}
+	switch value := value.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return value, nil
+	}
+	return nil, &ColumnConverterError{
+		Op:   "AppendRow",
+		To:   string(chType),
+		From: fmt.Sprintf("%T", v),
+		Hint: "the row must be an object",
+	}
+}
+
+func jsonDecode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return jsonNormalize(v)
+}
+
+// jsonNormalize converts the value to the objects, arrays and scalars of the column:
+// map[string]interface{}, []interface{}, int64, uint64, float64, string and bool
+func jsonNormalize(v interface{}) (interface{}, error) {
+	if value := reflect.ValueOf(v); !value.IsValid() || value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil, nil
+	}
+	switch v := v.(type) {
+	case json.RawMessage:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		return jsonDecode(v)
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+		if n, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return n, nil
+		}
+		return v.Float64()
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(text), nil
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Ptr:
+		return jsonNormalize(value.Elem().Interface())
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), nil
+		}
+		values := make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			elem, err := jsonNormalize(value.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, elem)
+		}
+		return values, nil
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+		obj := make(map[string]interface{}, value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			elem, err := jsonNormalize(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			obj[iter.Key().String()] = elem
+		}
+		return obj, nil
+	case reflect.Struct:
+		fields := structFields(value.Type())
+		obj := make(map[string]interface{}, len(fields))
+		for name, idx := range fields {
+			elem, err := jsonNormalize(value.FieldByIndex(idx).Interface())
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = elem
+		}
+		return obj, nil
+	}
+	return nil, fmt.Errorf("unsupported JSON value of type %T", v)
+}
+
+// setJSON sets the value of a row to dest, the fields of a struct are named by their ch tag
+func setJSON(dest reflect.Value, v interface{}) error {
+	if v == nil {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+	switch dest.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dest.Type().Elem())
+		if err := setJSON(elem.Elem(), v); err != nil {
+			return err
+		}
+		dest.Set(elem)
+		return nil
+	case reflect.Struct:
+		if obj, ok := v.(map[string]interface{}); ok {
+			for name, idx := range structFields(dest.Type()) {
+				if value, found := obj[name]; found {
+					if err := setJSON(dest.FieldByIndex(idx), value); err != nil {
+						return fmt.Errorf("%s: %w", name, err)
+					}
+				}
+			}
+			return nil
+		}
+	case reflect.Map:
+		if obj, ok := v.(map[string]interface{}); ok && dest.Type().Key().Kind() == reflect.String {
+			m := reflect.MakeMapWithSize(dest.Type(), len(obj))
+			for name, value := range obj {
+				elem := reflect.New(dest.Type().Elem()).Elem()
+				if err := setJSON(elem, value); err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				m.SetMapIndex(reflect.ValueOf(name).Convert(dest.Type().Key()), elem)
+			}
+			dest.Set(m)
+			return nil
+		}
+	case reflect.Slice:
+		if values, ok := v.([]interface{}); ok {
+			slice := reflect.MakeSlice(dest.Type(), len(values), len(values))
+			for i, value := range values {
+				if err := setJSON(slice.Index(i), value); err != nil {
+					return err
+				}
+			}
+			dest.Set(slice)
+			return nil
+		}
+	}
+	switch value := reflect.ValueOf(v); {
+	case value.Type().AssignableTo(dest.Type()):
+		dest.Set(value)
+	case numericKind(value.Kind()) && numericKind(dest.Kind()), value.Kind() == reflect.String && dest.Kind() == reflect.String:
+		dest.Set(value.Convert(dest.Type()))
+	default:
+		return fmt.Errorf("cannot set %T to %s", v, dest.Type())
+	}
+	return nil
+}
+
+func numericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// structFields returns the index of the fields named by their ch tag, as the struct mapping of the driver does
+func structFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		var (
+			f    = t.Field(i)
+			name = f.Name
+		)
+		if tn := f.Tag.Get("ch"); len(tn) != 0 {
+			name = tn
+		}
+		switch {
+		case name == "-", len(f.PkgPath) != 0 && !f.Anonymous:
+			continue
+		}
+		switch {
+		case f.Anonymous:
+			if f.Type.Kind() == reflect.Struct {
+				for k, idx := range structFields(f.Type) {
+					fields[k] = append(append([]int(nil), f.Index...), idx...)
+				}
+			}
+		default:
+			fields[name] = f.Index
+		}
+	}
+	return fields
+}
+
+var (
+	_ Interface           = (*JSON)(nil)
+	_ CustomSerialization = (*JSON)(nil)
+)
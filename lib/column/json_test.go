@@ -0,0 +1,211 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+func TestJSON(t *testing.T) {
+	col, err := Type("JSON(id UInt32, SKIP secret, SKIP REGEXP '^tmp\\.')").Column()
+	require.NoError(t, err)
+	user := jsonUser{
+		Name:      "alice",
+		Age:       42,
+		Score:     1.5,
+		Admin:     true,
+		Tags:      []string{"a", "b"},
+		Address:   jsonAddress{City: "Amsterdam", Zip: 1011},
+		Addresses: []jsonAddress{{City: "Berlin", Zip: 10115}, {City: "Paris"}},
+		Ignored:   "ignored",
+	}
+	require.NoError(t, col.AppendRow(user))
+	require.NoError(t, col.AppendRow(map[string]interface{}{"id": 7, "name": "bob", "secret": "s", "tmp": map[string]int{"a": 1}, "score": 2}))
+	_, err = col.Append([]json.RawMessage{json.RawMessage(`{"id": 8, "address": {"city": "Rome"}, "tags": [1, 2.5]}`)})
+	require.NoError(t, err)
+	require.NoError(t, col.AppendRow(nil))
+	assert.Equal(t, 4, col.Rows())
+
+	decoded, data := roundTrip(t, col)
+	decoder := binary.NewDecoder(bytes.NewReader(data))
+	version, err := decoder.UInt64()
+	require.NoError(t, err)
+	assert.Equal(t, jsonV1, version)
+	maxPaths, err := decoder.Uvarint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(jsonMaxDynamicPaths), maxPaths)
+	assert.Equal(t, []string{"address.city", "address.zip", "addresses", "admin", "age", "name", "score", "tags"}, decoded.(*JSON).paths,
+		"the typed, the skipped and the NULL paths are not dynamic")
+	require.Equal(t, 4, decoded.Rows())
+
+	var scanned jsonUser
+	require.NoError(t, decoded.ScanRow(&scanned, 0))
+	user.Ignored = ""
+	assert.Equal(t, user, scanned)
+
+	var row map[string]interface{}
+	require.NoError(t, decoded.ScanRow(&row, 1))
+	assert.Equal(t, map[string]interface{}{"id": uint32(7), "name": "bob", "score": int64(2)}, row)
+
+	var text json.RawMessage
+	require.NoError(t, decoded.ScanRow(&text, 2))
+	assert.JSONEq(t, `{"id": 8, "address": {"city": "Rome"}, "tags": [1, 2.5]}`, string(text))
+	assert.Equal(t, map[string]interface{}{"id": uint32(0)}, decoded.Row(3, false), "a missing typed path has the default value")
+
+	// the decoded rows are written again
+	again, _ := roundTrip(t, decoded)
+	for i := 0; i < decoded.Rows(); i++ {
+		assert.Equal(t, decoded.Row(i, false), again.Row(i, false))
+	}
+}
+
+func TestJSONSharedData(t *testing.T) {
+	col, err := Type("JSON(max_dynamic_paths=1)").Column()
+	require.NoError(t, err)
+	require.NoError(t, col.AppendRow(map[string]interface{}{"a": 1, "b": "x", "c": []bool{true}}))
+	require.NoError(t, col.AppendRow(map[string]interface{}{"b": "y", "d": map[string]interface{}{"e": nil}}))
+	decoded, _ := roundTrip(t, col)
+	assert.Equal(t, []string{"a"}, decoded.(*JSON).paths)
+	assert.Equal(t, UInt64{2, 3}, decoded.(*JSON).shared.offsets)
+	assert.Equal(t, map[string]interface{}{"a": int64(1), "b": "x", "c": []interface{}{true}}, decoded.Row(0, false))
+	assert.Equal(t, map[string]interface{}{"b": "y"}, decoded.Row(1, false))
+
+	require.NoError(t, col.AppendRow(`{"z": [{"k": 1}]}`))
+	err = col.(CustomSerialization).WriteStatePrefix(binary.NewEncoder(&bytes.Buffer{}))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `path "z"`, "an array of objects is not in the shared data")
+	}
+}
+
+func TestJSONServer(t *testing.T) {
+	for _, version := range []uint64{jsonV1, jsonV2} {
+		var (
+			buf     bytes.Buffer
+			encoder = binary.NewEncoder(&buf)
+		)
+		ids, err := Type("Int8").Column()
+		require.NoError(t, err)
+		_, err = ids.Append([]int8{1, 2})
+		require.NoError(t, err)
+		var (
+			ab = &Dynamic{chType: "Dynamic", maxTypes: dynamicMaxTypes}
+			c  = &Dynamic{chType: "Dynamic", maxTypes: dynamicMaxTypes}
+		)
+		require.NoError(t, ab.AppendRow("x"))
+		require.NoError(t, ab.AppendRow(nil))
+		require.NoError(t, c.AppendRow(DynamicValue{Type: sharedVariant, Value: binaryValue(t, "Array(Int64)", []int64{1, 2})}))
+		require.NoError(t, c.AppendRow(int64(5)))
+
+		require.NoError(t, encoder.UInt64(version))
+		if version == jsonV1 {
+			require.NoError(t, encoder.Uvarint(4))
+		}
+		require.NoError(t, encoder.Uvarint(2))
+		require.NoError(t, encoder.String("a.b"))
+		require.NoError(t, encoder.String("c"))
+		require.NoError(t, ab.WriteStatePrefix(encoder))
+		require.NoError(t, c.WriteStatePrefix(encoder))
+		require.NoError(t, ids.Encode(encoder))
+		require.NoError(t, ab.Encode(encoder))
+		require.NoError(t, c.Encode(encoder))
+		shared := jsonShared{
+			offsets: UInt64{1, 1},
+			paths:   String{"d.e"},
+			values:  String{binaryValue(t, "String", "y")},
+		}
+		require.NoError(t, shared.encode(encoder))
+		require.NoError(t, encoder.Flush())
+
+		col, err := Type("JSON(max_dynamic_paths=4, id Int8)").Column()
+		require.NoError(t, err)
+		decoder := binary.NewDecoder(&buf)
+		require.NoError(t, col.(CustomSerialization).ReadStatePrefix(decoder))
+		require.NoError(t, col.Decode(decoder, 2))
+		assert.Equal(t, map[string]interface{}{
+			"id": int8(1),
+			"a":  map[string]interface{}{"b": "x"},
+			"c":  []interface{}{int64(1), int64(2)},
+			"d":  map[string]interface{}{"e": "y"},
+		}, col.Row(0, false), "version %d", version)
+		assert.Equal(t, map[string]interface{}{"id": int8(2), "c": int64(5)}, col.Row(1, false), "version %d", version)
+	}
+}
+
+func TestJSONString(t *testing.T) {
+	var (
+		buf     bytes.Buffer
+		encoder = binary.NewEncoder(&buf)
+	)
+	require.NoError(t, encoder.UInt64(jsonString))
+	require.NoError(t, (&String{`{"a": {"b": 1}}`, `{}`}).Encode(encoder))
+	require.NoError(t, encoder.Flush())
+	col, err := Type("JSON").Column()
+	require.NoError(t, err)
+	decoder := binary.NewDecoder(&buf)
+	require.NoError(t, col.(CustomSerialization).ReadStatePrefix(decoder))
+	require.NoError(t, col.Decode(decoder, 2))
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"b": int64(1)}}, col.Row(0, false))
+	assert.Equal(t, map[string]interface{}{}, col.Row(1, false))
+}
+
+func TestJSONType(t *testing.T) {
+	col, err := Type("JSON(max_dynamic_paths=10, max_dynamic_types=3, `a.b` String, SKIP c, SKIP `d.e`, SKIP REGEXP 'f\\'g')").Column()
+	require.NoError(t, err)
+	j := col.(*JSON)
+	assert.Equal(t, 10, j.maxDynamicPaths)
+	assert.Equal(t, 3, j.maxDynamicTypes)
+	if assert.Len(t, j.typed, 1) {
+		assert.Equal(t, "a.b", j.typed[0].path)
+		assert.Equal(t, Type("String"), j.typed[0].column.Type())
+	}
+	assert.Equal(t, []string{"c", "d.e"}, j.skip)
+	if assert.Len(t, j.skipRegexp, 1) {
+		assert.Equal(t, "f'g", j.skipRegexp[0].String())
+	}
+	assert.Equal(t, Type("Dynamic(max_types=3)"), j.newDynamic().Type())
+
+	_, err = Type("JSON(max_dynamic_paths=many)").Column()
+	assert.Error(t, err)
+	_, err = Type("JSON(SKIP REGEXP '(')").Column()
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	encoder := binary.NewEncoder(&buf)
+	require.NoError(t, encoder.UInt64(3))
+	require.NoError(t, encoder.Flush())
+	assert.Error(t, col.(CustomSerialization).ReadStatePrefix(binary.NewDecoder(&buf)), "unsupported version")
+	assert.Error(t, (&JSON{chType: "JSON"}).Decode(binary.NewDecoder(&buf), 1), "the state prefix was not read")
+}
+
+// binaryValue returns the binary encoding of the type then the value, as it is in the shared data
+func binaryValue(t *testing.T, chType Type, v interface{}) string {
+	var (
+		buf     bytes.Buffer
+		encoder = binary.NewEncoder(&buf)
+	)
+	require.NoError(t, writeBinaryValue(encoder, chType, reflect.ValueOf(v)))
+	require.NoError(t, encoder.Flush())
+	return buf.String()
+}
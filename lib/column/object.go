@@ -0,0 +1,483 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// the kinds of the Object serialization, written first in the state prefix
+const (
+	objectTuple  uint8 = 0 // the paths are the elements of a named tuple, the type of which follows the kind
+	objectString uint8 = 1 // the rows are JSON texts
+)
+
+// Object is the Object('json') column, the JSON type of the servers before 24.8. The paths found in the rows
+// (the dynamic subcolumns) are sent as the elements of a named Tuple, nested objects as Tuple and arrays of objects
+// as Nested, the type of the tuple is written in the state prefix of the column. The rows are read as
+// map[string]interface{} with the values of the path types and []interface{} arrays. Appended rows are kept until
+// the block is written since the tuple is inferred from all of them, the numbers are Int64, UInt64 or Float64.
+type Object struct {
+	chType  Type
+	kind    uint8
+	tuple   Interface
+	text    String
+	decoded int
+	rows    []map[string]interface{}
+}
+
+func (col *Object) Type() Type {
+	return col.chType
+}
+
+func (col *Object) ScanType() reflect.Type {
+	return scanTypeJSON
+}
+
+func (col *Object) Rows() int {
+	return col.decoded + len(col.rows)
+}
+
+func (col *Object) Row(i int, ptr bool) interface{} {
+	if i >= col.decoded {
+		return col.rows[i-col.decoded]
+	}
+	if col.kind == objectString {
+		// the text was checked by the server
+		row, _ := jsonDecode([]byte(col.text[i]))
+		obj, _ := row.(map[string]interface{})
+		return obj
+	}
+	return jsonRow(col.tuple, i)
+}
+
+func (col *Object) ScanRow(dest interface{}, row int) error {
+	return scanJSON(col.chType, col.Row(row, false).(map[string]interface{}), dest)
+}
+
+func (col *Object) Append(v interface{}) (nulls []uint8, err error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "value must be a slice",
+		}
+	}
+	for i := 0; i < value.Len(); i++ {
+		if err := col.AppendRow(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// AppendRow appends a struct (the paths are named by the ch tags), a map with string keys,
+// or the text of an object as json.RawMessage, []byte or string
+func (col *Object) AppendRow(v interface{}) error {
+	row, err := jsonObject(col.chType, v)
+	if err != nil {
+		return err
+	}
+	col.rows = append(col.rows, row)
+	return nil
+}
+
+func (col *Object) Decode(decoder *binary.Decoder, rows int) error {
+	switch {
+	case col.kind == objectString:
+		if err := col.text.Decode(decoder, rows); err != nil {
+			return err
+		}
+	case col.tuple != nil:
+		if err := col.tuple.Decode(decoder, rows); err != nil {
+			return err
+		}
+	default:
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        errors.New("the state prefix was not read"),
+		}
+	}
+	col.decoded += rows
+	return nil
+}
+
+func (col *Object) Encode(encoder *binary.Encoder) error {
+	switch {
+	case col.Rows() == 0:
+		return nil
+	case len(col.rows) != 0:
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        errors.New("the state prefix was not written"),
+		}
+	case col.kind == objectString:
+		return col.text.Encode(encoder)
+	}
+	return col.tuple.Encode(encoder)
+}
+
+func (col *Object) ReadStatePrefix(decoder *binary.Decoder) (err error) {
+	if col.kind, err = decoder.UInt8(); err != nil {
+		return err
+	}
+	switch col.kind {
+	case objectString:
+		return nil
+	case objectTuple:
+		t, err := decoder.String()
+		if err != nil {
+			return err
+		}
+		if col.tuple, err = Type(nestedTuples(t)).Column(); err != nil {
+			return err
+		}
+		if _, ok := col.tuple.(*Tuple); !ok {
+			return &Error{
+				ColumnType: string(col.chType),
+				Err:        fmt.Errorf("unexpected paths type %s", t),
+			}
+		}
+		return col.tuple.(*Tuple).ReadStatePrefix(decoder)
+	}
+	return &Error{
+		ColumnType: string(col.chType),
+		Err:        fmt.Errorf("unsupported serialization kind %d", col.kind),
+	}
+}
+
+// WriteStatePrefix infers the tuple of the paths from the rows, the rows are sent as texts
+// when no path has a value
+func (col *Object) WriteStatePrefix(encoder *binary.Encoder) error {
+	if col.Rows() == 0 {
+		return nil
+	}
+	var (
+		root = &jsonPath{}
+		rows = make([]map[string]interface{}, 0, col.Rows())
+	)
+	for i := 0; i < col.Rows(); i++ {
+		// the decoded rows have the values of the path types
+		value, err := jsonNormalize(col.Row(i, false))
+		if err != nil {
+			return err
+		}
+		row, _ := value.(map[string]interface{})
+		if err := root.merge("", row); err != nil {
+			return &Error{
+				ColumnType: string(col.chType),
+				Err:        err,
+			}
+		}
+		rows = append(rows, row)
+	}
+	t := root.Type()
+	if len(t) == 0 {
+		text := make(String, 0, len(rows))
+		for _, row := range rows {
+			data, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			text = append(text, string(data))
+		}
+		col.kind, col.text, col.tuple, col.decoded, col.rows = objectString, text, nil, len(rows), nil
+		return encoder.UInt8(objectString)
+	}
+	tuple, err := Type(t).Column()
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := appendJSON(tuple, row); err != nil {
+			return &Error{
+				ColumnType: string(col.chType),
+				Err:        err,
+			}
+		}
+	}
+	col.kind, col.text, col.tuple, col.decoded, col.rows = objectTuple, nil, tuple, len(rows), nil
+	if err := encoder.UInt8(objectTuple); err != nil {
+		return err
+	}
+	if err := encoder.String(t); err != nil {
+		return err
+	}
+	return tuple.(*Tuple).WriteStatePrefix(encoder)
+}
+
+// nestedTuples replaces the Nested types by the Array of the named Tuple, Nested drops the names
+func nestedTuples(t string) string {
+	var (
+		out     strings.Builder
+		nested  []bool
+		quoted  bool
+		escaped bool
+	)
+	for i := 0; i < len(t); i++ {
+		switch c := t[i]; {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '`':
+			quoted = !quoted
+		case quoted:
+		case strings.HasPrefix(t[i:], "Nested("):
+			out.WriteString("Array(Tuple(")
+			nested, i = append(nested, true), i+len("Nested(")-1
+			continue
+		case c == '(':
+			nested = append(nested, false)
+		case c == ')' && len(nested) != 0:
+			if nested[len(nested)-1] {
+				out.WriteByte(')')
+			}
+			nested = nested[:len(nested)-1]
+		}
+		out.WriteByte(t[i])
+	}
+	return out.String()
+}
+
+// jsonRow returns the value of the row, the named tuples are objects
+func jsonRow(col Interface, row int) interface{} {
+	switch col := col.(type) {
+	case *Tuple:
+		obj := make(map[string]interface{}, len(col.columns))
+		for i, c := range col.columns {
+			name := col.names[i]
+			if len(name) == 0 {
+				name = strconv.Itoa(i + 1)
+			}
+			obj[name] = jsonRow(c, row)
+		}
+		return obj
+	case *Array:
+		return jsonArray(col, uint64(row), 0)
+	case *Nullable:
+		if col.Row(row, false) == nil {
+			return nil
+		}
+		return jsonRow(col.base, row)
+	}
+	return col.Row(row, false)
+}
+
+func jsonArray(col *Array, row uint64, level int) []interface{} {
+	var (
+		offsets = col.offsets[level].values
+		start   uint64
+		end     = offsets[row]
+	)
+	if row > 0 {
+		start = offsets[row-1]
+	}
+	values := make([]interface{}, 0, int(end-start))
+	for i := start; i < end; i++ {
+		switch {
+		case level == len(col.offsets)-1:
+			values = append(values, jsonRow(col.values, int(i)))
+		default:
+			values = append(values, jsonArray(col, i, level+1))
+		}
+	}
+	return values
+}
+
+// appendJSON appends the normalized value to the column built from the inferred type,
+// the missing paths take the default value of their type
+func appendJSON(col Interface, v interface{}) error {
+	switch col := col.(type) {
+	case *Tuple:
+		obj, _ := v.(map[string]interface{})
+		for i, c := range col.columns {
+			if err := appendJSON(c, obj[col.names[i]]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *Array:
+		return appendJSONArray(col, v, 0)
+	case *Int64:
+		if n, ok := v.(uint64); ok {
+			if n > math.MaxInt64 {
+				return fmt.Errorf("%d overflows Int64", n)
+			}
+			v = int64(n)
+		}
+	case *Float64:
+		switch n := v.(type) {
+		case int64:
+			v = float64(n)
+		case uint64:
+			v = float64(n)
+		}
+	}
+	if v == nil {
+		v = reflect.Zero(col.ScanType()).Interface()
+	}
+	return col.AppendRow(v)
+}
+
+func appendJSONArray(col *Array, v interface{}, level int) error {
+	values, _ := v.([]interface{})
+	offset := uint64(len(values))
+	if ln := len(col.offsets[level].values); ln != 0 {
+		offset += col.offsets[level].values[ln-1]
+	}
+	col.offsets[level].values = append(col.offsets[level].values, offset)
+	for _, value := range values {
+		var err error
+		switch {
+		case level == len(col.offsets)-1:
+			err = appendJSON(col.values, value)
+		default:
+			err = appendJSONArray(col, value, level+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonPath is the type inferred from the values of a path: a leaf, an object or an array
+type jsonPath struct {
+	leaf   Type
+	fields map[string]*jsonPath
+	elem   *jsonPath
+}
+
+func (p *jsonPath) merge(path string, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		if p.fields == nil {
+			if len(p.leaf) != 0 || p.elem != nil {
+				return fmt.Errorf("path %q has objects and %s values", path, p.kind())
+			}
+			p.fields = make(map[string]*jsonPath)
+		}
+		for name, value := range v {
+			field, found := p.fields[name]
+			if !found {
+				field = &jsonPath{}
+				p.fields[name] = field
+			}
+			if err := field.merge(strings.TrimPrefix(path+"."+name, "."), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		if p.elem == nil {
+			if len(p.leaf) != 0 || p.fields != nil {
+				return fmt.Errorf("path %q has arrays and %s values", path, p.kind())
+			}
+			p.elem = &jsonPath{}
+		}
+		for _, value := range v {
+			if err := p.elem.merge(path+"[]", value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var leaf Type
+	switch v.(type) {
+	case int64:
+		leaf = "Int64"
+	case uint64:
+		leaf = "UInt64"
+	case float64:
+		leaf = "Float64"
+	case string:
+		leaf = "String"
+	case bool:
+		leaf = "Bool"
+	}
+	switch {
+	case p.fields != nil || p.elem != nil:
+		return fmt.Errorf("path %q has %s and %s values", path, p.kind(), leaf)
+	case len(p.leaf) == 0, p.leaf == leaf:
+		p.leaf = leaf
+	case p.leaf == "Float64" && (leaf == "Int64" || leaf == "UInt64"), leaf == "Float64" && (p.leaf == "Int64" || p.leaf == "UInt64"):
+		p.leaf = "Float64"
+	case p.leaf == "Int64" && leaf == "UInt64", p.leaf == "UInt64" && leaf == "Int64":
+		p.leaf = "Int64"
+	default:
+		return fmt.Errorf("path %q has %s and %s values", path, p.leaf, leaf)
+	}
+	return nil
+}
+
+func (p *jsonPath) kind() string {
+	switch {
+	case p.fields != nil:
+		return "object"
+	case p.elem != nil:
+		return "array"
+	}
+	return string(p.leaf)
+}
+
+// Type returns the type of the path, empty when no value gives it
+func (p *jsonPath) Type() string {
+	switch {
+	case p.fields != nil:
+		names := make([]string, 0, len(p.fields))
+		for name := range p.fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		elements := make([]string, 0, len(names))
+		for _, name := range names {
+			if t := p.fields[name].Type(); len(t) != 0 {
+				elements = append(elements, quoteName(name)+" "+t)
+			}
+		}
+		if len(elements) == 0 {
+			return ""
+		}
+		return "Tuple(" + strings.Join(elements, ", ") + ")"
+	case p.elem != nil:
+		if t := p.elem.Type(); len(t) != 0 {
+			return "Array(" + t + ")"
+		}
+		return ""
+	}
+	return string(p.leaf)
+}
+
+var (
+	_ Interface           = (*Object)(nil)
+	_ CustomSerialization = (*Object)(nil)
+)
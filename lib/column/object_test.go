@@ -0,0 +1,204 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// roundTrip writes the column as a block does and reads it back as a column of the same type
+func roundTrip(t *testing.T, col Interface) (Interface, []byte) {
+	var (
+		buf     bytes.Buffer
+		encoder = binary.NewEncoder(&buf)
+	)
+	require.NoError(t, col.(CustomSerialization).WriteStatePrefix(encoder))
+	require.NoError(t, col.Encode(encoder))
+	require.NoError(t, encoder.Flush())
+	data := append([]byte(nil), buf.Bytes()...)
+	decoded, err := col.Type().Column()
+	require.NoError(t, err)
+	decoder := binary.NewDecoder(&buf)
+	require.NoError(t, decoded.(CustomSerialization).ReadStatePrefix(decoder))
+	require.NoError(t, decoded.Decode(decoder, col.Rows()))
+	return decoded, data
+}
+
+type jsonAddress struct {
+	City string `ch:"city"`
+	Zip  int32  `ch:"zip"`
+}
+
+type jsonUser struct {
+	Name      string        `ch:"name"`
+	Age       uint8         `ch:"age"`
+	Score     float64       `ch:"score"`
+	Admin     bool          `ch:"admin"`
+	Tags      []string      `ch:"tags"`
+	Address   jsonAddress   `ch:"address"`
+	Addresses []jsonAddress `ch:"addresses"`
+	Manager   *jsonUser     `ch:"manager"`
+	Ignored   string        `ch:"-"`
+}
+
+func TestObject(t *testing.T) {
+	col, err := Type("Object('json')").Column()
+	require.NoError(t, err)
+	user := jsonUser{
+		Name:      "alice",
+		Age:       42,
+		Score:     1.5,
+		Admin:     true,
+		Tags:      []string{"a", "b"},
+		Address:   jsonAddress{City: "Amsterdam", Zip: 1011},
+		Addresses: []jsonAddress{{City: "Berlin", Zip: 10115}, {City: "Paris"}},
+		Ignored:   "ignored",
+	}
+	require.NoError(t, col.AppendRow(user))
+	require.NoError(t, col.AppendRow(map[string]interface{}{"name": "bob", "score": 2, "extra": map[string]string{"key": "value"}}))
+	_, err = col.Append([]json.RawMessage{json.RawMessage(`{"name": "carol", "tags": ["c"], "addresses": [{"city": "Rome"}]}`)})
+	require.NoError(t, err)
+	require.NoError(t, col.AppendRow(`{"age": 7}`))
+	require.NoError(t, col.AppendRow(nil))
+	assert.Equal(t, 5, col.Rows())
+
+	decoded, data := roundTrip(t, col)
+	const paths = "Tuple(`address` Tuple(`city` String, `zip` Int64), `addresses` Array(Tuple(`city` String, `zip` Int64)), " +
+		"`admin` Bool, `age` Int64, `extra` Tuple(`key` String), `name` String, `score` Float64, `tags` Array(String))"
+	assert.Equal(t, append([]byte{objectTuple, byte(len(paths)), 1}, paths...), data[:len(paths)+3], "the kind then the length of the paths as a varint")
+	require.Equal(t, 5, decoded.Rows())
+
+	var scanned jsonUser
+	require.NoError(t, decoded.ScanRow(&scanned, 0))
+	user.Ignored = ""
+	assert.Equal(t, user, scanned)
+
+	var row map[string]interface{}
+	require.NoError(t, decoded.ScanRow(&row, 1))
+	assert.Equal(t, map[string]interface{}{
+		"addresses": []interface{}{},
+		"address":   map[string]interface{}{"city": "", "zip": int64(0)},
+		"admin":     false,
+		"age":       int64(0),
+		"extra":     map[string]interface{}{"key": "value"},
+		"name":      "bob",
+		"score":     float64(2),
+		"tags":      []interface{}{},
+	}, row)
+
+	var text json.RawMessage
+	require.NoError(t, decoded.ScanRow(&text, 2))
+	assert.JSONEq(t, `{
+		"addresses": [{"city": "Rome", "zip": 0}], "address": {"city": "", "zip": 0}, "admin": false,
+		"age": 0, "extra": {"key": ""}, "name": "carol", "score": 0, "tags": ["c"]
+	}`, string(text))
+
+	var age struct {
+		Age  int    `ch:"age"`
+		Name string `ch:"name"`
+	}
+	require.NoError(t, decoded.ScanRow(&age, 3))
+	assert.Equal(t, 7, age.Age)
+
+	var wrong struct {
+		Name int `ch:"name"`
+	}
+	assert.Error(t, decoded.ScanRow(&wrong, 0))
+}
+
+func TestObjectServerPaths(t *testing.T) {
+	// the server sends arrays of objects as Nested, without quoting the names
+	tuple, err := Type("Tuple(id Int8, items Array(Tuple(name String, price Nullable(Float32))))").Column()
+	require.NoError(t, err)
+	require.NoError(t, tuple.AppendRow([]interface{}{int8(1), [][]interface{}{{"pen", float32(1.5)}, {"ink", nil}}}))
+	var (
+		buf     bytes.Buffer
+		encoder = binary.NewEncoder(&buf)
+	)
+	require.NoError(t, encoder.UInt8(objectTuple))
+	require.NoError(t, encoder.String("Tuple(id Int8, items Nested(name String, price Nullable(Float32)))"))
+	require.NoError(t, tuple.Encode(encoder))
+	require.NoError(t, encoder.Flush())
+
+	col, err := Type("Object('json')").Column()
+	require.NoError(t, err)
+	decoder := binary.NewDecoder(&buf)
+	require.NoError(t, col.(CustomSerialization).ReadStatePrefix(decoder))
+	require.NoError(t, col.Decode(decoder, 1))
+	assert.Equal(t, map[string]interface{}{
+		"id": int8(1),
+		"items": []interface{}{
+			map[string]interface{}{"name": "pen", "price": float32(1.5)},
+			map[string]interface{}{"name": "ink", "price": nil},
+		},
+	}, col.Row(0, false))
+
+	// the decoded rows are written again with the inferred paths
+	decoded, _ := roundTrip(t, col)
+	assert.Equal(t, map[string]interface{}{
+		"id": int64(1),
+		"items": []interface{}{
+			map[string]interface{}{"name": "pen", "price": float64(1.5)},
+			map[string]interface{}{"name": "ink", "price": float64(0)},
+		},
+	}, decoded.Row(0, false))
+}
+
+func TestObjectWithoutPaths(t *testing.T) {
+	col := &Object{chType: "Object('json')"}
+	require.NoError(t, col.AppendRow(map[string]interface{}{}))
+	require.NoError(t, col.AppendRow(map[string]interface{}{"empty": []interface{}{}, "null": nil}))
+	decoded, data := roundTrip(t, col)
+	assert.Equal(t, objectString, data[0])
+	assert.Equal(t, map[string]interface{}{}, decoded.Row(0, false))
+	assert.Equal(t, map[string]interface{}{"empty": []interface{}{}, "null": nil}, decoded.Row(1, false))
+}
+
+func TestObjectErrors(t *testing.T) {
+	col := &Object{chType: "Object('json')"}
+	assert.Error(t, col.AppendRow(`[1, 2]`), "not an object")
+	assert.Error(t, col.AppendRow(`{"broken"`))
+	assert.Error(t, col.AppendRow(map[int]string{1: "one"}))
+
+	require.NoError(t, col.AppendRow(map[string]interface{}{"a": 1}))
+	require.NoError(t, col.AppendRow(map[string]interface{}{"a": "one"}))
+	var buf bytes.Buffer
+	err := col.WriteStatePrefix(binary.NewEncoder(&buf))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `path "a" has Int64 and String values`)
+	}
+}
+
+func TestTupleNames(t *testing.T) {
+	col, err := Type("Tuple(a Int8, `b c` Array(String), `d\\`(,` DateTime64(3, 'UTC'), Nullable(String))").Column()
+	require.NoError(t, err)
+	tuple := col.(*Tuple)
+	assert.Equal(t, []string{"a", "b c", "d`(,", ""}, tuple.names)
+	if assert.Len(t, tuple.columns, 4) {
+		assert.Equal(t, Type("Array(String)"), tuple.columns[1].Type())
+		assert.Equal(t, Type("DateTime64(3, 'UTC')"), tuple.columns[2].Type())
+	}
+	assert.Equal(t, "`d\\`(,`", quoteName("d`(,"))
+	assert.Equal(t, "Array(Tuple(a Array(Tuple(`b)` Int8)), c String))", nestedTuples("Nested(a Nested(`b)` Int8), c String)"))
+}
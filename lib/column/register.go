@@ -0,0 +1,68 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package column
+
+import (
+	"strings"
+	"sync"
+)
+
+var registry = struct {
+	sync.RWMutex
+	factories map[string]func(Type) (Interface, error)
+}{
+	factories: make(map[string]func(Type) (Interface, error)),
+}
+
+// Register makes the columns of the types that start with the prefix, such as "Time" or "Geometry(", available
+// for the types the driver does not implement. The registered types are resolved after the built-in ones and inside
+// Array, Nullable, Map, Tuple, LowCardinality and the other nested types, the longest matching prefix is used.
+// If Register is called twice with the same prefix or if factory is nil, it panics.
+func Register(prefix string, factory func(Type) (Interface, error)) {
+	registry.Lock()
+	defer registry.Unlock()
+	if len(prefix) == 0 {
+		panic("clickhouse: Register prefix is empty")
+	}
+	if factory == nil {
+		panic("clickhouse: Register factory is nil")
+	}
+	if _, dup := registry.factories[prefix]; dup {
+		panic("clickhouse: Register called twice for prefix " + prefix)
+	}
+	registry.factories[prefix] = factory
+}
+
+// registered returns the column of a registered type
+func registered(t Type) (_ Interface, ok bool, err error) {
+	registry.RLock()
+	var (
+		match   string
+		factory func(Type) (Interface, error)
+	)
+	for prefix, f := range registry.factories {
+		if strings.HasPrefix(string(t), prefix) && len(prefix) > len(match) {
+			match, factory = prefix, f
+		}
+	}
+	registry.RUnlock()
+	if factory == nil {
+		return nil, false, nil
+	}
+	col, err := factory(t)
+	return col, true, err
+}
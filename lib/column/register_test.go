@@ -0,0 +1,90 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package column
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// registeredTime is the column of a type the driver does not implement, stored as Int32
+type registeredTime struct {
+	Int32
+	chType Type
+}
+
+func (col *registeredTime) Type() Type {
+	return col.chType
+}
+
+func TestRegister(t *testing.T) {
+	Register("RegisteredTime", func(t Type) (Interface, error) {
+		return &registeredTime{chType: t}, nil
+	})
+	Register("RegisteredTime64(", func(t Type) (Interface, error) {
+		if t.params() == "" {
+			return nil, errors.New("the precision is not set")
+		}
+		return &registeredTime{chType: t}, nil
+	})
+	assert.Panics(t, func() {
+		Register("RegisteredTime", func(t Type) (Interface, error) {
+			return nil, nil
+		})
+	})
+	assert.Panics(t, func() {
+		Register("RegisteredNil", nil)
+	})
+
+	for _, chType := range []Type{
+		"RegisteredTime",
+		"Array(Nullable(RegisteredTime))",
+		"Map(String, RegisteredTime)",
+		"Tuple(RegisteredTime, String)",
+		"LowCardinality(RegisteredTime)",
+		"Variant(RegisteredTime, String)",
+		"RegisteredTime64(3)",
+	} {
+		col, err := chType.Column()
+		if assert.NoError(t, err, string(chType)) {
+			assert.Equal(t, chType, col.Type())
+		}
+	}
+	_, err := Type("RegisteredTime64()").Column()
+	assert.EqualError(t, err, "the precision is not set", "the longest prefix is used")
+	_, err = Type("Unregistered").Column()
+	assert.IsType(t, &UnsupportedColumnTypeError{}, err)
+
+	col, err := Type("Array(RegisteredTime)").Column()
+	require.NoError(t, err)
+	require.NoError(t, col.AppendRow([]int32{1, 2}))
+	var (
+		buf     bytes.Buffer
+		encoder = binary.NewEncoder(&buf)
+	)
+	require.NoError(t, col.Encode(encoder))
+	require.NoError(t, encoder.Flush())
+	decoded, err := col.Type().Column()
+	require.NoError(t, err)
+	require.NoError(t, decoded.Decode(binary.NewDecoder(&buf), 1))
+	assert.Equal(t, []int32{1, 2}, decoded.Row(0, false))
+}
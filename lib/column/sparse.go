@@ -0,0 +1,215 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// the kinds of serialization of the columns of a block with a custom serialization, see ISerialization::Kind
+const (
+	serializationDefault uint8 = 0
+	serializationSparse  uint8 = 1
+)
+
+// endOfGranule flags the last group of the offsets of a sparse column, the number of the trailing default rows
+const endOfGranule uint64 = 1 << 62
+
+// ReadSerializationKinds reads the kinds of serialization of col, those of the elements of a tuple follow its own,
+// and returns the column that reads the rows in these kinds
+func ReadSerializationKinds(col Interface, decoder *binary.Decoder) (Interface, error) {
+	kind, err := decoder.UInt8()
+	if err != nil {
+		return nil, err
+	}
+	tuple, isTuple := col.(*Tuple)
+	switch {
+	case kind == serializationDefault && isTuple:
+		for i, c := range tuple.columns {
+			if tuple.columns[i], err = ReadSerializationKinds(c, decoder); err != nil {
+				return nil, err
+			}
+		}
+		return col, nil
+	case kind == serializationDefault:
+		return col, nil
+	case kind == serializationSparse && !isTuple:
+		return &Sparse{values: col}, nil
+	}
+	return nil, &Error{
+		ColumnType: string(col.Type()),
+		Err:        fmt.Errorf("unsupported serialization kind %d", kind),
+	}
+}
+
+// Sparse is a column in the sparse serialization: the positions of the rows that do not have the default value
+// of the type (zero, an empty string), then the values of these rows. The appended rows are stored as values,
+// so the column can only be written when the rows read from the server have no default row.
+type Sparse struct {
+	values   Interface
+	defaults Interface // a single row with the default value
+	index    []int     // the row of values of each row, -1 for the default rows
+}
+
+func (col *Sparse) Type() Type {
+	return col.values.Type()
+}
+
+func (col *Sparse) ScanType() reflect.Type {
+	return col.values.ScanType()
+}
+
+// Base returns the column of the values
+func (col *Sparse) Base() Interface {
+	return col.values
+}
+
+func (col *Sparse) Rows() int {
+	return len(col.index)
+}
+
+func (col *Sparse) Row(i int, ptr bool) interface{} {
+	if row := col.index[i]; row >= 0 {
+		return col.values.Row(row, ptr)
+	}
+	return col.defaults.Row(0, ptr)
+}
+
+func (col *Sparse) ScanRow(dest interface{}, i int) error {
+	if row := col.index[i]; row >= 0 {
+		return col.values.ScanRow(dest, row)
+	}
+	return col.defaults.ScanRow(dest, 0)
+}
+
+func (col *Sparse) Append(v interface{}) (nulls []uint8, err error) {
+	rows := col.values.Rows()
+	if nulls, err = col.values.Append(v); err != nil {
+		return nil, err
+	}
+	for i := rows; i < col.values.Rows(); i++ {
+		col.index = append(col.index, i)
+	}
+	return nulls, nil
+}
+
+func (col *Sparse) AppendRow(v interface{}) error {
+	if err := col.values.AppendRow(v); err != nil {
+		return err
+	}
+	col.index = append(col.index, col.values.Rows()-1)
+	return nil
+}
+
+func (col *Sparse) Decode(decoder *binary.Decoder, rows int) error {
+	var (
+		index  = make([]int, 0, rows)
+		values = col.values.Rows()
+		first  = values
+	)
+	for {
+		group, err := decoder.Uvarint()
+		if err != nil {
+			return err
+		}
+		end := group&endOfGranule != 0
+		if group &^= endOfGranule; group > uint64(rows-len(index)) {
+			return &Error{
+				ColumnType: string(col.Type()),
+				Err:        fmt.Errorf("sparse offsets over the %d rows of the block", rows),
+			}
+		}
+		for i := uint64(0); i < group; i++ {
+			index = append(index, -1)
+		}
+		if end {
+			break
+		}
+		if len(index) == rows {
+			return &Error{
+				ColumnType: string(col.Type()),
+				Err:        fmt.Errorf("sparse offsets over the %d rows of the block", rows),
+			}
+		}
+		index, values = append(index, values), values+1
+	}
+	if len(index) != rows {
+		return &Error{
+			ColumnType: string(col.Type()),
+			Err:        fmt.Errorf("sparse offsets of %d rows, the block has %d", len(index), rows),
+		}
+	}
+	if err := col.values.Decode(decoder, values-first); err != nil {
+		return err
+	}
+	if values-first != rows && col.defaults == nil {
+		// the default value is the one of the zero bytes: the zero number, the empty string
+		defaults, err := col.Type().Column()
+		if err != nil {
+			return err
+		}
+		if err := defaults.Decode(binary.NewDecoder(zeros{}), 1); err != nil {
+			return err
+		}
+		col.defaults = defaults
+	}
+	col.index = append(col.index, index...)
+	return nil
+}
+
+func (col *Sparse) Encode(encoder *binary.Encoder) error {
+	if col.values.Rows() != len(col.index) {
+		return &Error{
+			ColumnType: string(col.Type()),
+			Err:        errors.New("the default rows of a sparse column cannot be written"),
+		}
+	}
+	return col.values.Encode(encoder)
+}
+
+func (col *Sparse) ReadStatePrefix(decoder *binary.Decoder) error {
+	if serialize, ok := col.values.(CustomSerialization); ok {
+		return serialize.ReadStatePrefix(decoder)
+	}
+	return nil
+}
+
+func (col *Sparse) WriteStatePrefix(encoder *binary.Encoder) error {
+	if serialize, ok := col.values.(CustomSerialization); ok {
+		return serialize.WriteStatePrefix(encoder)
+	}
+	return nil
+}
+
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+var (
+	_ Interface           = (*Sparse)(nil)
+	_ CustomSerialization = (*Sparse)(nil)
+)
@@ -0,0 +1,132 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+// sparseColumn reads the kinds and the rows the way a block does
+func sparseColumn(t *testing.T, chType Type, rows int, write func(*binary.Encoder)) (Interface, error) {
+	var (
+		buf     bytes.Buffer
+		encoder = binary.NewEncoder(&buf)
+	)
+	write(encoder)
+	require.NoError(t, encoder.Flush())
+	col, err := chType.Column()
+	require.NoError(t, err)
+	decoder := binary.NewDecoder(&buf)
+	if col, err = ReadSerializationKinds(col, decoder); err != nil {
+		return nil, err
+	}
+	if err := col.Decode(decoder, rows); err != nil {
+		return nil, err
+	}
+	assert.Zero(t, buf.Len())
+	return col, nil
+}
+
+func TestSparse(t *testing.T) {
+	col, err := sparseColumn(t, "UInt64", 6, func(encoder *binary.Encoder) {
+		encoder.UInt8(serializationSparse)
+		encoder.Uvarint(1) // the default row 0, then the value of the row 1
+		encoder.Uvarint(2) // the default rows 2 and 3, then the value of the row 4
+		encoder.Uvarint(1 | endOfGranule)
+		encoder.UInt64(7)
+		encoder.UInt64(9)
+	})
+	require.NoError(t, err)
+	require.IsType(t, &Sparse{}, col)
+	assert.Equal(t, Type("UInt64"), col.Type())
+	assert.Equal(t, 6, col.Rows())
+	var values []uint64
+	for i := 0; i < col.Rows(); i++ {
+		var v uint64
+		require.NoError(t, col.ScanRow(&v, i))
+		values = append(values, v)
+	}
+	assert.Equal(t, []uint64{0, 7, 0, 0, 9, 0}, values)
+	assert.Equal(t, uint64(9), col.Row(4, false))
+	assert.Equal(t, uint64(0), col.Row(5, false))
+	assert.Error(t, col.Encode(binary.NewEncoder(&bytes.Buffer{})), "the default rows are not written")
+
+	col, err = sparseColumn(t, "String", 3, func(encoder *binary.Encoder) {
+		encoder.UInt8(serializationSparse)
+		encoder.Uvarint(2)
+		encoder.Uvarint(0 | endOfGranule)
+		encoder.String("last")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"", "", "last"}, []interface{}{col.Row(0, false), col.Row(1, false), col.Row(2, false)})
+
+	_, err = sparseColumn(t, "UInt8", 2, func(encoder *binary.Encoder) {
+		encoder.UInt8(serializationSparse)
+		encoder.Uvarint(3 | endOfGranule)
+	})
+	assert.Error(t, err, "more rows than the block")
+	_, err = sparseColumn(t, "UInt8", 1, func(encoder *binary.Encoder) {
+		encoder.UInt8(3)
+	})
+	assert.Error(t, err, "unknown kind")
+}
+
+func TestSparseTuple(t *testing.T) {
+	col, err := sparseColumn(t, "Tuple(name String, count Int32)", 3, func(encoder *binary.Encoder) {
+		encoder.UInt8(serializationDefault) // the tuple
+		encoder.UInt8(serializationDefault) // name
+		encoder.UInt8(serializationSparse)  // count
+		for _, name := range []string{"a", "b", "c"} {
+			encoder.String(name)
+		}
+		encoder.Uvarint(1)
+		encoder.Uvarint(1 | endOfGranule)
+		encoder.Int32(5)
+	})
+	require.NoError(t, err)
+	require.IsType(t, &Tuple{}, col)
+	assert.IsType(t, &Sparse{}, col.(*Tuple).columns[1])
+	assert.Equal(t, 3, col.Rows())
+	var row []interface{}
+	require.NoError(t, col.ScanRow(&row, 1))
+	assert.Equal(t, []interface{}{"b", int32(5)}, row)
+	require.NoError(t, col.ScanRow(&row, 2))
+	assert.Equal(t, []interface{}{"c", int32(0)}, row)
+}
+
+func TestSparseAppend(t *testing.T) {
+	// the header of an INSERT has no rows, the appended rows are written as they are
+	col, err := sparseColumn(t, "Int16", 0, func(encoder *binary.Encoder) {
+		encoder.UInt8(serializationSparse)
+		encoder.Uvarint(0 | endOfGranule)
+	})
+	require.NoError(t, err)
+	require.NoError(t, col.AppendRow(int16(0)))
+	_, err = col.Append([]int16{1, 2})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	encoder := binary.NewEncoder(&buf)
+	require.NoError(t, col.Encode(encoder))
+	require.NoError(t, encoder.Flush())
+	assert.Equal(t, []byte{0, 0, 1, 0, 2, 0}, buf.Bytes())
+}
@@ -27,56 +27,92 @@ import (
 
 type Tuple struct {
 	chType  Type
+	names   []string // the names of the elements of a named tuple, empty otherwise
 	columns []Interface
 }
 
 func (col *Tuple) parse(t Type) (_ Interface, err error) {
 	col.chType = t
+	for _, element := range splitElements(t.params()) {
+		name, ct := tupleElement(element)
+		column, err := Type(ct).Column()
+		if err != nil {
+			return nil, err
+		}
+		col.names, col.columns = append(col.names, name), append(col.columns, column)
+	}
+	if len(col.columns) != 0 {
+		return col, nil
+	}
+	return nil, &UnsupportedColumnTypeError{
+		t: t,
+	}
+}
+
+// splitElements splits the parameters of a type on the commas that are not nested in brackets or quoted names
+func splitElements(params string) []string {
 	var (
 		element       []rune
 		elements      []string
 		brackets      int
+		quoted        bool
+		escaped       bool
 		appendElement = func() {
 			if len(element) != 0 {
-				name := strings.TrimSpace(string(element))
-				if parts := strings.SplitN(name, " ", 2); len(parts) == 2 {
-					if !strings.Contains(parts[0], "(") {
-						name = parts[1]
-					}
-				}
-				elements = append(elements, name)
+				elements = append(elements, string(element))
 			}
 		}
 	)
-	for _, r := range t.params() {
-		switch r {
-		case '(':
+	for _, r := range params {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '`':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
 			brackets++
-		case ')':
+		case r == ')':
 			brackets--
-		case ',':
-			if brackets == 0 {
-				appendElement()
-				element = element[:0]
-				continue
-			}
+		case r == ',' && brackets == 0:
+			appendElement()
+			element = element[:0]
+			continue
 		}
 		element = append(element, r)
 	}
 	appendElement()
-	for _, ct := range elements {
-		column, err := Type(strings.TrimSpace(ct)).Column()
-		if err != nil {
-			return nil, err
+	return elements
+}
+
+// tupleElement splits the "name Type" element of a named tuple, the name may be quoted with backticks
+func tupleElement(element string) (name string, t string) {
+	element = strings.TrimSpace(element)
+	if strings.HasPrefix(element, "`") {
+		var unquoted []byte
+		for i := 1; i < len(element); i++ {
+			switch c := element[i]; {
+			case c == '\\' && i+1 < len(element):
+				i++
+				unquoted = append(unquoted, element[i])
+			case c == '`':
+				return string(unquoted), strings.TrimSpace(element[i+1:])
+			default:
+				unquoted = append(unquoted, c)
+			}
 		}
-		col.columns = append(col.columns, column)
-	}
-	if len(col.columns) != 0 {
-		return col, nil
 	}
-	return nil, &UnsupportedColumnTypeError{
-		t: t,
+	if parts := strings.SplitN(element, " ", 2); len(parts) == 2 && !strings.Contains(parts[0], "(") {
+		return parts[0], strings.TrimSpace(parts[1])
 	}
+	return "", element
+}
+
+// quoteName quotes the name of a tuple element with backticks
+func quoteName(name string) string {
+	return "`" + strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name) + "`"
 }
 
 func (col *Tuple) Type() Type {
@@ -0,0 +1,282 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+const (
+	// variantBasicMode is the serialization of the discriminators written in the state prefix, one per row
+	variantBasicMode uint64 = 0
+	// variantNull is the discriminator of the NULL rows
+	variantNull uint8 = 255
+)
+
+var scanTypeVariant = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// VariantValue is a value of a Variant column with the type of the variant that holds it, Type is empty for NULL.
+// Appending a VariantValue sets the variant instead of picking it from the type of the value.
+type VariantValue struct {
+	Type  Type
+	Value interface{}
+}
+
+// Variant is a Variant(T1, T2, ...) column. The variants are sorted by type name as the server does, the discriminator
+// of a row is the index of its variant (255 for NULL) and each variant column holds the rows of its type only.
+type Variant struct {
+	chType         Type
+	types          []Type
+	columns        []Interface
+	discriminators UInt8
+	offsets        []int // the row of the variant column of each row
+}
+
+func (col *Variant) parse(t Type) (_ Interface, err error) {
+	col.chType = t
+	for _, element := range splitElements(t.params()) {
+		col.types = append(col.types, Type(strings.TrimSpace(element)))
+	}
+	sort.Slice(col.types, func(i, j int) bool {
+		return col.types[i] < col.types[j]
+	})
+	if len(col.types) == 0 || len(col.types) >= int(variantNull) {
+		return nil, &UnsupportedColumnTypeError{
+			t: t,
+		}
+	}
+	for _, variant := range col.types {
+		column, err := variant.Column()
+		if err != nil {
+			return nil, err
+		}
+		col.columns = append(col.columns, column)
+	}
+	return col, nil
+}
+
+func (col *Variant) Type() Type {
+	return col.chType
+}
+
+func (col *Variant) ScanType() reflect.Type {
+	return scanTypeVariant
+}
+
+func (col *Variant) Rows() int {
+	return len(col.discriminators)
+}
+
+func (col *Variant) Row(i int, ptr bool) interface{} {
+	if d := col.discriminators[i]; d != variantNull {
+		return col.columns[d].Row(col.offsets[i], ptr)
+	}
+	return nil
+}
+
+// ScanRow scans the value into *VariantValue, *interface{} or the type of the variant,
+// dest is left unchanged by NULL as with Nullable
+func (col *Variant) ScanRow(dest interface{}, row int) error {
+	d := col.discriminators[row]
+	switch v := dest.(type) {
+	case *VariantValue:
+		*v = VariantValue{}
+		if d != variantNull {
+			*v = VariantValue{
+				Type:  col.types[d],
+				Value: col.columns[d].Row(col.offsets[row], false),
+			}
+		}
+		return nil
+	case *interface{}:
+		*v = col.Row(row, false)
+		return nil
+	}
+	if d == variantNull {
+		return nil
+	}
+	return col.columns[d].ScanRow(dest, col.offsets[row])
+}
+
+func (col *Variant) Append(v interface{}) (nulls []uint8, err error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "value must be a slice",
+		}
+	}
+	for i := 0; i < value.Len(); i++ {
+		if err := col.AppendRow(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// AppendRow appends the value to the variant of its type, or to the first variant that accepts it.
+// A VariantValue appends to the variant of its Type
+func (col *Variant) AppendRow(v interface{}) error {
+	switch value := v.(type) {
+	case VariantValue:
+		return col.appendVariant(value)
+	case *VariantValue:
+		if value != nil {
+			return col.appendVariant(*value)
+		}
+	}
+	if value := reflect.ValueOf(v); !value.IsValid() || value.Kind() == reflect.Ptr && value.IsNil() {
+		col.discriminators, col.offsets = append(col.discriminators, variantNull), append(col.offsets, 0)
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	for i, c := range col.columns {
+		if scanType := c.ScanType(); t == scanType || t.Kind() == reflect.Ptr && t.Elem() == scanType {
+			return col.append(i, v)
+		}
+	}
+	for i, c := range col.columns {
+		rows := c.Rows()
+		if err := c.AppendRow(v); err == nil {
+			col.discriminators, col.offsets = append(col.discriminators, uint8(i)), append(col.offsets, rows)
+			return nil
+		} else if c.Rows() != rows {
+			return err
+		}
+	}
+	return &ColumnConverterError{
+		Op:   "AppendRow",
+		To:   string(col.chType),
+		From: fmt.Sprintf("%T", v),
+		Hint: "use column.VariantValue to set the variant",
+	}
+}
+
+func (col *Variant) appendVariant(v VariantValue) error {
+	if len(v.Type) == 0 && v.Value == nil {
+		return col.AppendRow(nil)
+	}
+	for i, t := range col.types {
+		if t == v.Type {
+			return col.append(i, v.Value)
+		}
+	}
+	return &Error{
+		ColumnType: string(col.chType),
+		Err:        fmt.Errorf("unknown variant %s", v.Type),
+	}
+}
+
+func (col *Variant) append(i int, v interface{}) error {
+	rows := col.columns[i].Rows()
+	if err := col.columns[i].AppendRow(v); err != nil {
+		return err
+	}
+	col.discriminators, col.offsets = append(col.discriminators, uint8(i)), append(col.offsets, rows)
+	return nil
+}
+
+func (col *Variant) Decode(decoder *binary.Decoder, rows int) error {
+	if err := col.discriminators.Decode(decoder, rows); err != nil {
+		return err
+	}
+	var (
+		counts  = make([]int, len(col.columns))
+		offsets = make([]int, 0, rows)
+	)
+	for _, d := range col.discriminators[len(col.offsets):] {
+		switch {
+		case d == variantNull:
+			offsets = append(offsets, 0)
+		case int(d) < len(col.columns):
+			offsets = append(offsets, col.columns[d].Rows()+counts[d])
+			counts[d]++
+		default:
+			return &Error{
+				ColumnType: string(col.chType),
+				Err:        fmt.Errorf("invalid discriminator %d", d),
+			}
+		}
+	}
+	col.offsets = append(col.offsets, offsets...)
+	for i, c := range col.columns {
+		if err := c.Decode(decoder, counts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (col *Variant) Encode(encoder *binary.Encoder) error {
+	if err := col.discriminators.Encode(encoder); err != nil {
+		return err
+	}
+	for _, c := range col.columns {
+		if err := c.Encode(encoder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (col *Variant) ReadStatePrefix(decoder *binary.Decoder) error {
+	mode, err := decoder.UInt64()
+	if err != nil {
+		return err
+	}
+	if mode != variantBasicMode {
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        fmt.Errorf("unsupported discriminators serialization mode %d", mode),
+		}
+	}
+	for _, c := range col.columns {
+		if serialize, ok := c.(CustomSerialization); ok {
+			if err := serialize.ReadStatePrefix(decoder); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (col *Variant) WriteStatePrefix(encoder *binary.Encoder) error {
+	if err := encoder.UInt64(variantBasicMode); err != nil {
+		return err
+	}
+	for _, c := range col.columns {
+		if serialize, ok := c.(CustomSerialization); ok {
+			if err := serialize.WriteStatePrefix(encoder); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	_ Interface           = (*Variant)(nil)
+	_ CustomSerialization = (*Variant)(nil)
+)
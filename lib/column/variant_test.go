@@ -0,0 +1,94 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+)
+
+func TestVariant(t *testing.T) {
+	col, err := Type("Variant(String, Int64, Array(UInt8), LowCardinality(String))").Column()
+	require.NoError(t, err)
+	variant := col.(*Variant)
+	assert.Equal(t, []Type{"Array(UInt8)", "Int64", "LowCardinality(String)", "String"}, variant.types, "sorted by name")
+
+	name := "ptr"
+	for _, v := range []interface{}{
+		"text",
+		int64(42),
+		nil,
+		[]uint8{1, 2},
+		&name,
+		VariantValue{Type: "String", Value: "low"},
+		(*string)(nil),
+		VariantValue{},
+		int64(-1),
+	} {
+		require.NoError(t, col.AppendRow(v))
+	}
+	assert.Error(t, col.AppendRow(3.14), "no variant takes a float")
+	assert.Error(t, col.AppendRow(VariantValue{Type: "UInt8", Value: uint8(1)}))
+	assert.Equal(t, []uint8{2, 1, variantNull, 0, 2, 3, variantNull, variantNull, 1}, []uint8(variant.discriminators), "the first variant of the type unless set")
+
+	decoded, data := roundTrip(t, col)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0}, data[:8], "the basic discriminators mode")
+	expected := []interface{}{"text", int64(42), nil, []uint8{1, 2}, "ptr", "low", nil, nil, int64(-1)}
+	for i, v := range expected {
+		assert.Equal(t, v, decoded.Row(i, false), "row %d", i)
+	}
+
+	var value VariantValue
+	require.NoError(t, decoded.ScanRow(&value, 5))
+	assert.Equal(t, VariantValue{Type: "String", Value: "low"}, value)
+	require.NoError(t, decoded.ScanRow(&value, 2))
+	assert.Equal(t, VariantValue{}, value)
+
+	var any interface{}
+	require.NoError(t, decoded.ScanRow(&any, 1))
+	assert.Equal(t, int64(42), any)
+
+	var n int64
+	require.NoError(t, decoded.ScanRow(&n, 8))
+	assert.Equal(t, int64(-1), n)
+	require.NoError(t, decoded.ScanRow(&n, 2), "NULL leaves the destination unchanged")
+	assert.Equal(t, int64(-1), n)
+	assert.Error(t, decoded.ScanRow(&n, 0), "the String variant is not an int64")
+}
+
+func TestVariantDiscriminators(t *testing.T) {
+	col, err := Type("Variant(String, UInt8, UInt16)").Column()
+	require.NoError(t, err)
+	require.NoError(t, col.AppendRow(uint16(1)))
+	_, data := roundTrip(t, col)
+	assert.Equal(t, uint8(1), data[8], "UInt16 sorts before UInt8")
+
+	data[8] = 3
+	decoded, err := col.Type().Column()
+	require.NoError(t, err)
+	decoder := binary.NewDecoder(bytes.NewReader(data))
+	require.NoError(t, decoded.(CustomSerialization).ReadStatePrefix(decoder))
+	assert.Error(t, decoded.Decode(decoder, 1))
+
+	_, err = Type("Variant()").Column()
+	assert.Error(t, err)
+}
@@ -19,6 +19,8 @@ package compress
 
 import (
 	"encoding/binary"
+	"fmt"
+	"sync/atomic"
 )
 
 var endian = binary.LittleEndian
@@ -27,10 +29,25 @@ type Method byte
 
 const (
 	NONE Method = 0x02
-	LZ4         = 0x82
-	ZSTD        = 0x90
+	LZ4  Method = 0x82
+	ZSTD Method = 0x90
 )
 
+func (m Method) String() string {
+	switch m {
+	case NONE:
+		return "NONE"
+	case LZ4:
+		return "LZ4"
+	case ZSTD:
+		return "ZSTD"
+	}
+	return fmt.Sprintf("unknown(0x%02x)", byte(m))
+}
+
+// DefaultZSTDLevel is the level used by the server when network_zstd_compression_level is not set
+const DefaultZSTDLevel = 1
+
 const (
 	// ChecksumSize is 128bits for cityhash102 checksum
 	checksumSize = 16
@@ -38,4 +55,20 @@ const (
 	compressHeaderSize = 1 + 4 + 4
 	headerSize         = checksumSize + compressHeaderSize
 	maxBlockSize       = 1 << 20
+	// maxCompressedSize is DBMS_MAX_COMPRESSED_SIZE of the server
+	maxCompressedSize = 1 << 30
 )
+
+// Stats counts the bytes of the blocks going through readers and writers, it may be shared between them
+type Stats struct {
+	Compressed   int64 // size of the blocks on the wire, headers and checksums included
+	Uncompressed int64 // size of the same blocks once decompressed
+}
+
+func (s *Stats) add(compressed, uncompressed int) {
+	if s == nil {
+		return
+	}
+	atomic.AddInt64(&s.Compressed, int64(compressed))
+	atomic.AddInt64(&s.Uncompressed, int64(uncompressed))
+}
@@ -21,25 +21,53 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v4"
+	"github.com/supresu/clickhouse-go/v2/lib/cityhash102"
 )
 
+// ChecksumError is returned when the CityHash128 checksum of a compressed frame does not match its content
+type ChecksumError struct {
+	Expected cityhash102.Uint128
+	Actual   cityhash102.Uint128
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("compressed block checksum mismatch: expected %016x%016x, got %016x%016x",
+		e.Expected.Higher64(), e.Expected.Lower64(),
+		e.Actual.Higher64(), e.Actual.Lower64(),
+	)
+}
+
 func NewReader(r io.Reader) *Reader {
 	return &Reader{
 		r:      r,
 		pos:    maxBlockSize,
 		data:   make([]byte, maxBlockSize),
-		zdata:  make([]byte, lz4.CompressBlockBound(maxBlockSize)+headerSize),
+		zdata:  make([]byte, lz4.CompressBlockBound(maxBlockSize)+compressHeaderSize),
 		header: make([]byte, headerSize),
 	}
 }
 
 type Reader struct {
-	r      io.Reader
-	pos    int
-	data   []byte
-	zdata  []byte
-	header []byte
+	r            io.Reader
+	pos          int
+	data         []byte
+	zdata        []byte
+	header       []byte
+	zstd         *zstd.Decoder
+	skipChecksum bool
+	stats        *Stats
+}
+
+// SkipChecksum disables the verification of the compressed frames checksum, for benchmarking only
+func (r *Reader) SkipChecksum(v bool) {
+	r.skipChecksum = v
+}
+
+// SetStats makes the reader count the blocks it reads into s
+func (r *Reader) SetStats(s *Stats) {
+	r.stats = s
 }
 
 func (r *Reader) Read(p []byte) (int, error) {
@@ -71,40 +99,82 @@ func (r *Reader) readBlock() (err error) {
 		return
 	}
 	if n != len(r.header) {
-		return fmt.Errorf("LZ4 decompression header EOF")
+		return fmt.Errorf("decompression header EOF")
 	}
 	var (
-		compressedSize   = int(endian.Uint32(r.header[17:])) - 9
+		method           = Method(r.header[16])
+		compressedSize   = int(endian.Uint32(r.header[17:])) - compressHeaderSize
 		decompressedSize = int(endian.Uint32(r.header[21:]))
 	)
-	if compressedSize > cap(r.zdata) {
-		r.zdata = make([]byte, compressedSize)
+	switch method {
+	case NONE, LZ4, ZSTD:
+	default:
+		return fmt.Errorf("unknown compression method: 0x%02x ", r.header[16])
+	}
+	if compressedSize < 0 || compressedSize > maxCompressedSize || decompressedSize > maxCompressedSize {
+		return fmt.Errorf("invalid compressed block size: compressed=%d, decompressed=%d", compressedSize, decompressedSize)
+	}
+	// the checksum covers the method and sizes as well as the payload, so keep them together
+	if compressedSize+compressHeaderSize > cap(r.zdata) {
+		r.zdata = make([]byte, compressedSize+compressHeaderSize)
 	}
 	if decompressedSize > cap(r.data) {
 		r.data = make([]byte, decompressedSize)
 	}
-
-	r.data, r.zdata = r.data[:decompressedSize], r.zdata[:compressedSize]
-
-	switch r.header[16] {
-	case LZ4:
-	default:
-		return fmt.Errorf("unknown compression method: 0x%02x ", r.header[16])
-	}
-	// @TODO checksum
-	if n, err = io.ReadFull(r.r, r.zdata); err != nil {
+	r.data, r.zdata = r.data[:decompressedSize], r.zdata[:compressedSize+compressHeaderSize]
+	copy(r.zdata, r.header[checksumSize:])
+	if n, err = io.ReadFull(r.r, r.zdata[compressHeaderSize:]); err != nil {
 		return
 	}
-	if n != len(r.zdata) {
+	if n != compressedSize {
 		return fmt.Errorf("decompress read size not match")
 	}
-	if _, err = lz4.UncompressBlock(r.zdata, r.data); err != nil {
-		return
+	if !r.skipChecksum {
+		var (
+			expected = cityhash102.Uint128{endian.Uint64(r.header[0:]), endian.Uint64(r.header[8:])}
+			actual   = cityhash102.CityHash128(r.zdata, uint32(len(r.zdata)))
+		)
+		if expected != actual {
+			return &ChecksumError{
+				Expected: expected,
+				Actual:   actual,
+			}
+		}
+	}
+	zdata := r.zdata[compressHeaderSize:]
+	switch method {
+	case LZ4:
+		if _, err = lz4.UncompressBlock(zdata, r.data); err != nil {
+			return
+		}
+	case ZSTD:
+		if r.zstd == nil {
+			if r.zstd, err = zstd.NewReader(nil); err != nil {
+				return
+			}
+		}
+		var data []byte
+		if data, err = r.zstd.DecodeAll(zdata, r.data[:0]); err != nil {
+			return
+		}
+		if len(data) != decompressedSize {
+			return fmt.Errorf("ZSTD decompressed size mismatch: expected %d, got %d", decompressedSize, len(data))
+		}
+	case NONE:
+		if compressedSize != decompressedSize {
+			return fmt.Errorf("NONE compressed size %d does not match decompressed size %d", compressedSize, decompressedSize)
+		}
+		copy(r.data, zdata)
 	}
+	r.stats.add(checksumSize+len(r.zdata), decompressedSize)
 	return nil
 }
 
 func (r *Reader) Close() error {
+	if r.zstd != nil {
+		r.zstd.Close()
+		r.zstd = nil
+	}
 	r.data = nil
 	r.zdata = nil
 	return nil
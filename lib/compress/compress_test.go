@@ -0,0 +1,145 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package compress
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var (
+		random = make([]byte, 3*maxBlockSize+17)
+		repeat = bytes.Repeat([]byte("clickhouse "), 100_000)
+	)
+	rand.Read(random)
+	for _, method := range []Method{NONE, LZ4, ZSTD} {
+		for name, data := range map[string][]byte{"random": random, "repeat": repeat} {
+			t.Run(method.String()+"/"+name, func(t *testing.T) {
+				var (
+					buf bytes.Buffer
+					w   = NewWriter(&buf, method, 3)
+				)
+				_, err := w.Write(data)
+				require.NoError(t, err)
+				require.NoError(t, w.Flush())
+				if method == NONE {
+					assert.Greater(t, buf.Len(), len(data))
+				}
+				var (
+					r      = NewReader(&buf)
+					actual = make([]byte, len(data))
+				)
+				_, err = io.ReadFull(r, actual)
+				require.NoError(t, err)
+				assert.Equal(t, data, actual)
+			})
+		}
+	}
+}
+
+func TestUnknownMethod(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, LZ4, 0)
+	_, err := w.Write([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	frame := buf.Bytes()
+	frame[16] = 0x42
+	_, err = io.ReadFull(NewReader(bytes.NewReader(frame)), make([]byte, 4))
+	assert.Error(t, err)
+}
+
+func TestChecksum(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, NONE, 0)
+	_, err := w.Write(bytes.Repeat([]byte("clickhouse "), 100))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	frame := buf.Bytes()
+	frame[len(frame)-1] ^= 0xff
+	{
+		_, err := io.ReadFull(NewReader(bytes.NewReader(frame)), make([]byte, 1100))
+		var checksumErr *ChecksumError
+		if assert.ErrorAs(t, err, &checksumErr) {
+			assert.NotEqual(t, checksumErr.Expected, checksumErr.Actual)
+		}
+	}
+	{
+		r := NewReader(bytes.NewReader(frame))
+		r.SkipChecksum(true)
+		_, err := io.ReadFull(r, make([]byte, 1100))
+		assert.NoError(t, err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		data = bytes.Repeat([]byte("clickhouse "), 100_000)
+		ws   Stats
+		rs   Stats
+		w    = NewWriter(&buf, LZ4, 0)
+		r    = NewReader(&buf)
+	)
+	w.SetStats(&ws)
+	r.SetStats(&rs)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, int64(len(data)), ws.Uncompressed)
+	assert.Equal(t, int64(buf.Len()), ws.Compressed)
+	assert.Less(t, ws.Compressed, ws.Uncompressed)
+	_, err = io.ReadFull(r, make([]byte, len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, ws, rs)
+}
+
+func BenchmarkReader(b *testing.B) {
+	var (
+		buf  bytes.Buffer
+		data = bytes.Repeat([]byte("clickhouse "), 100_000)
+		w    = NewWriter(&buf, LZ4, 0)
+	)
+	if _, err := w.Write(data); err != nil {
+		b.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		b.Fatal(err)
+	}
+	for _, skipChecksum := range []bool{false, true} {
+		name := "checksum"
+		if skipChecksum {
+			name = "skip_checksum"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				r := NewReader(bytes.NewReader(buf.Bytes()))
+				r.SkipChecksum(skipChecksum)
+				if _, err := io.ReadFull(r, make([]byte, len(data))); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -18,18 +18,21 @@
 package compress
 
 import (
+	"fmt"
 	"io"
-	"log"
 
-	"github.com/supresu/clickhouse-go/v2/lib/cityhash102"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v4"
+	"github.com/supresu/clickhouse-go/v2/lib/cityhash102"
 )
 
-func NewWriter(wr io.Writer) *Writer {
+func NewWriter(wr io.Writer, method Method, level int) *Writer {
 	return &Writer{
-		wr:    wr,
-		data:  make([]byte, maxBlockSize),
-		zdata: make([]byte, lz4.CompressBlockBound(maxBlockSize)+headerSize),
+		wr:     wr,
+		level:  level,
+		method: method,
+		data:   make([]byte, maxBlockSize),
+		zdata:  make([]byte, lz4.CompressBlockBound(maxBlockSize)+headerSize),
 	}
 }
 
@@ -38,7 +41,16 @@ type Writer struct {
 	pos        int
 	data       []byte
 	zdata      []byte
+	level      int
+	method     Method
 	compressor lz4.Compressor
+	zstd       *zstd.Encoder
+	stats      *Stats
+}
+
+// SetStats makes the writer count the blocks it writes into s
+func (w *Writer) SetStats(s *Stats) {
+	w.stats = s
 }
 
 func (w *Writer) Write(p []byte) (n int, err error) {
@@ -60,13 +72,33 @@ func (w *Writer) Flush() (err error) {
 	if w.pos == 0 {
 		return
 	}
-	compressedSize, err := w.compressor.CompressBlock(w.data[:w.pos], w.zdata[headerSize:])
-	if err != nil {
-		return err
+	var compressedSize int
+	switch w.method {
+	case LZ4:
+		if compressedSize, err = w.compressor.CompressBlock(w.data[:w.pos], w.zdata[headerSize:]); err != nil {
+			return err
+		}
+	case ZSTD:
+		if w.zstd == nil {
+			level := w.level
+			if level == 0 {
+				level = DefaultZSTDLevel
+			}
+			if w.zstd, err = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level))); err != nil {
+				return err
+			}
+		}
+		// EncodeAll appends to the header and may grow the buffer for incompressible data
+		w.zdata = w.zstd.EncodeAll(w.data[:w.pos], w.zdata[:headerSize])
+		compressedSize = len(w.zdata) - headerSize
+	case NONE:
+		compressedSize = copy(w.zdata[headerSize:], w.data[:w.pos])
+	default:
+		return fmt.Errorf("unknown compression method: 0x%02x", byte(w.method))
 	}
 	compressedSize += compressHeaderSize
 	// fill the header, compressed_size_32 + uncompressed_size_32
-	w.zdata[16] = LZ4
+	w.zdata[16] = byte(w.method)
 	endian.PutUint32(w.zdata[17:], uint32(compressedSize))
 	endian.PutUint32(w.zdata[21:], uint32(w.pos))
 	// fill the checksum
@@ -75,18 +107,19 @@ func (w *Writer) Flush() (err error) {
 		endian.PutUint64(w.zdata[0:], checkSum.Lower64())
 		endian.PutUint64(w.zdata[8:], checkSum.Higher64())
 	}
-	log.Printf("[compress_writer] %v %v", w.data, w.zdata) // TODO
 	if _, err := w.wr.Write(w.zdata[:compressedSize+checksumSize]); err != nil {
 		return err
 	}
-	/*if w, ok := cw.writer.(WriteFlusher); ok {
-		err = w.Flush()
-	}*/
+	w.stats.add(compressedSize+checksumSize, w.pos)
 	w.pos = 0
 	return
 }
 
 func (w *Writer) Close() error {
+	if w.zstd != nil {
+		w.zstd.Close()
+		w.zstd = nil
+	}
 	w.data = nil
 	w.zdata = nil
 	return nil
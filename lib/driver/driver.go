@@ -20,11 +20,15 @@ package driver
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/supresu/clickhouse-go/v2/lib/proto"
 )
 
-type ServerVersion = proto.ServerHandshake
+type (
+	ServerVersion     = proto.ServerHandshake
+	ColumnDescription = proto.ColumnDescription
+)
 
 type (
 	NamedValue struct {
@@ -32,13 +36,67 @@ type (
 		Value interface{}
 	}
 	Stats struct {
-		MaxOpenConns int
-		MaxIdleConns int
-		Open         int
-		Idle         int
+		MaxOpenConns      int
+		MaxIdleConns      int
+		Open              int
+		Idle              int
+		MaxIdleTimeClosed int64 // idle connections closed because of ConnMaxIdleTime
+		MaxLifetimeClosed int64 // connections closed because of ConnMaxLifetime
+		KeepAlivePings    int64 // pings sent to idle connections
+		KeepAliveFailures int64 // idle connections closed because the ping failed
+		ErrorClosed       int64 // connections closed after an error
+		BadClosed         int64 // idle connections found broken on acquire
+		WaitCount         int64 // acquires that had to wait for a free connection
+		WaitDuration      time.Duration
+		Dials             map[string]DialStats // by address
+		Hosts             map[string]HostStats // by address
+		BytesRead         int64
+		BytesWritten      int64
+		CompressedBytes   int64 // size of the compressed blocks read and written
+		UncompressedBytes int64 // size of the same blocks once decompressed
+	}
+	DialStats struct {
+		Attempts int64
+		Failures int64
+	}
+	HostStats struct {
+		State     HostState
+		Open      int       // connections open to the host
+		Failures  int       // consecutive dial failures
+		LastError error     // of the last failed dial
+		RetryAt   time.Time // when a down host is probed again
 	}
 )
 
+type HostState uint8
+
+const (
+	HostUp      HostState = iota
+	HostDown              // the host failed to dial and is skipped until RetryAt
+	HostProbing           // a connection is being dialed to check that the host recovered
+)
+
+func (s HostState) String() string {
+	switch s {
+	case HostUp:
+		return "up"
+	case HostDown:
+		return "down"
+	case HostProbing:
+		return "probing"
+	}
+	return "unknown"
+}
+
+// CompressionRatio is the uncompressed size of the blocks divided by their compressed size,
+// it is 0 when nothing was compressed
+func (s Stats) CompressionRatio() float64 {
+	if s.CompressedBytes == 0 {
+		return 0
+	}
+	return float64(s.UncompressedBytes) / float64(s.CompressedBytes)
+}
+
 type (
 	Conn interface {
 		Contributors() []string
@@ -51,6 +109,16 @@ type (
 		AsyncInsert(ctx context.Context, query string, wait bool) error
 		Ping(context.Context) error
 		Stats() Stats
+		Acquire(ctx context.Context) (Session, error)
+		Close() error
+	}
+	// Session runs its statements on the same connection until it is closed
+	Session interface {
+		Query(ctx context.Context, query string, args ...interface{}) (Rows, error)
+		QueryRow(ctx context.Context, query string, args ...interface{}) Row
+		Exec(ctx context.Context, query string, args ...interface{}) error
+		PrepareBatch(ctx context.Context, query string) (Batch, error)
+		SetSettings(settings map[string]interface{})
 		Close() error
 	}
 	Row interface {
@@ -64,6 +132,7 @@ type (
 		ScanStruct(dest interface{}) error
 		ColumnTypes() []ColumnType
 		Totals(dest ...interface{}) error
+		Extremes(dest ...interface{}) error
 		Columns() []string
 		Close() error
 		Err() error
@@ -73,6 +142,7 @@ type (
 		Append(v ...interface{}) error
 		AppendStruct(v interface{}) error
 		Column(int) BatchColumn
+		Columns() []ColumnDescription
 		Send() error
 	}
 	BatchColumn interface {
@@ -29,13 +29,13 @@ const (
 	maxWriterSize = 1 << 20
 )
 
-func NewStream(rw io.ReadWriter) *Stream {
+func NewStream(rw io.ReadWriter, method compress.Method, level int) *Stream {
 	stream := Stream{
 		r: bufio.NewReaderSize(rw, maxReaderSize),
 		w: bufio.NewWriterSize(rw, maxWriterSize),
 	}
 	stream.compress.r = compress.NewReader(stream.r)
-	stream.compress.w = compress.NewWriter(stream.w)
+	stream.compress.w = compress.NewWriter(stream.w, method, level)
 	return &stream
 }
 
@@ -53,6 +53,16 @@ func (s *Stream) Compress(v bool) {
 	s.compress.enable = v
 }
 
+func (s *Stream) SkipChecksum(v bool) {
+	s.compress.r.SkipChecksum(v)
+}
+
+// SetStats counts the compressed blocks read and written by the stream into stats
+func (s *Stream) SetStats(stats *compress.Stats) {
+	s.compress.r.SetStats(stats)
+	s.compress.w.SetStats(stats)
+}
+
 func (s *Stream) Read(p []byte) (int, error) {
 	if s.compress.enable {
 		return io.ReadFull(s.compress.r, p)
@@ -102,6 +102,11 @@ func (b *Block) Encode(encoder *binary.Encoder, revision uint64) error {
 		if err := encoder.String(string(c.Type())); err != nil {
 			return err
 		}
+		if revision >= DBMS_MIN_REVISION_WITH_CUSTOM_SERIALIZATION {
+			if err := encoder.Bool(false); err != nil { // no custom (sparse) serialization
+				return err
+			}
+		}
 		if serialize, ok := c.(column.CustomSerialization); ok {
 			if err := serialize.WriteStatePrefix(encoder); err != nil {
 				return &BlockError{
@@ -160,6 +165,22 @@ func (b *Block) Decode(decoder *binary.Decoder, revision uint64) (err error) {
 		if err != nil {
 			return err
 		}
+		if revision >= DBMS_MIN_REVISION_WITH_CUSTOM_SERIALIZATION {
+			custom, err := decoder.Bool()
+			if err != nil {
+				return err
+			}
+			if custom {
+				// the sparse columns, sent from the revision DBMS_MIN_REVISION_WITH_SPARSE_SERIALIZATION
+				if c, err = column.ReadSerializationKinds(c, decoder); err != nil {
+					return &BlockError{
+						Op:         "Decode",
+						Err:        err,
+						ColumnName: columnName,
+					}
+				}
+			}
+		}
 		if numRows != 0 {
 			if serialize, ok := c.(column.CustomSerialization); ok {
 				if err := serialize.ReadStatePrefix(decoder); err != nil {
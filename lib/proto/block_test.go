@@ -0,0 +1,90 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockJSON(t *testing.T) {
+	// the JSON type of the servers from 24.8 starts its state prefix with a UInt64 version,
+	// then the dynamic paths each one with the prefix of its Dynamic column
+	buf, encoder, decoder := newCodec()
+	require.NoError(t, encodeBlockInfo(encoder))
+	require.NoError(t, encoder.Uvarint(1)) // columns
+	require.NoError(t, encoder.Uvarint(1)) // rows
+	require.NoError(t, encoder.String("j"))
+	require.NoError(t, encoder.String("JSON"))
+	require.NoError(t, encoder.Bool(false))   // custom serialization
+	require.NoError(t, encoder.UInt64(0))     // object serialization version
+	require.NoError(t, encoder.Uvarint(1024)) // max_dynamic_paths
+	require.NoError(t, encoder.Uvarint(1))    // dynamic paths
+	require.NoError(t, encoder.String("a"))
+	require.NoError(t, encoder.UInt64(2)) // dynamic structure version of the path
+	require.NoError(t, encoder.Uvarint(1))
+	require.NoError(t, encoder.String("Int64"))
+	require.NoError(t, encoder.UInt64(0)) // variant discriminators mode
+	require.NoError(t, encoder.UInt8(0))
+	require.NoError(t, encoder.Int64(42))
+	require.NoError(t, encoder.UInt64(0)) // shared data offsets
+	require.NoError(t, encoder.Flush())
+	require.NotZero(t, buf.Len())
+
+	var block Block
+	require.NoError(t, block.Decode(decoder, DBMS_TCP_PROTOCOL_VERSION))
+	require.Len(t, block.Columns, 1)
+	assert.Equal(t, map[string]interface{}{"a": int64(42)}, block.Columns[0].Row(0, false))
+	assert.Zero(t, buf.Len())
+}
+
+func TestBlockSparse(t *testing.T) {
+	// the sparse columns follow the custom serialization flag with their kind, see NativeWriter
+	buf, encoder, decoder := newCodec()
+	require.NoError(t, encodeBlockInfo(encoder))
+	require.NoError(t, encoder.Uvarint(2)) // columns
+	require.NoError(t, encoder.Uvarint(4)) // rows
+	require.NoError(t, encoder.String("id"))
+	require.NoError(t, encoder.String("UInt32"))
+	require.NoError(t, encoder.Bool(false))
+	for i := uint32(1); i <= 4; i++ {
+		require.NoError(t, encoder.UInt32(i))
+	}
+	require.NoError(t, encoder.String("comment"))
+	require.NoError(t, encoder.String("String"))
+	require.NoError(t, encoder.Bool(true))
+	require.NoError(t, encoder.UInt8(1))           // sparse
+	require.NoError(t, encoder.Uvarint(2))         // the rows 0 and 1 are empty
+	require.NoError(t, encoder.Uvarint(1|(1<<62))) // so is the row 3, the last group
+	require.NoError(t, encoder.String("third"))
+	require.NoError(t, encoder.Flush())
+
+	var block Block
+	require.NoError(t, block.Decode(decoder, DBMS_TCP_PROTOCOL_VERSION))
+	assert.Zero(t, buf.Len())
+	require.Equal(t, 4, block.Rows())
+	var comments []string
+	for i := 0; i < block.Rows(); i++ {
+		var comment string
+		require.NoError(t, block.Columns[1].ScanRow(&comment, i))
+		comments = append(comments, comment)
+	}
+	assert.Equal(t, []string{"", "", "third", ""}, comments)
+}
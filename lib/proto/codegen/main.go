@@ -0,0 +1,123 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed error_codes.tpl
+var errorCodesSrc string
+
+type errorCode struct {
+	Code  int
+	Name  string // UNKNOWN_TABLE
+	Const string // ErrCodeUnknownTable
+}
+
+var errorCodeRe = regexp.MustCompile(`M\(\s*(\d+)\s*,\s*(\w+)\s*\)`)
+
+// read returns the ErrorCodes.cpp source from a path or an URL
+func read(src string) ([]byte, error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		return os.ReadFile(src)
+	}
+	resp, err := http.Get(src)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", src, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func parse(src []byte) ([]errorCode, error) {
+	var (
+		codes []errorCode
+		seen  = make(map[string]bool)
+	)
+	for _, match := range errorCodeRe.FindAllSubmatch(src, -1) {
+		code, err := strconv.Atoi(string(match[1]))
+		if err != nil {
+			return nil, err
+		}
+		name := string(match[2])
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate error code name %s", name)
+		}
+		seen[name] = true
+		var constant strings.Builder
+		constant.WriteString("ErrCode")
+		for _, word := range strings.Split(strings.ToLower(name), "_") {
+			if len(word) != 0 {
+				constant.WriteString(strings.ToUpper(word[:1]) + word[1:])
+			}
+		}
+		codes = append(codes, errorCode{
+			Code:  code,
+			Name:  name,
+			Const: constant.String(),
+		})
+	}
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("no error codes found")
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].Code < codes[j].Code
+	})
+	return codes, nil
+}
+
+func main() {
+	src := flag.String("src", "https://raw.githubusercontent.com/ClickHouse/ClickHouse/master/src/Common/ErrorCodes.cpp", "path or URL of ErrorCodes.cpp")
+	flag.Parse()
+	data, err := read(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	codes, err := parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := template.Must(template.New("error_codes").Parse(errorCodesSrc)).Execute(&out, codes); err != nil {
+		log.Fatal(err)
+	}
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("error_codes_gen.go", source, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
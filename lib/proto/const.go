@@ -19,20 +19,30 @@ package proto
 
 // see https://github.com/ClickHouse/ClickHouse/blob/master/src/Core/Protocol.h
 const (
-	DBMS_MIN_REVISION_WITH_CLIENT_INFO                        = 54032
-	DBMS_MIN_REVISION_WITH_SERVER_TIMEZONE                    = 54058
-	DBMS_MIN_REVISION_WITH_QUOTA_KEY_IN_CLIENT_INFO           = 54060
-	DBMS_MIN_REVISION_WITH_SERVER_DISPLAY_NAME                = 54372
-	DBMS_MIN_REVISION_WITH_VERSION_PATCH                      = 54401
-	DBMS_MIN_REVISION_WITH_CLIENT_WRITE_INFO                  = 54420
-	DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS     = 54429
-	DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET                 = 54441
-	DBMS_MIN_REVISION_WITH_OPENTELEMETRY                      = 54442
-	DBMS_MIN_PROTOCOL_VERSION_WITH_DISTRIBUTED_DEPTH          = 54448
-	DBMS_MIN_PROTOCOL_VERSION_WITH_INITIAL_QUERY_START_TIME   = 54449
-	DBMS_MIN_PROTOCOL_VERSION_WITH_INCREMENTAL_PROFILE_EVENTS = 54451
-	DBMS_MIN_REVISION_WITH_PARALLEL_REPLICAS                  = 54453
-	DBMS_TCP_PROTOCOL_VERSION                                 = DBMS_MIN_REVISION_WITH_PARALLEL_REPLICAS
+	DBMS_MIN_REVISION_WITH_CLIENT_INFO                           = 54032
+	DBMS_MIN_REVISION_WITH_SERVER_TIMEZONE                       = 54058
+	DBMS_MIN_REVISION_WITH_QUOTA_KEY_IN_CLIENT_INFO              = 54060
+	DBMS_MIN_REVISION_WITH_SERVER_DISPLAY_NAME                   = 54372
+	DBMS_MIN_REVISION_WITH_VERSION_PATCH                         = 54401
+	DBMS_MIN_REVISION_WITH_CLIENT_WRITE_INFO                     = 54420
+	DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS        = 54429
+	DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET                    = 54441
+	DBMS_MIN_REVISION_WITH_OPENTELEMETRY                         = 54442
+	DBMS_MIN_PROTOCOL_VERSION_WITH_DISTRIBUTED_DEPTH             = 54448
+	DBMS_MIN_PROTOCOL_VERSION_WITH_INITIAL_QUERY_START_TIME      = 54449
+	DBMS_MIN_PROTOCOL_VERSION_WITH_INCREMENTAL_PROFILE_EVENTS    = 54451
+	DBMS_MIN_REVISION_WITH_PARALLEL_REPLICAS                     = 54453
+	DBMS_MIN_REVISION_WITH_CUSTOM_SERIALIZATION                  = 54454
+	DBMS_MIN_PROTOCOL_VERSION_WITH_ADDENDUM                      = 54458
+	DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS                    = 54459
+	DBMS_MIN_PROTOCOL_VERSION_WITH_SERVER_QUERY_TIME_IN_PROGRESS = 54460
+	DBMS_MIN_PROTOCOL_VERSION_WITH_PASSWORD_COMPLEXITY_RULES     = 54461
+	DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET_V2                 = 54462
+	DBMS_MIN_PROTOCOL_VERSION_WITH_TOTAL_BYTES_IN_PROGRESS       = 54463
+	DBMS_MIN_PROTOCOL_VERSION_WITH_TIMEZONE_UPDATES              = 54464
+	DBMS_MIN_REVISION_WITH_SPARSE_SERIALIZATION                  = 54465
+	DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION                    = 54466
+	DBMS_TCP_PROTOCOL_VERSION                                    = DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION
 )
 
 const (
@@ -41,6 +51,9 @@ const (
 	ClientData   = 2
 	ClientCancel = 3
 	ClientPing   = 4
+
+	ClientSSHChallengeRequest  = 11
+	ClientSSHChallengeResponse = 12
 )
 
 const (
@@ -75,4 +88,6 @@ const (
 	ServerReadTaskRequest     = 13
 	ServerProfileEvents       = 14
 	ServerTreeReadTaskRequest = 15
+	ServerTimezoneUpdate      = 17
+	ServerSSHChallenge        = 18
 )
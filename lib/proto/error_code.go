@@ -0,0 +1,36 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package proto
+
+import "fmt"
+
+// ErrorCode is the code of a server exception. It implements error, so an ErrCode constant
+// can be given to errors.Is to look for an exception with that code, nested exceptions included.
+type ErrorCode int32
+
+// String returns the name of the code as ClickHouse spells it, such as UNKNOWN_TABLE
+func (c ErrorCode) String() string {
+	if name, found := errorCodeNames[c]; found {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_ERROR_CODE_%d", int32(c))
+}
+
+func (c ErrorCode) Error() string {
+	return fmt.Sprintf("code: %d, name: %s", int32(c), c.String())
+}
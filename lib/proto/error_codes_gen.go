@@ -0,0 +1,1399 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by make codegen DO NOT EDIT.
+// source: src/Common/ErrorCodes.cpp of the ClickHouse repository
+
+package proto
+
+const (
+	ErrCodeUnsupportedMethod                                   ErrorCode = 1
+	ErrCodeUnsupportedParameter                                ErrorCode = 2
+	ErrCodeUnexpectedEndOfFile                                 ErrorCode = 3
+	ErrCodeExpectedEndOfFile                                   ErrorCode = 4
+	ErrCodeCannotParseText                                     ErrorCode = 6
+	ErrCodeIncorrectNumberOfColumns                            ErrorCode = 7
+	ErrCodeThereIsNoColumn                                     ErrorCode = 8
+	ErrCodeSizesOfColumnsDoesntMatch                           ErrorCode = 9
+	ErrCodeNotFoundColumnInBlock                               ErrorCode = 10
+	ErrCodePositionOutOfBound                                  ErrorCode = 11
+	ErrCodeParameterOutOfBound                                 ErrorCode = 12
+	ErrCodeSizesOfColumnsInTupleDoesntMatch                    ErrorCode = 13
+	ErrCodeDuplicateColumn                                     ErrorCode = 15
+	ErrCodeNoSuchColumnInTable                                 ErrorCode = 16
+	ErrCodeDelimiterInStringLiteralDoesntMatch                 ErrorCode = 17
+	ErrCodeCannotInsertElementIntoConstantColumn               ErrorCode = 18
+	ErrCodeSizeOfFixedStringDoesntMatch                        ErrorCode = 19
+	ErrCodeNumberOfColumnsDoesntMatch                          ErrorCode = 20
+	ErrCodeCannotReadAllDataFromTabSeparatedInput              ErrorCode = 21
+	ErrCodeCannotParseAllValueFromTabSeparatedInput            ErrorCode = 22
+	ErrCodeCannotReadFromIstream                               ErrorCode = 23
+	ErrCodeCannotWriteToOstream                                ErrorCode = 24
+	ErrCodeCannotParseEscapeSequence                           ErrorCode = 25
+	ErrCodeCannotParseQuotedString                             ErrorCode = 26
+	ErrCodeCannotParseInputAssertionFailed                     ErrorCode = 27
+	ErrCodeCannotPrintFloatOrDoubleNumber                      ErrorCode = 28
+	ErrCodeCannotPrintInteger                                  ErrorCode = 29
+	ErrCodeCannotReadSizeOfCompressedChunk                     ErrorCode = 30
+	ErrCodeCannotReadCompressedChunk                           ErrorCode = 31
+	ErrCodeAttemptToReadAfterEof                               ErrorCode = 32
+	ErrCodeCannotReadAllData                                   ErrorCode = 33
+	ErrCodeTooManyArgumentsForFunction                         ErrorCode = 34
+	ErrCodeTooFewArgumentsForFunction                          ErrorCode = 35
+	ErrCodeBadArguments                                        ErrorCode = 36
+	ErrCodeUnknownElementInAst                                 ErrorCode = 37
+	ErrCodeCannotParseDate                                     ErrorCode = 38
+	ErrCodeTooLargeSizeCompressed                              ErrorCode = 39
+	ErrCodeChecksumDoesntMatch                                 ErrorCode = 40
+	ErrCodeCannotParseDatetime                                 ErrorCode = 41
+	ErrCodeNumberOfArgumentsDoesntMatch                        ErrorCode = 42
+	ErrCodeIllegalTypeOfArgument                               ErrorCode = 43
+	ErrCodeIllegalColumn                                       ErrorCode = 44
+	ErrCodeIllegalNumberOfResultColumns                        ErrorCode = 45
+	ErrCodeUnknownFunction                                     ErrorCode = 46
+	ErrCodeUnknownIdentifier                                   ErrorCode = 47
+	ErrCodeNotImplemented                                      ErrorCode = 48
+	ErrCodeLogicalError                                        ErrorCode = 49
+	ErrCodeUnknownType                                         ErrorCode = 50
+	ErrCodeEmptyListOfColumnsQueried                           ErrorCode = 51
+	ErrCodeColumnQueriedMoreThanOnce                           ErrorCode = 52
+	ErrCodeTypeMismatch                                        ErrorCode = 53
+	ErrCodeStorageDoesntAllowParameters                        ErrorCode = 54
+	ErrCodeStorageRequiresParameter                            ErrorCode = 55
+	ErrCodeUnknownStorage                                      ErrorCode = 56
+	ErrCodeTableAlreadyExists                                  ErrorCode = 57
+	ErrCodeTableMetadataAlreadyExists                          ErrorCode = 58
+	ErrCodeIllegalTypeOfColumnForFilter                        ErrorCode = 59
+	ErrCodeUnknownTable                                        ErrorCode = 60
+	ErrCodeOnlyFilterColumnInBlock                             ErrorCode = 61
+	ErrCodeSyntaxError                                         ErrorCode = 62
+	ErrCodeUnknownAggregateFunction                            ErrorCode = 63
+	ErrCodeCannotReadAggregateFunctionFromText                 ErrorCode = 64
+	ErrCodeCannotWriteAggregateFunctionAsText                  ErrorCode = 65
+	ErrCodeNotAColumn                                          ErrorCode = 66
+	ErrCodeIllegalKeyOfAggregation                             ErrorCode = 67
+	ErrCodeCannotGetSizeOfField                                ErrorCode = 68
+	ErrCodeArgumentOutOfBound                                  ErrorCode = 69
+	ErrCodeCannotConvertType                                   ErrorCode = 70
+	ErrCodeCannotWriteAfterEndOfBuffer                         ErrorCode = 71
+	ErrCodeCannotParseNumber                                   ErrorCode = 72
+	ErrCodeUnknownFormat                                       ErrorCode = 73
+	ErrCodeCannotReadFromFileDescriptor                        ErrorCode = 74
+	ErrCodeCannotWriteToFileDescriptor                         ErrorCode = 75
+	ErrCodeCannotOpenFile                                      ErrorCode = 76
+	ErrCodeCannotCloseFile                                     ErrorCode = 77
+	ErrCodeUnknownTypeOfQuery                                  ErrorCode = 78
+	ErrCodeIncorrectFileName                                   ErrorCode = 79
+	ErrCodeIncorrectQuery                                      ErrorCode = 80
+	ErrCodeUnknownDatabase                                     ErrorCode = 81
+	ErrCodeDatabaseAlreadyExists                               ErrorCode = 82
+	ErrCodeDirectoryDoesntExist                                ErrorCode = 83
+	ErrCodeDirectoryAlreadyExists                              ErrorCode = 84
+	ErrCodeFormatIsNotSuitableForInput                         ErrorCode = 85
+	ErrCodeReceivedErrorFromRemoteIoServer                     ErrorCode = 86
+	ErrCodeCannotSeekThroughFile                               ErrorCode = 87
+	ErrCodeCannotTruncateFile                                  ErrorCode = 88
+	ErrCodeUnknownCompressionMethod                            ErrorCode = 89
+	ErrCodeEmptyListOfColumnsPassed                            ErrorCode = 90
+	ErrCodeSizesOfMarksFilesAreInconsistent                    ErrorCode = 91
+	ErrCodeEmptyDataPassed                                     ErrorCode = 92
+	ErrCodeUnknownAggregatedDataVariant                        ErrorCode = 93
+	ErrCodeCannotMergeDifferentAggregatedDataVariants          ErrorCode = 94
+	ErrCodeCannotReadFromSocket                                ErrorCode = 95
+	ErrCodeCannotWriteToSocket                                 ErrorCode = 96
+	ErrCodeCannotReadAllDataFromChunkedInput                   ErrorCode = 97
+	ErrCodeCannotWriteToEmptyBlockOutputStream                 ErrorCode = 98
+	ErrCodeUnknownPacketFromClient                             ErrorCode = 99
+	ErrCodeUnknownPacketFromServer                             ErrorCode = 100
+	ErrCodeUnexpectedPacketFromClient                          ErrorCode = 101
+	ErrCodeUnexpectedPacketFromServer                          ErrorCode = 102
+	ErrCodeReceivedDataForWrongQueryId                         ErrorCode = 103
+	ErrCodeTooSmallBufferSize                                  ErrorCode = 104
+	ErrCodeCannotReadHistory                                   ErrorCode = 105
+	ErrCodeCannotAppendHistory                                 ErrorCode = 106
+	ErrCodeFileDoesntExist                                     ErrorCode = 107
+	ErrCodeNoDataToInsert                                      ErrorCode = 108
+	ErrCodeCannotBlockSignal                                   ErrorCode = 109
+	ErrCodeCannotUnblockSignal                                 ErrorCode = 110
+	ErrCodeCannotManipulateSigset                              ErrorCode = 111
+	ErrCodeCannotWaitForSignal                                 ErrorCode = 112
+	ErrCodeThereIsNoSession                                    ErrorCode = 113
+	ErrCodeCannotClockGettime                                  ErrorCode = 114
+	ErrCodeUnknownSetting                                      ErrorCode = 115
+	ErrCodeThereIsNoDefaultValue                               ErrorCode = 116
+	ErrCodeIncorrectData                                       ErrorCode = 117
+	ErrCodeEngineRequired                                      ErrorCode = 119
+	ErrCodeCannotInsertValueOfDifferentSizeIntoTuple           ErrorCode = 120
+	ErrCodeUnsupportedJoinKeys                                 ErrorCode = 121
+	ErrCodeIncompatibleColumns                                 ErrorCode = 122
+	ErrCodeUnknownTypeOfAstNode                                ErrorCode = 123
+	ErrCodeIncorrectElementOfSet                               ErrorCode = 124
+	ErrCodeIncorrectResultOfScalarSubquery                     ErrorCode = 125
+	ErrCodeCannotGetReturnType                                 ErrorCode = 126
+	ErrCodeIllegalIndex                                        ErrorCode = 127
+	ErrCodeTooLargeArraySize                                   ErrorCode = 128
+	ErrCodeFunctionIsSpecial                                   ErrorCode = 129
+	ErrCodeCannotReadArrayFromText                             ErrorCode = 130
+	ErrCodeTooLargeStringSize                                  ErrorCode = 131
+	ErrCodeCannotCreateTableFromMetadata                       ErrorCode = 132
+	ErrCodeAggregateFunctionDoesntAllowParameters              ErrorCode = 133
+	ErrCodeParametersToAggregateFunctionsMustBeLiterals        ErrorCode = 134
+	ErrCodeZeroArrayOrTupleIndex                               ErrorCode = 135
+	ErrCodeUnknownElementInConfig                              ErrorCode = 137
+	ErrCodeExcessiveElementInConfig                            ErrorCode = 138
+	ErrCodeNoElementsInConfig                                  ErrorCode = 139
+	ErrCodeAllRequestedColumnsAreMissing                       ErrorCode = 140
+	ErrCodeSamplingNotSupported                                ErrorCode = 141
+	ErrCodeNotFoundNode                                        ErrorCode = 142
+	ErrCodeFoundMoreThanOneNode                                ErrorCode = 143
+	ErrCodeFirstDateIsBiggerThanLastDate                       ErrorCode = 144
+	ErrCodeUnknownOverflowMode                                 ErrorCode = 145
+	ErrCodeQuerySectionDoesntMakeSense                         ErrorCode = 146
+	ErrCodeNotFoundFunctionElementForAggregate                 ErrorCode = 147
+	ErrCodeNotFoundRelationElementForCondition                 ErrorCode = 148
+	ErrCodeNotFoundRhsElementForCondition                      ErrorCode = 149
+	ErrCodeNoAttributesListed                                  ErrorCode = 150
+	ErrCodeIndexOfColumnInSortClauseIsOutOfRange               ErrorCode = 151
+	ErrCodeUnknownDirectionOfSorting                           ErrorCode = 152
+	ErrCodeIllegalDivision                                     ErrorCode = 153
+	ErrCodeAggregateFunctionNotApplicable                      ErrorCode = 154
+	ErrCodeUnknownRelation                                     ErrorCode = 155
+	ErrCodeDictionariesWasNotLoaded                            ErrorCode = 156
+	ErrCodeIllegalOverflowMode                                 ErrorCode = 157
+	ErrCodeTooManyRows                                         ErrorCode = 158
+	ErrCodeTimeoutExceeded                                     ErrorCode = 159
+	ErrCodeTooSlow                                             ErrorCode = 160
+	ErrCodeTooManyColumns                                      ErrorCode = 161
+	ErrCodeTooDeepSubqueries                                   ErrorCode = 162
+	ErrCodeTooDeepPipeline                                     ErrorCode = 163
+	ErrCodeReadonly                                            ErrorCode = 164
+	ErrCodeTooManyTemporaryColumns                             ErrorCode = 165
+	ErrCodeTooManyTemporaryNonConstColumns                     ErrorCode = 166
+	ErrCodeTooDeepAst                                          ErrorCode = 167
+	ErrCodeTooBigAst                                           ErrorCode = 168
+	ErrCodeBadTypeOfField                                      ErrorCode = 169
+	ErrCodeBadGet                                              ErrorCode = 170
+	ErrCodeBlocksHaveDifferentStructure                        ErrorCode = 171
+	ErrCodeCannotCreateDirectory                               ErrorCode = 172
+	ErrCodeCannotAllocateMemory                                ErrorCode = 173
+	ErrCodeCyclicAliases                                       ErrorCode = 174
+	ErrCodeChunkNotFound                                       ErrorCode = 176
+	ErrCodeDuplicateChunkName                                  ErrorCode = 177
+	ErrCodeMultipleAliasesForExpression                        ErrorCode = 178
+	ErrCodeMultipleExpressionsForAlias                         ErrorCode = 179
+	ErrCodeThereIsNoProfile                                    ErrorCode = 180
+	ErrCodeIllegalFinal                                        ErrorCode = 181
+	ErrCodeIllegalPrewhere                                     ErrorCode = 182
+	ErrCodeUnexpectedExpression                                ErrorCode = 183
+	ErrCodeIllegalAggregation                                  ErrorCode = 184
+	ErrCodeUnsupportedMyisamBlockType                          ErrorCode = 185
+	ErrCodeUnsupportedCollationLocale                          ErrorCode = 186
+	ErrCodeCollationComparisonFailed                           ErrorCode = 187
+	ErrCodeUnknownAction                                       ErrorCode = 188
+	ErrCodeTableMustNotBeCreatedManually                       ErrorCode = 189
+	ErrCodeSizesOfArraysDontMatch                              ErrorCode = 190
+	ErrCodeSetSizeLimitExceeded                                ErrorCode = 191
+	ErrCodeUnknownUser                                         ErrorCode = 192
+	ErrCodeWrongPassword                                       ErrorCode = 193
+	ErrCodeRequiredPassword                                    ErrorCode = 194
+	ErrCodeIpAddressNotAllowed                                 ErrorCode = 195
+	ErrCodeUnknownAddressPatternType                           ErrorCode = 196
+	ErrCodeServerRevisionIsTooOld                              ErrorCode = 197
+	ErrCodeDnsError                                            ErrorCode = 198
+	ErrCodeUnknownQuota                                        ErrorCode = 199
+	ErrCodeQuotaDoesntAllowKeys                                ErrorCode = 200
+	ErrCodeQuotaExpired                                        ErrorCode = 201
+	ErrCodeTooManySimultaneousQueries                          ErrorCode = 202
+	ErrCodeNoFreeConnection                                    ErrorCode = 203
+	ErrCodeCannotFsync                                         ErrorCode = 204
+	ErrCodeNestedTypeTooDeep                                   ErrorCode = 205
+	ErrCodeAliasRequired                                       ErrorCode = 206
+	ErrCodeAmbiguousIdentifier                                 ErrorCode = 207
+	ErrCodeEmptyNestedTable                                    ErrorCode = 208
+	ErrCodeSocketTimeout                                       ErrorCode = 209
+	ErrCodeNetworkError                                        ErrorCode = 210
+	ErrCodeEmptyQuery                                          ErrorCode = 211
+	ErrCodeUnknownLoadBalancing                                ErrorCode = 212
+	ErrCodeUnknownTotalsMode                                   ErrorCode = 213
+	ErrCodeCannotStatvfs                                       ErrorCode = 214
+	ErrCodeNotAnAggregate                                      ErrorCode = 215
+	ErrCodeQueryWithSameIdIsAlreadyRunning                     ErrorCode = 216
+	ErrCodeClientHasConnectedToWrongPort                       ErrorCode = 217
+	ErrCodeTableIsDropped                                      ErrorCode = 218
+	ErrCodeDatabaseNotEmpty                                    ErrorCode = 219
+	ErrCodeDuplicateInterserverIoEndpoint                      ErrorCode = 220
+	ErrCodeNoSuchInterserverIoEndpoint                         ErrorCode = 221
+	ErrCodeAddingReplicaToNonEmptyTable                        ErrorCode = 222
+	ErrCodeUnexpectedAstStructure                              ErrorCode = 223
+	ErrCodeReplicaIsAlreadyActive                              ErrorCode = 224
+	ErrCodeNoZookeeper                                         ErrorCode = 225
+	ErrCodeNoFileInDataPart                                    ErrorCode = 226
+	ErrCodeUnexpectedFileInDataPart                            ErrorCode = 227
+	ErrCodeBadSizeOfFileInDataPart                             ErrorCode = 228
+	ErrCodeQueryIsTooLarge                                     ErrorCode = 229
+	ErrCodeNotFoundExpectedDataPart                            ErrorCode = 230
+	ErrCodeTooManyUnexpectedDataParts                          ErrorCode = 231
+	ErrCodeNoSuchDataPart                                      ErrorCode = 232
+	ErrCodeBadDataPartName                                     ErrorCode = 233
+	ErrCodeNoReplicaHasPart                                    ErrorCode = 234
+	ErrCodeDuplicateDataPart                                   ErrorCode = 235
+	ErrCodeAborted                                             ErrorCode = 236
+	ErrCodeNoReplicaNameGiven                                  ErrorCode = 237
+	ErrCodeFormatVersionTooOld                                 ErrorCode = 238
+	ErrCodeCannotMunmap                                        ErrorCode = 239
+	ErrCodeCannotMremap                                        ErrorCode = 240
+	ErrCodeMemoryLimitExceeded                                 ErrorCode = 241
+	ErrCodeTableIsReadOnly                                     ErrorCode = 242
+	ErrCodeNotEnoughSpace                                      ErrorCode = 243
+	ErrCodeUnexpectedZookeeperError                            ErrorCode = 244
+	ErrCodeCorruptedData                                       ErrorCode = 246
+	ErrCodeIncorrectMark                                       ErrorCode = 247
+	ErrCodeInvalidPartitionValue                               ErrorCode = 248
+	ErrCodeNotEnoughBlockNumbers                               ErrorCode = 250
+	ErrCodeNoSuchReplica                                       ErrorCode = 251
+	ErrCodeTooManyParts                                        ErrorCode = 252
+	ErrCodeReplicaIsAlreadyExist                               ErrorCode = 253
+	ErrCodeNoActiveReplicas                                    ErrorCode = 254
+	ErrCodeTooManyRetriesToFetchParts                          ErrorCode = 255
+	ErrCodePartitionAlreadyExists                              ErrorCode = 256
+	ErrCodePartitionDoesntExist                                ErrorCode = 257
+	ErrCodeUnionAllResultStructuresMismatch                    ErrorCode = 258
+	ErrCodeClientOutputFormatSpecified                         ErrorCode = 260
+	ErrCodeUnknownBlockInfoField                               ErrorCode = 261
+	ErrCodeBadCollation                                        ErrorCode = 262
+	ErrCodeCannotCompileCode                                   ErrorCode = 263
+	ErrCodeIncompatibleTypeOfJoin                              ErrorCode = 264
+	ErrCodeNoAvailableReplica                                  ErrorCode = 265
+	ErrCodeMismatchReplicasDataSources                         ErrorCode = 266
+	ErrCodeStorageDoesntSupportParallelReplicas                ErrorCode = 267
+	ErrCodeCpuidError                                          ErrorCode = 268
+	ErrCodeInfiniteLoop                                        ErrorCode = 269
+	ErrCodeCannotCompress                                      ErrorCode = 270
+	ErrCodeCannotDecompress                                    ErrorCode = 271
+	ErrCodeCannotIoSubmit                                      ErrorCode = 272
+	ErrCodeCannotIoGetevents                                   ErrorCode = 273
+	ErrCodeAioReadError                                        ErrorCode = 274
+	ErrCodeAioWriteError                                       ErrorCode = 275
+	ErrCodeIndexNotUsed                                        ErrorCode = 277
+	ErrCodeLeadershipLost                                      ErrorCode = 278
+	ErrCodeAllConnectionTriesFailed                            ErrorCode = 279
+	ErrCodeNoAvailableData                                     ErrorCode = 280
+	ErrCodeDictionaryIsEmpty                                   ErrorCode = 281
+	ErrCodeIncorrectIndex                                      ErrorCode = 282
+	ErrCodeUnknownDistributedProductMode                       ErrorCode = 283
+	ErrCodeWrongGlobalSubquery                                 ErrorCode = 284
+	ErrCodeTooFewLiveReplicas                                  ErrorCode = 285
+	ErrCodeUnsatisfiedQuorumForPreviousWrite                   ErrorCode = 286
+	ErrCodeUnknownFormatVersion                                ErrorCode = 287
+	ErrCodeDistributedInJoinSubqueryDenied                     ErrorCode = 288
+	ErrCodeReplicaIsNotInQuorum                                ErrorCode = 289
+	ErrCodeLimitExceeded                                       ErrorCode = 290
+	ErrCodeDatabaseAccessDenied                                ErrorCode = 291
+	ErrCodeLeadershipChanged                                   ErrorCode = 292
+	ErrCodeMongodbCannotAuthenticate                           ErrorCode = 293
+	ErrCodeCannotWriteToFile                                   ErrorCode = 294
+	ErrCodeReceivedEmptyData                                   ErrorCode = 295
+	ErrCodeNoRemoteShardFound                                  ErrorCode = 296
+	ErrCodeShardHasNoConnections                               ErrorCode = 297
+	ErrCodeCannotPipe                                          ErrorCode = 298
+	ErrCodeCannotFork                                          ErrorCode = 299
+	ErrCodeCannotDlsym                                         ErrorCode = 300
+	ErrCodeCannotCreateChildProcess                            ErrorCode = 301
+	ErrCodeChildWasNotExitedNormally                           ErrorCode = 302
+	ErrCodeCannotSelect                                        ErrorCode = 303
+	ErrCodeCannotWaitpid                                       ErrorCode = 304
+	ErrCodeTableWasNotDropped                                  ErrorCode = 305
+	ErrCodeTooDeepRecursion                                    ErrorCode = 306
+	ErrCodeTooManyBytes                                        ErrorCode = 307
+	ErrCodeUnexpectedNodeInZookeeper                           ErrorCode = 308
+	ErrCodeFunctionCannotHaveParameters                        ErrorCode = 309
+	ErrCodeInvalidShardWeight                                  ErrorCode = 317
+	ErrCodeInvalidConfigParameter                              ErrorCode = 318
+	ErrCodeUnknownStatusOfInsert                               ErrorCode = 319
+	ErrCodeValueIsOutOfRangeOfDataType                         ErrorCode = 321
+	ErrCodeBarrierTimeout                                      ErrorCode = 335
+	ErrCodeUnknownDatabaseEngine                               ErrorCode = 336
+	ErrCodeDdlGuardIsActive                                    ErrorCode = 337
+	ErrCodeUnfinished                                          ErrorCode = 341
+	ErrCodeMetadataMismatch                                    ErrorCode = 342
+	ErrCodeSupportIsDisabled                                   ErrorCode = 344
+	ErrCodeTableDiffersTooMuch                                 ErrorCode = 345
+	ErrCodeCannotConvertCharset                                ErrorCode = 346
+	ErrCodeCannotLoadConfig                                    ErrorCode = 347
+	ErrCodeCannotInsertNullInOrdinaryColumn                    ErrorCode = 349
+	ErrCodeIncompatibleSourceTables                            ErrorCode = 350
+	ErrCodeAmbiguousTableName                                  ErrorCode = 351
+	ErrCodeAmbiguousColumnName                                 ErrorCode = 352
+	ErrCodeIndexOfPositionalArgumentIsOutOfRange               ErrorCode = 353
+	ErrCodeZlibInflateFailed                                   ErrorCode = 354
+	ErrCodeZlibDeflateFailed                                   ErrorCode = 355
+	ErrCodeBadLambda                                           ErrorCode = 356
+	ErrCodeReservedIdentifierName                              ErrorCode = 357
+	ErrCodeIntoOutfileNotAllowed                               ErrorCode = 358
+	ErrCodeTableSizeExceedsMaxDropSizeLimit                    ErrorCode = 359
+	ErrCodeCannotCreateCharsetConverter                        ErrorCode = 360
+	ErrCodeSeekPositionOutOfBound                              ErrorCode = 361
+	ErrCodeCurrentWriteBufferIsExhausted                       ErrorCode = 362
+	ErrCodeCannotCreateIoBuffer                                ErrorCode = 363
+	ErrCodeReceivedErrorTooManyRequests                        ErrorCode = 364
+	ErrCodeOutputIsNotSorted                                   ErrorCode = 365
+	ErrCodeSizesOfNestedColumnsAreInconsistent                 ErrorCode = 366
+	ErrCodeTooManyFetches                                      ErrorCode = 367
+	ErrCodeBadCast                                             ErrorCode = 368
+	ErrCodeAllReplicasAreStale                                 ErrorCode = 369
+	ErrCodeDataTypeCannotBeUsedInTables                        ErrorCode = 370
+	ErrCodeInconsistentClusterDefinition                       ErrorCode = 371
+	ErrCodeSessionNotFound                                     ErrorCode = 372
+	ErrCodeSessionIsLocked                                     ErrorCode = 373
+	ErrCodeInvalidSessionTimeout                               ErrorCode = 374
+	ErrCodeCannotDlopen                                        ErrorCode = 375
+	ErrCodeCannotParseUuid                                     ErrorCode = 376
+	ErrCodeIllegalSyntaxForDataType                            ErrorCode = 377
+	ErrCodeDataTypeCannotHaveArguments                         ErrorCode = 378
+	ErrCodeUnknownStatusOfDistributedDdlTask                   ErrorCode = 379
+	ErrCodeCannotKill                                          ErrorCode = 380
+	ErrCodeHttpLengthRequired                                  ErrorCode = 381
+	ErrCodeCannotLoadCatboostModel                             ErrorCode = 382
+	ErrCodeCannotApplyCatboostModel                            ErrorCode = 383
+	ErrCodePartIsTemporarilyLocked                             ErrorCode = 384
+	ErrCodeMultipleStreamsRequired                             ErrorCode = 385
+	ErrCodeNoCommonType                                        ErrorCode = 386
+	ErrCodeExternalLoadableAlreadyExists                       ErrorCode = 387
+	ErrCodeCannotAssignOptimize                                ErrorCode = 388
+	ErrCodeInsertWasDeduplicated                               ErrorCode = 389
+	ErrCodeCannotGetCreateTableQuery                           ErrorCode = 390
+	ErrCodeExternalLibraryError                                ErrorCode = 391
+	ErrCodeQueryIsProhibited                                   ErrorCode = 392
+	ErrCodeThereIsNoQuery                                      ErrorCode = 393
+	ErrCodeQueryWasCancelled                                   ErrorCode = 394
+	ErrCodeFunctionThrowIfValueIsNonZero                       ErrorCode = 395
+	ErrCodeTooManyRowsOrBytes                                  ErrorCode = 396
+	ErrCodeQueryIsNotSupportedInMaterializedView               ErrorCode = 397
+	ErrCodeUnknownMutationCommand                              ErrorCode = 398
+	ErrCodeFormatIsNotSuitableForOutput                        ErrorCode = 399
+	ErrCodeCannotStat                                          ErrorCode = 400
+	ErrCodeFeatureIsNotEnabledAtBuildTime                      ErrorCode = 401
+	ErrCodeCannotIosetup                                       ErrorCode = 402
+	ErrCodeInvalidJoinOnExpression                             ErrorCode = 403
+	ErrCodeBadOdbcConnectionString                             ErrorCode = 404
+	ErrCodeTopAndLimitTogether                                 ErrorCode = 406
+	ErrCodeDecimalOverflow                                     ErrorCode = 407
+	ErrCodeBadRequestParameter                                 ErrorCode = 408
+	ErrCodeExternalServerIsNotResponding                       ErrorCode = 410
+	ErrCodePthreadError                                        ErrorCode = 411
+	ErrCodeNetlinkError                                        ErrorCode = 412
+	ErrCodeCannotSetSignalHandler                              ErrorCode = 413
+	ErrCodeAllReplicasLost                                     ErrorCode = 415
+	ErrCodeReplicaStatusChanged                                ErrorCode = 416
+	ErrCodeExpectedAllOrAny                                    ErrorCode = 417
+	ErrCodeUnknownJoin                                         ErrorCode = 418
+	ErrCodeMultipleAssignmentsToColumn                         ErrorCode = 419
+	ErrCodeCannotUpdateColumn                                  ErrorCode = 420
+	ErrCodeCannotAddDifferentAggregateStates                   ErrorCode = 421
+	ErrCodeUnsupportedUriScheme                                ErrorCode = 422
+	ErrCodeCannotGettimeofday                                  ErrorCode = 423
+	ErrCodeCannotLink                                          ErrorCode = 424
+	ErrCodeSystemError                                         ErrorCode = 425
+	ErrCodeCannotCompileRegexp                                 ErrorCode = 427
+	ErrCodeFailedToGetpwuid                                    ErrorCode = 429
+	ErrCodeMismatchingUsersForProcessAndData                   ErrorCode = 430
+	ErrCodeIllegalSyntaxForCodecType                           ErrorCode = 431
+	ErrCodeUnknownCodec                                        ErrorCode = 432
+	ErrCodeIllegalCodecParameter                               ErrorCode = 433
+	ErrCodeCannotParseProtobufSchema                           ErrorCode = 434
+	ErrCodeNoColumnSerializedToRequiredProtobufField           ErrorCode = 435
+	ErrCodeProtobufBadCast                                     ErrorCode = 436
+	ErrCodeProtobufFieldNotRepeated                            ErrorCode = 437
+	ErrCodeDataTypeCannotBePromoted                            ErrorCode = 438
+	ErrCodeCannotScheduleTask                                  ErrorCode = 439
+	ErrCodeInvalidLimitExpression                              ErrorCode = 440
+	ErrCodeCannotParseDomainValueFromString                    ErrorCode = 441
+	ErrCodeBadDatabaseForTemporaryTable                        ErrorCode = 442
+	ErrCodeNoColumnsSerializedToProtobufFields                 ErrorCode = 443
+	ErrCodeUnknownProtobufFormat                               ErrorCode = 444
+	ErrCodeCannotMprotect                                      ErrorCode = 445
+	ErrCodeFunctionNotAllowed                                  ErrorCode = 446
+	ErrCodeHyperscanCannotScanText                             ErrorCode = 447
+	ErrCodeBrotliReadFailed                                    ErrorCode = 448
+	ErrCodeBrotliWriteFailed                                   ErrorCode = 449
+	ErrCodeBadTtlExpression                                    ErrorCode = 450
+	ErrCodeBadTtlFile                                          ErrorCode = 451
+	ErrCodeSettingConstraintViolation                          ErrorCode = 452
+	ErrCodeMysqlClientInsufficientCapabilities                 ErrorCode = 453
+	ErrCodeOpensslError                                        ErrorCode = 454
+	ErrCodeSuspiciousTypeForLowCardinality                     ErrorCode = 455
+	ErrCodeUnknownQueryParameter                               ErrorCode = 456
+	ErrCodeBadQueryParameter                                   ErrorCode = 457
+	ErrCodeCannotUnlink                                        ErrorCode = 458
+	ErrCodeCannotSetThreadPriority                             ErrorCode = 459
+	ErrCodeCannotCreateTimer                                   ErrorCode = 460
+	ErrCodeCannotSetTimerPeriod                                ErrorCode = 461
+	ErrCodeCannotFcntl                                         ErrorCode = 463
+	ErrCodeCannotParseElf                                      ErrorCode = 464
+	ErrCodeCannotParseDwarf                                    ErrorCode = 465
+	ErrCodeInsecurePath                                        ErrorCode = 466
+	ErrCodeCannotParseBool                                     ErrorCode = 467
+	ErrCodeCannotPthreadAttr                                   ErrorCode = 468
+	ErrCodeViolatedConstraint                                  ErrorCode = 469
+	ErrCodeInvalidSettingValue                                 ErrorCode = 471
+	ErrCodeReadonlySetting                                     ErrorCode = 472
+	ErrCodeDeadlockAvoided                                     ErrorCode = 473
+	ErrCodeInvalidTemplateFormat                               ErrorCode = 474
+	ErrCodeInvalidWithFillExpression                           ErrorCode = 475
+	ErrCodeWithTiesWithoutOrderBy                              ErrorCode = 476
+	ErrCodeInvalidUsageOfInput                                 ErrorCode = 477
+	ErrCodeUnknownPolicy                                       ErrorCode = 478
+	ErrCodeUnknownDisk                                         ErrorCode = 479
+	ErrCodeUnknownProtocol                                     ErrorCode = 480
+	ErrCodePathAccessDenied                                    ErrorCode = 481
+	ErrCodeDictionaryAccessDenied                              ErrorCode = 482
+	ErrCodeTooManyRedirects                                    ErrorCode = 483
+	ErrCodeInternalRedisError                                  ErrorCode = 484
+	ErrCodeCannotGetCreateDictionaryQuery                      ErrorCode = 487
+	ErrCodeIncorrectDictionaryDefinition                       ErrorCode = 489
+	ErrCodeCannotFormatDatetime                                ErrorCode = 490
+	ErrCodeUnacceptableUrl                                     ErrorCode = 491
+	ErrCodeAccessEntityNotFound                                ErrorCode = 492
+	ErrCodeAccessEntityAlreadyExists                           ErrorCode = 493
+	ErrCodeAccessStorageReadonly                               ErrorCode = 495
+	ErrCodeQuotaRequiresClientKey                              ErrorCode = 496
+	ErrCodeAccessDenied                                        ErrorCode = 497
+	ErrCodeLimitByWithTiesIsNotSupported                       ErrorCode = 498
+	ErrCodeS3Error                                             ErrorCode = 499
+	ErrCodeAzureBlobStorageError                               ErrorCode = 500
+	ErrCodeCannotCreateDatabase                                ErrorCode = 501
+	ErrCodeCannotSigqueue                                      ErrorCode = 502
+	ErrCodeAggregateFunctionThrow                              ErrorCode = 503
+	ErrCodeFileAlreadyExists                                   ErrorCode = 504
+	ErrCodeUnableToSkipUnusedShards                            ErrorCode = 507
+	ErrCodeUnknownAccessType                                   ErrorCode = 508
+	ErrCodeInvalidGrant                                        ErrorCode = 509
+	ErrCodeCacheDictionaryUpdateFail                           ErrorCode = 510
+	ErrCodeUnknownRole                                         ErrorCode = 511
+	ErrCodeSetNonGrantedRole                                   ErrorCode = 512
+	ErrCodeUnknownPartType                                     ErrorCode = 513
+	ErrCodeAccessStorageForInsertionNotFound                   ErrorCode = 514
+	ErrCodeIncorrectAccessEntityDefinition                     ErrorCode = 515
+	ErrCodeAuthenticationFailed                                ErrorCode = 516
+	ErrCodeCannotAssignAlter                                   ErrorCode = 517
+	ErrCodeCannotCommitOffset                                  ErrorCode = 518
+	ErrCodeNoRemoteShardAvailable                              ErrorCode = 519
+	ErrCodeCannotDetachDictionaryAsTable                       ErrorCode = 520
+	ErrCodeAtomicRenameFail                                    ErrorCode = 521
+	ErrCodeUnknownRowPolicy                                    ErrorCode = 523
+	ErrCodeAlterOfColumnIsForbidden                            ErrorCode = 524
+	ErrCodeIncorrectDiskIndex                                  ErrorCode = 525
+	ErrCodeNoSuitableFunctionImplementation                    ErrorCode = 527
+	ErrCodeCassandraInternalError                              ErrorCode = 528
+	ErrCodeNotALeader                                          ErrorCode = 529
+	ErrCodeCannotConnectRabbitmq                               ErrorCode = 530
+	ErrCodeCannotFstat                                         ErrorCode = 531
+	ErrCodeLdapError                                           ErrorCode = 532
+	ErrCodeUnknownRaidType                                     ErrorCode = 535
+	ErrCodeCannotRestoreFromFieldDump                          ErrorCode = 536
+	ErrCodeIllegalMysqlVariable                                ErrorCode = 537
+	ErrCodeMysqlSyntaxError                                    ErrorCode = 538
+	ErrCodeCannotBindRabbitmqExchange                          ErrorCode = 539
+	ErrCodeCannotDeclareRabbitmqExchange                       ErrorCode = 540
+	ErrCodeCannotCreateRabbitmqQueueBinding                    ErrorCode = 541
+	ErrCodeCannotRemoveRabbitmqExchange                        ErrorCode = 542
+	ErrCodeUnknownMysqlDatatypesSupportLevel                   ErrorCode = 543
+	ErrCodeRowAndRowsTogether                                  ErrorCode = 544
+	ErrCodeFirstAndNextTogether                                ErrorCode = 545
+	ErrCodeNoRowDelimiter                                      ErrorCode = 546
+	ErrCodeInvalidRaidType                                     ErrorCode = 547
+	ErrCodeUnknownVolume                                       ErrorCode = 548
+	ErrCodeDataTypeCannotBeUsedInKey                           ErrorCode = 549
+	ErrCodeUnrecognizedArguments                               ErrorCode = 552
+	ErrCodeLzmaStreamEncoderFailed                             ErrorCode = 553
+	ErrCodeLzmaStreamDecoderFailed                             ErrorCode = 554
+	ErrCodeRocksdbError                                        ErrorCode = 555
+	ErrCodeSyncMysqlUserAccessError                            ErrorCode = 556
+	ErrCodeUnknownUnion                                        ErrorCode = 557
+	ErrCodeExpectedAllOrDistinct                               ErrorCode = 558
+	ErrCodeInvalidGrpcQueryInfo                                ErrorCode = 559
+	ErrCodeZstdEncoderFailed                                   ErrorCode = 560
+	ErrCodeZstdDecoderFailed                                   ErrorCode = 561
+	ErrCodeTldListNotFound                                     ErrorCode = 562
+	ErrCodeCannotReadMapFromText                               ErrorCode = 563
+	ErrCodeInterserverSchemeDoesntMatch                        ErrorCode = 564
+	ErrCodeTooManyPartitions                                   ErrorCode = 565
+	ErrCodeCannotRmdir                                         ErrorCode = 566
+	ErrCodeDuplicatedPartUuids                                 ErrorCode = 567
+	ErrCodeRaftError                                           ErrorCode = 568
+	ErrCodeMultipleColumnsSerializedToSameProtobufField        ErrorCode = 569
+	ErrCodeDataTypeIncompatibleWithProtobufField               ErrorCode = 570
+	ErrCodeDatabaseReplicationFailed                           ErrorCode = 571
+	ErrCodeTooManyQueryPlanOptimizations                       ErrorCode = 572
+	ErrCodeEpollError                                          ErrorCode = 573
+	ErrCodeDistributedTooManyPendingBytes                      ErrorCode = 574
+	ErrCodeUnknownSnapshot                                     ErrorCode = 575
+	ErrCodeKerberosError                                       ErrorCode = 576
+	ErrCodeInvalidShardId                                      ErrorCode = 577
+	ErrCodeInvalidFormatInsertQueryWithData                    ErrorCode = 578
+	ErrCodeIncorrectPartType                                   ErrorCode = 579
+	ErrCodeCannotSetRoundingMode                               ErrorCode = 580
+	ErrCodeTooLargeDistributedDepth                            ErrorCode = 581
+	ErrCodeNoSuchProjectionInTable                             ErrorCode = 582
+	ErrCodeIllegalProjection                                   ErrorCode = 583
+	ErrCodeProjectionNotUsed                                   ErrorCode = 584
+	ErrCodeCannotParseYaml                                     ErrorCode = 585
+	ErrCodeCannotCreateFile                                    ErrorCode = 586
+	ErrCodeConcurrentAccessNotSupported                        ErrorCode = 587
+	ErrCodeDistributedBrokenBatchInfo                          ErrorCode = 588
+	ErrCodeDistributedBrokenBatchFiles                         ErrorCode = 589
+	ErrCodeCannotSysconf                                       ErrorCode = 590
+	ErrCodeSqliteEngineError                                   ErrorCode = 591
+	ErrCodeDataEncryptionError                                 ErrorCode = 592
+	ErrCodeZeroCopyReplicationError                            ErrorCode = 593
+	ErrCodeBzip2StreamDecoderFailed                            ErrorCode = 594
+	ErrCodeBzip2StreamEncoderFailed                            ErrorCode = 595
+	ErrCodeIntersectOrExceptResultStructuresMismatch           ErrorCode = 596
+	ErrCodeNoSuchErrorCode                                     ErrorCode = 597
+	ErrCodeBackupAlreadyExists                                 ErrorCode = 598
+	ErrCodeBackupNotFound                                      ErrorCode = 599
+	ErrCodeBackupVersionNotSupported                           ErrorCode = 600
+	ErrCodeBackupDamaged                                       ErrorCode = 601
+	ErrCodeNoBaseBackup                                        ErrorCode = 602
+	ErrCodeWrongBaseBackup                                     ErrorCode = 603
+	ErrCodeBackupEntryAlreadyExists                            ErrorCode = 604
+	ErrCodeBackupEntryNotFound                                 ErrorCode = 605
+	ErrCodeBackupIsEmpty                                       ErrorCode = 606
+	ErrCodeCannotRestoreDatabase                               ErrorCode = 607
+	ErrCodeCannotRestoreTable                                  ErrorCode = 608
+	ErrCodeFunctionAlreadyExists                               ErrorCode = 609
+	ErrCodeCannotDropFunction                                  ErrorCode = 610
+	ErrCodeCannotCreateRecursiveFunction                       ErrorCode = 611
+	ErrCodePostgresqlConnectionFailure                         ErrorCode = 614
+	ErrCodeCannotAdvise                                        ErrorCode = 615
+	ErrCodeUnknownReadMethod                                   ErrorCode = 616
+	ErrCodeLz4EncoderFailed                                    ErrorCode = 617
+	ErrCodeLz4DecoderFailed                                    ErrorCode = 618
+	ErrCodePostgresqlReplicationInternalError                  ErrorCode = 619
+	ErrCodeQueryNotAllowed                                     ErrorCode = 620
+	ErrCodeCannotNormalizeString                               ErrorCode = 621
+	ErrCodeCannotParseCapnProtoSchema                          ErrorCode = 622
+	ErrCodeCapnProtoBadCast                                    ErrorCode = 623
+	ErrCodeBadFileType                                         ErrorCode = 624
+	ErrCodeIoSetupError                                        ErrorCode = 625
+	ErrCodeCannotSkipUnknownField                              ErrorCode = 626
+	ErrCodeBackupEngineNotFound                                ErrorCode = 627
+	ErrCodeOffsetFetchWithoutOrderBy                           ErrorCode = 628
+	ErrCodeHttpRangeNotSatisfiable                             ErrorCode = 629
+	ErrCodeHaveDependentObjects                                ErrorCode = 630
+	ErrCodeUnknownFileSize                                     ErrorCode = 631
+	ErrCodeUnexpectedDataAfterParsedValue                      ErrorCode = 632
+	ErrCodeQueryIsNotSupportedInWindowView                     ErrorCode = 633
+	ErrCodeMongodbError                                        ErrorCode = 634
+	ErrCodeCannotPoll                                          ErrorCode = 635
+	ErrCodeCannotExtractTableStructure                         ErrorCode = 636
+	ErrCodeInvalidTableOverride                                ErrorCode = 637
+	ErrCodeSnappyUncompressFailed                              ErrorCode = 638
+	ErrCodeSnappyCompressFailed                                ErrorCode = 639
+	ErrCodeNoHivemetastore                                     ErrorCode = 640
+	ErrCodeCannotAppendToFile                                  ErrorCode = 641
+	ErrCodeCannotPackArchive                                   ErrorCode = 642
+	ErrCodeCannotUnpackArchive                                 ErrorCode = 643
+	ErrCodeNumberOfDimensionsMismatched                        ErrorCode = 645
+	ErrCodeCannotBackupTable                                   ErrorCode = 647
+	ErrCodeWrongDdlRenamingSettings                            ErrorCode = 648
+	ErrCodeInvalidTransaction                                  ErrorCode = 649
+	ErrCodeSerializationError                                  ErrorCode = 650
+	ErrCodeCapnProtoBadType                                    ErrorCode = 651
+	ErrCodeOnlyNullsWhileReadingSchema                         ErrorCode = 652
+	ErrCodeCannotParseBackupSettings                           ErrorCode = 653
+	ErrCodeWrongBackupSettings                                 ErrorCode = 654
+	ErrCodeFailedToSyncBackupOrRestore                         ErrorCode = 655
+	ErrCodeUnknownStatusOfTransaction                          ErrorCode = 659
+	ErrCodeHdfsError                                           ErrorCode = 660
+	ErrCodeCannotSendSignal                                    ErrorCode = 661
+	ErrCodeFsMetadataError                                     ErrorCode = 662
+	ErrCodeInconsistentMetadataForBackup                       ErrorCode = 663
+	ErrCodeAccessStorageDoesntAllowBackup                      ErrorCode = 664
+	ErrCodeCannotConnectNats                                   ErrorCode = 665
+	ErrCodeNotInitialized                                      ErrorCode = 667
+	ErrCodeInvalidState                                        ErrorCode = 668
+	ErrCodeNamedCollectionDoesntExist                          ErrorCode = 669
+	ErrCodeNamedCollectionAlreadyExists                        ErrorCode = 670
+	ErrCodeNamedCollectionIsImmutable                          ErrorCode = 671
+	ErrCodeInvalidSchedulerNode                                ErrorCode = 672
+	ErrCodeResourceAccessDenied                                ErrorCode = 673
+	ErrCodeResourceNotFound                                    ErrorCode = 674
+	ErrCodeCannotParseIpv4                                     ErrorCode = 675
+	ErrCodeCannotParseIpv6                                     ErrorCode = 676
+	ErrCodeThreadWasCanceled                                   ErrorCode = 677
+	ErrCodeIoUringInitFailed                                   ErrorCode = 678
+	ErrCodeIoUringSubmitError                                  ErrorCode = 679
+	ErrCodeMixedAccessParameterTypes                           ErrorCode = 690
+	ErrCodeUnknownElementOfEnum                                ErrorCode = 691
+	ErrCodeTooManyMutations                                    ErrorCode = 692
+	ErrCodeAwsError                                            ErrorCode = 693
+	ErrCodeAsyncLoadCycle                                      ErrorCode = 694
+	ErrCodeAsyncLoadFailed                                     ErrorCode = 695
+	ErrCodeAsyncLoadCanceled                                   ErrorCode = 696
+	ErrCodeCannotRestoreToNonencryptedDisk                     ErrorCode = 697
+	ErrCodeInvalidRedisStorageType                             ErrorCode = 698
+	ErrCodeInvalidRedisTableStructure                          ErrorCode = 699
+	ErrCodeUserSessionLimitExceeded                            ErrorCode = 700
+	ErrCodeClusterDoesntExist                                  ErrorCode = 701
+	ErrCodeClientInfoDoesNotMatch                              ErrorCode = 702
+	ErrCodeInvalidIdentifier                                   ErrorCode = 703
+	ErrCodeQueryCacheUsedWithNondeterministicFunctions         ErrorCode = 704
+	ErrCodeTableNotEmpty                                       ErrorCode = 705
+	ErrCodeLibsshError                                         ErrorCode = 706
+	ErrCodeGcpError                                            ErrorCode = 707
+	ErrCodeIllegalStatistics                                   ErrorCode = 708
+	ErrCodeCannotGetReplicatedDatabaseSnapshot                 ErrorCode = 709
+	ErrCodeFaultInjected                                       ErrorCode = 710
+	ErrCodeFilecacheAccessDenied                               ErrorCode = 711
+	ErrCodeTooManyMaterializedViews                            ErrorCode = 712
+	ErrCodeBrokenProjection                                    ErrorCode = 713
+	ErrCodeUnexpectedCluster                                   ErrorCode = 714
+	ErrCodeCannotDetectFormat                                  ErrorCode = 715
+	ErrCodeCannotForgetPartition                               ErrorCode = 716
+	ErrCodeExperimentalFeatureError                            ErrorCode = 717
+	ErrCodeTooSlowParsing                                      ErrorCode = 718
+	ErrCodeQueryCacheUsedWithSystemTable                       ErrorCode = 719
+	ErrCodeUserExpired                                         ErrorCode = 720
+	ErrCodeDeprecatedFunction                                  ErrorCode = 721
+	ErrCodeAsyncLoadWaitFailed                                 ErrorCode = 722
+	ErrCodeParquetException                                    ErrorCode = 723
+	ErrCodeTooManyTables                                       ErrorCode = 724
+	ErrCodeTooManyDatabases                                    ErrorCode = 725
+	ErrCodeUnexpectedHttpHeaders                               ErrorCode = 726
+	ErrCodeUnexpectedTableEngine                               ErrorCode = 727
+	ErrCodeUnexpectedDataType                                  ErrorCode = 728
+	ErrCodeIllegalTimeSeriesTags                               ErrorCode = 729
+	ErrCodeRefreshFailed                                       ErrorCode = 730
+	ErrCodeQueryCacheUsedWithNonThrowOverflowMode              ErrorCode = 731
+	ErrCodeTableIsBeingRestarted                               ErrorCode = 733
+	ErrCodeCannotWriteAfterBufferCanceled                      ErrorCode = 734
+	ErrCodeQueryWasCancelledByClient                           ErrorCode = 735
+	ErrCodeDatalakeDatabaseError                               ErrorCode = 736
+	ErrCodeGoogleCloudError                                    ErrorCode = 737
+	ErrCodePartIsLocked                                        ErrorCode = 738
+	ErrCodeBuzzhouse                                           ErrorCode = 739
+	ErrCodePotentiallyBrokenDataPart                           ErrorCode = 740
+	ErrCodeTableUuidMismatch                                   ErrorCode = 741
+	ErrCodeDeltaKernelError                                    ErrorCode = 742
+	ErrCodeIcebergSpecificationViolation                       ErrorCode = 743
+	ErrCodeSessionIdEmpty                                      ErrorCode = 744
+	ErrCodeServerOverloaded                                    ErrorCode = 745
+	ErrCodeDependenciesNotFound                                ErrorCode = 746
+	ErrCodeFilecacheCannotWriteThroughCacheWithConcurrentReads ErrorCode = 747
+	ErrCodeDistributedCacheError                               ErrorCode = 900
+	ErrCodeCannotUseDistributedCache                           ErrorCode = 901
+	ErrCodeProtocolVersionMismatch                             ErrorCode = 902
+	ErrCodeLicenseExpired                                      ErrorCode = 903
+	ErrCodeKeeperException                                     ErrorCode = 999
+	ErrCodePocoException                                       ErrorCode = 1000
+	ErrCodeStdException                                        ErrorCode = 1001
+	ErrCodeUnknownException                                    ErrorCode = 1002
+	ErrCodeSshException                                        ErrorCode = 1003
+	ErrCodeStartupScriptsError                                 ErrorCode = 1004
+	ErrCodeConditionalTreeParentNotFound                       ErrorCode = 2001
+	ErrCodeIllegalProjectionManipulator                        ErrorCode = 2002
+)
+
+var errorCodeNames = map[ErrorCode]string{
+	ErrCodeUnsupportedMethod:                                   "UNSUPPORTED_METHOD",
+	ErrCodeUnsupportedParameter:                                "UNSUPPORTED_PARAMETER",
+	ErrCodeUnexpectedEndOfFile:                                 "UNEXPECTED_END_OF_FILE",
+	ErrCodeExpectedEndOfFile:                                   "EXPECTED_END_OF_FILE",
+	ErrCodeCannotParseText:                                     "CANNOT_PARSE_TEXT",
+	ErrCodeIncorrectNumberOfColumns:                            "INCORRECT_NUMBER_OF_COLUMNS",
+	ErrCodeThereIsNoColumn:                                     "THERE_IS_NO_COLUMN",
+	ErrCodeSizesOfColumnsDoesntMatch:                           "SIZES_OF_COLUMNS_DOESNT_MATCH",
+	ErrCodeNotFoundColumnInBlock:                               "NOT_FOUND_COLUMN_IN_BLOCK",
+	ErrCodePositionOutOfBound:                                  "POSITION_OUT_OF_BOUND",
+	ErrCodeParameterOutOfBound:                                 "PARAMETER_OUT_OF_BOUND",
+	ErrCodeSizesOfColumnsInTupleDoesntMatch:                    "SIZES_OF_COLUMNS_IN_TUPLE_DOESNT_MATCH",
+	ErrCodeDuplicateColumn:                                     "DUPLICATE_COLUMN",
+	ErrCodeNoSuchColumnInTable:                                 "NO_SUCH_COLUMN_IN_TABLE",
+	ErrCodeDelimiterInStringLiteralDoesntMatch:                 "DELIMITER_IN_STRING_LITERAL_DOESNT_MATCH",
+	ErrCodeCannotInsertElementIntoConstantColumn:               "CANNOT_INSERT_ELEMENT_INTO_CONSTANT_COLUMN",
+	ErrCodeSizeOfFixedStringDoesntMatch:                        "SIZE_OF_FIXED_STRING_DOESNT_MATCH",
+	ErrCodeNumberOfColumnsDoesntMatch:                          "NUMBER_OF_COLUMNS_DOESNT_MATCH",
+	ErrCodeCannotReadAllDataFromTabSeparatedInput:              "CANNOT_READ_ALL_DATA_FROM_TAB_SEPARATED_INPUT",
+	ErrCodeCannotParseAllValueFromTabSeparatedInput:            "CANNOT_PARSE_ALL_VALUE_FROM_TAB_SEPARATED_INPUT",
+	ErrCodeCannotReadFromIstream:                               "CANNOT_READ_FROM_ISTREAM",
+	ErrCodeCannotWriteToOstream:                                "CANNOT_WRITE_TO_OSTREAM",
+	ErrCodeCannotParseEscapeSequence:                           "CANNOT_PARSE_ESCAPE_SEQUENCE",
+	ErrCodeCannotParseQuotedString:                             "CANNOT_PARSE_QUOTED_STRING",
+	ErrCodeCannotParseInputAssertionFailed:                     "CANNOT_PARSE_INPUT_ASSERTION_FAILED",
+	ErrCodeCannotPrintFloatOrDoubleNumber:                      "CANNOT_PRINT_FLOAT_OR_DOUBLE_NUMBER",
+	ErrCodeCannotPrintInteger:                                  "CANNOT_PRINT_INTEGER",
+	ErrCodeCannotReadSizeOfCompressedChunk:                     "CANNOT_READ_SIZE_OF_COMPRESSED_CHUNK",
+	ErrCodeCannotReadCompressedChunk:                           "CANNOT_READ_COMPRESSED_CHUNK",
+	ErrCodeAttemptToReadAfterEof:                               "ATTEMPT_TO_READ_AFTER_EOF",
+	ErrCodeCannotReadAllData:                                   "CANNOT_READ_ALL_DATA",
+	ErrCodeTooManyArgumentsForFunction:                         "TOO_MANY_ARGUMENTS_FOR_FUNCTION",
+	ErrCodeTooFewArgumentsForFunction:                          "TOO_FEW_ARGUMENTS_FOR_FUNCTION",
+	ErrCodeBadArguments:                                        "BAD_ARGUMENTS",
+	ErrCodeUnknownElementInAst:                                 "UNKNOWN_ELEMENT_IN_AST",
+	ErrCodeCannotParseDate:                                     "CANNOT_PARSE_DATE",
+	ErrCodeTooLargeSizeCompressed:                              "TOO_LARGE_SIZE_COMPRESSED",
+	ErrCodeChecksumDoesntMatch:                                 "CHECKSUM_DOESNT_MATCH",
+	ErrCodeCannotParseDatetime:                                 "CANNOT_PARSE_DATETIME",
+	ErrCodeNumberOfArgumentsDoesntMatch:                        "NUMBER_OF_ARGUMENTS_DOESNT_MATCH",
+	ErrCodeIllegalTypeOfArgument:                               "ILLEGAL_TYPE_OF_ARGUMENT",
+	ErrCodeIllegalColumn:                                       "ILLEGAL_COLUMN",
+	ErrCodeIllegalNumberOfResultColumns:                        "ILLEGAL_NUMBER_OF_RESULT_COLUMNS",
+	ErrCodeUnknownFunction:                                     "UNKNOWN_FUNCTION",
+	ErrCodeUnknownIdentifier:                                   "UNKNOWN_IDENTIFIER",
+	ErrCodeNotImplemented:                                      "NOT_IMPLEMENTED",
+	ErrCodeLogicalError:                                        "LOGICAL_ERROR",
+	ErrCodeUnknownType:                                         "UNKNOWN_TYPE",
+	ErrCodeEmptyListOfColumnsQueried:                           "EMPTY_LIST_OF_COLUMNS_QUERIED",
+	ErrCodeColumnQueriedMoreThanOnce:                           "COLUMN_QUERIED_MORE_THAN_ONCE",
+	ErrCodeTypeMismatch:                                        "TYPE_MISMATCH",
+	ErrCodeStorageDoesntAllowParameters:                        "STORAGE_DOESNT_ALLOW_PARAMETERS",
+	ErrCodeStorageRequiresParameter:                            "STORAGE_REQUIRES_PARAMETER",
+	ErrCodeUnknownStorage:                                      "UNKNOWN_STORAGE",
+	ErrCodeTableAlreadyExists:                                  "TABLE_ALREADY_EXISTS",
+	ErrCodeTableMetadataAlreadyExists:                          "TABLE_METADATA_ALREADY_EXISTS",
+	ErrCodeIllegalTypeOfColumnForFilter:                        "ILLEGAL_TYPE_OF_COLUMN_FOR_FILTER",
+	ErrCodeUnknownTable:                                        "UNKNOWN_TABLE",
+	ErrCodeOnlyFilterColumnInBlock:                             "ONLY_FILTER_COLUMN_IN_BLOCK",
+	ErrCodeSyntaxError:                                         "SYNTAX_ERROR",
+	ErrCodeUnknownAggregateFunction:                            "UNKNOWN_AGGREGATE_FUNCTION",
+	ErrCodeCannotReadAggregateFunctionFromText:                 "CANNOT_READ_AGGREGATE_FUNCTION_FROM_TEXT",
+	ErrCodeCannotWriteAggregateFunctionAsText:                  "CANNOT_WRITE_AGGREGATE_FUNCTION_AS_TEXT",
+	ErrCodeNotAColumn:                                          "NOT_A_COLUMN",
+	ErrCodeIllegalKeyOfAggregation:                             "ILLEGAL_KEY_OF_AGGREGATION",
+	ErrCodeCannotGetSizeOfField:                                "CANNOT_GET_SIZE_OF_FIELD",
+	ErrCodeArgumentOutOfBound:                                  "ARGUMENT_OUT_OF_BOUND",
+	ErrCodeCannotConvertType:                                   "CANNOT_CONVERT_TYPE",
+	ErrCodeCannotWriteAfterEndOfBuffer:                         "CANNOT_WRITE_AFTER_END_OF_BUFFER",
+	ErrCodeCannotParseNumber:                                   "CANNOT_PARSE_NUMBER",
+	ErrCodeUnknownFormat:                                       "UNKNOWN_FORMAT",
+	ErrCodeCannotReadFromFileDescriptor:                        "CANNOT_READ_FROM_FILE_DESCRIPTOR",
+	ErrCodeCannotWriteToFileDescriptor:                         "CANNOT_WRITE_TO_FILE_DESCRIPTOR",
+	ErrCodeCannotOpenFile:                                      "CANNOT_OPEN_FILE",
+	ErrCodeCannotCloseFile:                                     "CANNOT_CLOSE_FILE",
+	ErrCodeUnknownTypeOfQuery:                                  "UNKNOWN_TYPE_OF_QUERY",
+	ErrCodeIncorrectFileName:                                   "INCORRECT_FILE_NAME",
+	ErrCodeIncorrectQuery:                                      "INCORRECT_QUERY",
+	ErrCodeUnknownDatabase:                                     "UNKNOWN_DATABASE",
+	ErrCodeDatabaseAlreadyExists:                               "DATABASE_ALREADY_EXISTS",
+	ErrCodeDirectoryDoesntExist:                                "DIRECTORY_DOESNT_EXIST",
+	ErrCodeDirectoryAlreadyExists:                              "DIRECTORY_ALREADY_EXISTS",
+	ErrCodeFormatIsNotSuitableForInput:                         "FORMAT_IS_NOT_SUITABLE_FOR_INPUT",
+	ErrCodeReceivedErrorFromRemoteIoServer:                     "RECEIVED_ERROR_FROM_REMOTE_IO_SERVER",
+	ErrCodeCannotSeekThroughFile:                               "CANNOT_SEEK_THROUGH_FILE",
+	ErrCodeCannotTruncateFile:                                  "CANNOT_TRUNCATE_FILE",
+	ErrCodeUnknownCompressionMethod:                            "UNKNOWN_COMPRESSION_METHOD",
+	ErrCodeEmptyListOfColumnsPassed:                            "EMPTY_LIST_OF_COLUMNS_PASSED",
+	ErrCodeSizesOfMarksFilesAreInconsistent:                    "SIZES_OF_MARKS_FILES_ARE_INCONSISTENT",
+	ErrCodeEmptyDataPassed:                                     "EMPTY_DATA_PASSED",
+	ErrCodeUnknownAggregatedDataVariant:                        "UNKNOWN_AGGREGATED_DATA_VARIANT",
+	ErrCodeCannotMergeDifferentAggregatedDataVariants:          "CANNOT_MERGE_DIFFERENT_AGGREGATED_DATA_VARIANTS",
+	ErrCodeCannotReadFromSocket:                                "CANNOT_READ_FROM_SOCKET",
+	ErrCodeCannotWriteToSocket:                                 "CANNOT_WRITE_TO_SOCKET",
+	ErrCodeCannotReadAllDataFromChunkedInput:                   "CANNOT_READ_ALL_DATA_FROM_CHUNKED_INPUT",
+	ErrCodeCannotWriteToEmptyBlockOutputStream:                 "CANNOT_WRITE_TO_EMPTY_BLOCK_OUTPUT_STREAM",
+	ErrCodeUnknownPacketFromClient:                             "UNKNOWN_PACKET_FROM_CLIENT",
+	ErrCodeUnknownPacketFromServer:                             "UNKNOWN_PACKET_FROM_SERVER",
+	ErrCodeUnexpectedPacketFromClient:                          "UNEXPECTED_PACKET_FROM_CLIENT",
+	ErrCodeUnexpectedPacketFromServer:                          "UNEXPECTED_PACKET_FROM_SERVER",
+	ErrCodeReceivedDataForWrongQueryId:                         "RECEIVED_DATA_FOR_WRONG_QUERY_ID",
+	ErrCodeTooSmallBufferSize:                                  "TOO_SMALL_BUFFER_SIZE",
+	ErrCodeCannotReadHistory:                                   "CANNOT_READ_HISTORY",
+	ErrCodeCannotAppendHistory:                                 "CANNOT_APPEND_HISTORY",
+	ErrCodeFileDoesntExist:                                     "FILE_DOESNT_EXIST",
+	ErrCodeNoDataToInsert:                                      "NO_DATA_TO_INSERT",
+	ErrCodeCannotBlockSignal:                                   "CANNOT_BLOCK_SIGNAL",
+	ErrCodeCannotUnblockSignal:                                 "CANNOT_UNBLOCK_SIGNAL",
+	ErrCodeCannotManipulateSigset:                              "CANNOT_MANIPULATE_SIGSET",
+	ErrCodeCannotWaitForSignal:                                 "CANNOT_WAIT_FOR_SIGNAL",
+	ErrCodeThereIsNoSession:                                    "THERE_IS_NO_SESSION",
+	ErrCodeCannotClockGettime:                                  "CANNOT_CLOCK_GETTIME",
+	ErrCodeUnknownSetting:                                      "UNKNOWN_SETTING",
+	ErrCodeThereIsNoDefaultValue:                               "THERE_IS_NO_DEFAULT_VALUE",
+	ErrCodeIncorrectData:                                       "INCORRECT_DATA",
+	ErrCodeEngineRequired:                                      "ENGINE_REQUIRED",
+	ErrCodeCannotInsertValueOfDifferentSizeIntoTuple:           "CANNOT_INSERT_VALUE_OF_DIFFERENT_SIZE_INTO_TUPLE",
+	ErrCodeUnsupportedJoinKeys:                                 "UNSUPPORTED_JOIN_KEYS",
+	ErrCodeIncompatibleColumns:                                 "INCOMPATIBLE_COLUMNS",
+	ErrCodeUnknownTypeOfAstNode:                                "UNKNOWN_TYPE_OF_AST_NODE",
+	ErrCodeIncorrectElementOfSet:                               "INCORRECT_ELEMENT_OF_SET",
+	ErrCodeIncorrectResultOfScalarSubquery:                     "INCORRECT_RESULT_OF_SCALAR_SUBQUERY",
+	ErrCodeCannotGetReturnType:                                 "CANNOT_GET_RETURN_TYPE",
+	ErrCodeIllegalIndex:                                        "ILLEGAL_INDEX",
+	ErrCodeTooLargeArraySize:                                   "TOO_LARGE_ARRAY_SIZE",
+	ErrCodeFunctionIsSpecial:                                   "FUNCTION_IS_SPECIAL",
+	ErrCodeCannotReadArrayFromText:                             "CANNOT_READ_ARRAY_FROM_TEXT",
+	ErrCodeTooLargeStringSize:                                  "TOO_LARGE_STRING_SIZE",
+	ErrCodeCannotCreateTableFromMetadata:                       "CANNOT_CREATE_TABLE_FROM_METADATA",
+	ErrCodeAggregateFunctionDoesntAllowParameters:              "AGGREGATE_FUNCTION_DOESNT_ALLOW_PARAMETERS",
+	ErrCodeParametersToAggregateFunctionsMustBeLiterals:        "PARAMETERS_TO_AGGREGATE_FUNCTIONS_MUST_BE_LITERALS",
+	ErrCodeZeroArrayOrTupleIndex:                               "ZERO_ARRAY_OR_TUPLE_INDEX",
+	ErrCodeUnknownElementInConfig:                              "UNKNOWN_ELEMENT_IN_CONFIG",
+	ErrCodeExcessiveElementInConfig:                            "EXCESSIVE_ELEMENT_IN_CONFIG",
+	ErrCodeNoElementsInConfig:                                  "NO_ELEMENTS_IN_CONFIG",
+	ErrCodeAllRequestedColumnsAreMissing:                       "ALL_REQUESTED_COLUMNS_ARE_MISSING",
+	ErrCodeSamplingNotSupported:                                "SAMPLING_NOT_SUPPORTED",
+	ErrCodeNotFoundNode:                                        "NOT_FOUND_NODE",
+	ErrCodeFoundMoreThanOneNode:                                "FOUND_MORE_THAN_ONE_NODE",
+	ErrCodeFirstDateIsBiggerThanLastDate:                       "FIRST_DATE_IS_BIGGER_THAN_LAST_DATE",
+	ErrCodeUnknownOverflowMode:                                 "UNKNOWN_OVERFLOW_MODE",
+	ErrCodeQuerySectionDoesntMakeSense:                         "QUERY_SECTION_DOESNT_MAKE_SENSE",
+	ErrCodeNotFoundFunctionElementForAggregate:                 "NOT_FOUND_FUNCTION_ELEMENT_FOR_AGGREGATE",
+	ErrCodeNotFoundRelationElementForCondition:                 "NOT_FOUND_RELATION_ELEMENT_FOR_CONDITION",
+	ErrCodeNotFoundRhsElementForCondition:                      "NOT_FOUND_RHS_ELEMENT_FOR_CONDITION",
+	ErrCodeNoAttributesListed:                                  "NO_ATTRIBUTES_LISTED",
+	ErrCodeIndexOfColumnInSortClauseIsOutOfRange:               "INDEX_OF_COLUMN_IN_SORT_CLAUSE_IS_OUT_OF_RANGE",
+	ErrCodeUnknownDirectionOfSorting:                           "UNKNOWN_DIRECTION_OF_SORTING",
+	ErrCodeIllegalDivision:                                     "ILLEGAL_DIVISION",
+	ErrCodeAggregateFunctionNotApplicable:                      "AGGREGATE_FUNCTION_NOT_APPLICABLE",
+	ErrCodeUnknownRelation:                                     "UNKNOWN_RELATION",
+	ErrCodeDictionariesWasNotLoaded:                            "DICTIONARIES_WAS_NOT_LOADED",
+	ErrCodeIllegalOverflowMode:                                 "ILLEGAL_OVERFLOW_MODE",
+	ErrCodeTooManyRows:                                         "TOO_MANY_ROWS",
+	ErrCodeTimeoutExceeded:                                     "TIMEOUT_EXCEEDED",
+	ErrCodeTooSlow:                                             "TOO_SLOW",
+	ErrCodeTooManyColumns:                                      "TOO_MANY_COLUMNS",
+	ErrCodeTooDeepSubqueries:                                   "TOO_DEEP_SUBQUERIES",
+	ErrCodeTooDeepPipeline:                                     "TOO_DEEP_PIPELINE",
+	ErrCodeReadonly:                                            "READONLY",
+	ErrCodeTooManyTemporaryColumns:                             "TOO_MANY_TEMPORARY_COLUMNS",
+	ErrCodeTooManyTemporaryNonConstColumns:                     "TOO_MANY_TEMPORARY_NON_CONST_COLUMNS",
+	ErrCodeTooDeepAst:                                          "TOO_DEEP_AST",
+	ErrCodeTooBigAst:                                           "TOO_BIG_AST",
+	ErrCodeBadTypeOfField:                                      "BAD_TYPE_OF_FIELD",
+	ErrCodeBadGet:                                              "BAD_GET",
+	ErrCodeBlocksHaveDifferentStructure:                        "BLOCKS_HAVE_DIFFERENT_STRUCTURE",
+	ErrCodeCannotCreateDirectory:                               "CANNOT_CREATE_DIRECTORY",
+	ErrCodeCannotAllocateMemory:                                "CANNOT_ALLOCATE_MEMORY",
+	ErrCodeCyclicAliases:                                       "CYCLIC_ALIASES",
+	ErrCodeChunkNotFound:                                       "CHUNK_NOT_FOUND",
+	ErrCodeDuplicateChunkName:                                  "DUPLICATE_CHUNK_NAME",
+	ErrCodeMultipleAliasesForExpression:                        "MULTIPLE_ALIASES_FOR_EXPRESSION",
+	ErrCodeMultipleExpressionsForAlias:                         "MULTIPLE_EXPRESSIONS_FOR_ALIAS",
+	ErrCodeThereIsNoProfile:                                    "THERE_IS_NO_PROFILE",
+	ErrCodeIllegalFinal:                                        "ILLEGAL_FINAL",
+	ErrCodeIllegalPrewhere:                                     "ILLEGAL_PREWHERE",
+	ErrCodeUnexpectedExpression:                                "UNEXPECTED_EXPRESSION",
+	ErrCodeIllegalAggregation:                                  "ILLEGAL_AGGREGATION",
+	ErrCodeUnsupportedMyisamBlockType:                          "UNSUPPORTED_MYISAM_BLOCK_TYPE",
+	ErrCodeUnsupportedCollationLocale:                          "UNSUPPORTED_COLLATION_LOCALE",
+	ErrCodeCollationComparisonFailed:                           "COLLATION_COMPARISON_FAILED",
+	ErrCodeUnknownAction:                                       "UNKNOWN_ACTION",
+	ErrCodeTableMustNotBeCreatedManually:                       "TABLE_MUST_NOT_BE_CREATED_MANUALLY",
+	ErrCodeSizesOfArraysDontMatch:                              "SIZES_OF_ARRAYS_DONT_MATCH",
+	ErrCodeSetSizeLimitExceeded:                                "SET_SIZE_LIMIT_EXCEEDED",
+	ErrCodeUnknownUser:                                         "UNKNOWN_USER",
+	ErrCodeWrongPassword:                                       "WRONG_PASSWORD",
+	ErrCodeRequiredPassword:                                    "REQUIRED_PASSWORD",
+	ErrCodeIpAddressNotAllowed:                                 "IP_ADDRESS_NOT_ALLOWED",
+	ErrCodeUnknownAddressPatternType:                           "UNKNOWN_ADDRESS_PATTERN_TYPE",
+	ErrCodeServerRevisionIsTooOld:                              "SERVER_REVISION_IS_TOO_OLD",
+	ErrCodeDnsError:                                            "DNS_ERROR",
+	ErrCodeUnknownQuota:                                        "UNKNOWN_QUOTA",
+	ErrCodeQuotaDoesntAllowKeys:                                "QUOTA_DOESNT_ALLOW_KEYS",
+	ErrCodeQuotaExpired:                                        "QUOTA_EXPIRED",
+	ErrCodeTooManySimultaneousQueries:                          "TOO_MANY_SIMULTANEOUS_QUERIES",
+	ErrCodeNoFreeConnection:                                    "NO_FREE_CONNECTION",
+	ErrCodeCannotFsync:                                         "CANNOT_FSYNC",
+	ErrCodeNestedTypeTooDeep:                                   "NESTED_TYPE_TOO_DEEP",
+	ErrCodeAliasRequired:                                       "ALIAS_REQUIRED",
+	ErrCodeAmbiguousIdentifier:                                 "AMBIGUOUS_IDENTIFIER",
+	ErrCodeEmptyNestedTable:                                    "EMPTY_NESTED_TABLE",
+	ErrCodeSocketTimeout:                                       "SOCKET_TIMEOUT",
+	ErrCodeNetworkError:                                        "NETWORK_ERROR",
+	ErrCodeEmptyQuery:                                          "EMPTY_QUERY",
+	ErrCodeUnknownLoadBalancing:                                "UNKNOWN_LOAD_BALANCING",
+	ErrCodeUnknownTotalsMode:                                   "UNKNOWN_TOTALS_MODE",
+	ErrCodeCannotStatvfs:                                       "CANNOT_STATVFS",
+	ErrCodeNotAnAggregate:                                      "NOT_AN_AGGREGATE",
+	ErrCodeQueryWithSameIdIsAlreadyRunning:                     "QUERY_WITH_SAME_ID_IS_ALREADY_RUNNING",
+	ErrCodeClientHasConnectedToWrongPort:                       "CLIENT_HAS_CONNECTED_TO_WRONG_PORT",
+	ErrCodeTableIsDropped:                                      "TABLE_IS_DROPPED",
+	ErrCodeDatabaseNotEmpty:                                    "DATABASE_NOT_EMPTY",
+	ErrCodeDuplicateInterserverIoEndpoint:                      "DUPLICATE_INTERSERVER_IO_ENDPOINT",
+	ErrCodeNoSuchInterserverIoEndpoint:                         "NO_SUCH_INTERSERVER_IO_ENDPOINT",
+	ErrCodeAddingReplicaToNonEmptyTable:                        "ADDING_REPLICA_TO_NON_EMPTY_TABLE",
+	ErrCodeUnexpectedAstStructure:                              "UNEXPECTED_AST_STRUCTURE",
+	ErrCodeReplicaIsAlreadyActive:                              "REPLICA_IS_ALREADY_ACTIVE",
+	ErrCodeNoZookeeper:                                         "NO_ZOOKEEPER",
+	ErrCodeNoFileInDataPart:                                    "NO_FILE_IN_DATA_PART",
+	ErrCodeUnexpectedFileInDataPart:                            "UNEXPECTED_FILE_IN_DATA_PART",
+	ErrCodeBadSizeOfFileInDataPart:                             "BAD_SIZE_OF_FILE_IN_DATA_PART",
+	ErrCodeQueryIsTooLarge:                                     "QUERY_IS_TOO_LARGE",
+	ErrCodeNotFoundExpectedDataPart:                            "NOT_FOUND_EXPECTED_DATA_PART",
+	ErrCodeTooManyUnexpectedDataParts:                          "TOO_MANY_UNEXPECTED_DATA_PARTS",
+	ErrCodeNoSuchDataPart:                                      "NO_SUCH_DATA_PART",
+	ErrCodeBadDataPartName:                                     "BAD_DATA_PART_NAME",
+	ErrCodeNoReplicaHasPart:                                    "NO_REPLICA_HAS_PART",
+	ErrCodeDuplicateDataPart:                                   "DUPLICATE_DATA_PART",
+	ErrCodeAborted:                                             "ABORTED",
+	ErrCodeNoReplicaNameGiven:                                  "NO_REPLICA_NAME_GIVEN",
+	ErrCodeFormatVersionTooOld:                                 "FORMAT_VERSION_TOO_OLD",
+	ErrCodeCannotMunmap:                                        "CANNOT_MUNMAP",
+	ErrCodeCannotMremap:                                        "CANNOT_MREMAP",
+	ErrCodeMemoryLimitExceeded:                                 "MEMORY_LIMIT_EXCEEDED",
+	ErrCodeTableIsReadOnly:                                     "TABLE_IS_READ_ONLY",
+	ErrCodeNotEnoughSpace:                                      "NOT_ENOUGH_SPACE",
+	ErrCodeUnexpectedZookeeperError:                            "UNEXPECTED_ZOOKEEPER_ERROR",
+	ErrCodeCorruptedData:                                       "CORRUPTED_DATA",
+	ErrCodeIncorrectMark:                                       "INCORRECT_MARK",
+	ErrCodeInvalidPartitionValue:                               "INVALID_PARTITION_VALUE",
+	ErrCodeNotEnoughBlockNumbers:                               "NOT_ENOUGH_BLOCK_NUMBERS",
+	ErrCodeNoSuchReplica:                                       "NO_SUCH_REPLICA",
+	ErrCodeTooManyParts:                                        "TOO_MANY_PARTS",
+	ErrCodeReplicaIsAlreadyExist:                               "REPLICA_IS_ALREADY_EXIST",
+	ErrCodeNoActiveReplicas:                                    "NO_ACTIVE_REPLICAS",
+	ErrCodeTooManyRetriesToFetchParts:                          "TOO_MANY_RETRIES_TO_FETCH_PARTS",
+	ErrCodePartitionAlreadyExists:                              "PARTITION_ALREADY_EXISTS",
+	ErrCodePartitionDoesntExist:                                "PARTITION_DOESNT_EXIST",
+	ErrCodeUnionAllResultStructuresMismatch:                    "UNION_ALL_RESULT_STRUCTURES_MISMATCH",
+	ErrCodeClientOutputFormatSpecified:                         "CLIENT_OUTPUT_FORMAT_SPECIFIED",
+	ErrCodeUnknownBlockInfoField:                               "UNKNOWN_BLOCK_INFO_FIELD",
+	ErrCodeBadCollation:                                        "BAD_COLLATION",
+	ErrCodeCannotCompileCode:                                   "CANNOT_COMPILE_CODE",
+	ErrCodeIncompatibleTypeOfJoin:                              "INCOMPATIBLE_TYPE_OF_JOIN",
+	ErrCodeNoAvailableReplica:                                  "NO_AVAILABLE_REPLICA",
+	ErrCodeMismatchReplicasDataSources:                         "MISMATCH_REPLICAS_DATA_SOURCES",
+	ErrCodeStorageDoesntSupportParallelReplicas:                "STORAGE_DOESNT_SUPPORT_PARALLEL_REPLICAS",
+	ErrCodeCpuidError:                                          "CPUID_ERROR",
+	ErrCodeInfiniteLoop:                                        "INFINITE_LOOP",
+	ErrCodeCannotCompress:                                      "CANNOT_COMPRESS",
+	ErrCodeCannotDecompress:                                    "CANNOT_DECOMPRESS",
+	ErrCodeCannotIoSubmit:                                      "CANNOT_IO_SUBMIT",
+	ErrCodeCannotIoGetevents:                                   "CANNOT_IO_GETEVENTS",
+	ErrCodeAioReadError:                                        "AIO_READ_ERROR",
+	ErrCodeAioWriteError:                                       "AIO_WRITE_ERROR",
+	ErrCodeIndexNotUsed:                                        "INDEX_NOT_USED",
+	ErrCodeLeadershipLost:                                      "LEADERSHIP_LOST",
+	ErrCodeAllConnectionTriesFailed:                            "ALL_CONNECTION_TRIES_FAILED",
+	ErrCodeNoAvailableData:                                     "NO_AVAILABLE_DATA",
+	ErrCodeDictionaryIsEmpty:                                   "DICTIONARY_IS_EMPTY",
+	ErrCodeIncorrectIndex:                                      "INCORRECT_INDEX",
+	ErrCodeUnknownDistributedProductMode:                       "UNKNOWN_DISTRIBUTED_PRODUCT_MODE",
+	ErrCodeWrongGlobalSubquery:                                 "WRONG_GLOBAL_SUBQUERY",
+	ErrCodeTooFewLiveReplicas:                                  "TOO_FEW_LIVE_REPLICAS",
+	ErrCodeUnsatisfiedQuorumForPreviousWrite:                   "UNSATISFIED_QUORUM_FOR_PREVIOUS_WRITE",
+	ErrCodeUnknownFormatVersion:                                "UNKNOWN_FORMAT_VERSION",
+	ErrCodeDistributedInJoinSubqueryDenied:                     "DISTRIBUTED_IN_JOIN_SUBQUERY_DENIED",
+	ErrCodeReplicaIsNotInQuorum:                                "REPLICA_IS_NOT_IN_QUORUM",
+	ErrCodeLimitExceeded:                                       "LIMIT_EXCEEDED",
+	ErrCodeDatabaseAccessDenied:                                "DATABASE_ACCESS_DENIED",
+	ErrCodeLeadershipChanged:                                   "LEADERSHIP_CHANGED",
+	ErrCodeMongodbCannotAuthenticate:                           "MONGODB_CANNOT_AUTHENTICATE",
+	ErrCodeCannotWriteToFile:                                   "CANNOT_WRITE_TO_FILE",
+	ErrCodeReceivedEmptyData:                                   "RECEIVED_EMPTY_DATA",
+	ErrCodeNoRemoteShardFound:                                  "NO_REMOTE_SHARD_FOUND",
+	ErrCodeShardHasNoConnections:                               "SHARD_HAS_NO_CONNECTIONS",
+	ErrCodeCannotPipe:                                          "CANNOT_PIPE",
+	ErrCodeCannotFork:                                          "CANNOT_FORK",
+	ErrCodeCannotDlsym:                                         "CANNOT_DLSYM",
+	ErrCodeCannotCreateChildProcess:                            "CANNOT_CREATE_CHILD_PROCESS",
+	ErrCodeChildWasNotExitedNormally:                           "CHILD_WAS_NOT_EXITED_NORMALLY",
+	ErrCodeCannotSelect:                                        "CANNOT_SELECT",
+	ErrCodeCannotWaitpid:                                       "CANNOT_WAITPID",
+	ErrCodeTableWasNotDropped:                                  "TABLE_WAS_NOT_DROPPED",
+	ErrCodeTooDeepRecursion:                                    "TOO_DEEP_RECURSION",
+	ErrCodeTooManyBytes:                                        "TOO_MANY_BYTES",
+	ErrCodeUnexpectedNodeInZookeeper:                           "UNEXPECTED_NODE_IN_ZOOKEEPER",
+	ErrCodeFunctionCannotHaveParameters:                        "FUNCTION_CANNOT_HAVE_PARAMETERS",
+	ErrCodeInvalidShardWeight:                                  "INVALID_SHARD_WEIGHT",
+	ErrCodeInvalidConfigParameter:                              "INVALID_CONFIG_PARAMETER",
+	ErrCodeUnknownStatusOfInsert:                               "UNKNOWN_STATUS_OF_INSERT",
+	ErrCodeValueIsOutOfRangeOfDataType:                         "VALUE_IS_OUT_OF_RANGE_OF_DATA_TYPE",
+	ErrCodeBarrierTimeout:                                      "BARRIER_TIMEOUT",
+	ErrCodeUnknownDatabaseEngine:                               "UNKNOWN_DATABASE_ENGINE",
+	ErrCodeDdlGuardIsActive:                                    "DDL_GUARD_IS_ACTIVE",
+	ErrCodeUnfinished:                                          "UNFINISHED",
+	ErrCodeMetadataMismatch:                                    "METADATA_MISMATCH",
+	ErrCodeSupportIsDisabled:                                   "SUPPORT_IS_DISABLED",
+	ErrCodeTableDiffersTooMuch:                                 "TABLE_DIFFERS_TOO_MUCH",
+	ErrCodeCannotConvertCharset:                                "CANNOT_CONVERT_CHARSET",
+	ErrCodeCannotLoadConfig:                                    "CANNOT_LOAD_CONFIG",
+	ErrCodeCannotInsertNullInOrdinaryColumn:                    "CANNOT_INSERT_NULL_IN_ORDINARY_COLUMN",
+	ErrCodeIncompatibleSourceTables:                            "INCOMPATIBLE_SOURCE_TABLES",
+	ErrCodeAmbiguousTableName:                                  "AMBIGUOUS_TABLE_NAME",
+	ErrCodeAmbiguousColumnName:                                 "AMBIGUOUS_COLUMN_NAME",
+	ErrCodeIndexOfPositionalArgumentIsOutOfRange:               "INDEX_OF_POSITIONAL_ARGUMENT_IS_OUT_OF_RANGE",
+	ErrCodeZlibInflateFailed:                                   "ZLIB_INFLATE_FAILED",
+	ErrCodeZlibDeflateFailed:                                   "ZLIB_DEFLATE_FAILED",
+	ErrCodeBadLambda:                                           "BAD_LAMBDA",
+	ErrCodeReservedIdentifierName:                              "RESERVED_IDENTIFIER_NAME",
+	ErrCodeIntoOutfileNotAllowed:                               "INTO_OUTFILE_NOT_ALLOWED",
+	ErrCodeTableSizeExceedsMaxDropSizeLimit:                    "TABLE_SIZE_EXCEEDS_MAX_DROP_SIZE_LIMIT",
+	ErrCodeCannotCreateCharsetConverter:                        "CANNOT_CREATE_CHARSET_CONVERTER",
+	ErrCodeSeekPositionOutOfBound:                              "SEEK_POSITION_OUT_OF_BOUND",
+	ErrCodeCurrentWriteBufferIsExhausted:                       "CURRENT_WRITE_BUFFER_IS_EXHAUSTED",
+	ErrCodeCannotCreateIoBuffer:                                "CANNOT_CREATE_IO_BUFFER",
+	ErrCodeReceivedErrorTooManyRequests:                        "RECEIVED_ERROR_TOO_MANY_REQUESTS",
+	ErrCodeOutputIsNotSorted:                                   "OUTPUT_IS_NOT_SORTED",
+	ErrCodeSizesOfNestedColumnsAreInconsistent:                 "SIZES_OF_NESTED_COLUMNS_ARE_INCONSISTENT",
+	ErrCodeTooManyFetches:                                      "TOO_MANY_FETCHES",
+	ErrCodeBadCast:                                             "BAD_CAST",
+	ErrCodeAllReplicasAreStale:                                 "ALL_REPLICAS_ARE_STALE",
+	ErrCodeDataTypeCannotBeUsedInTables:                        "DATA_TYPE_CANNOT_BE_USED_IN_TABLES",
+	ErrCodeInconsistentClusterDefinition:                       "INCONSISTENT_CLUSTER_DEFINITION",
+	ErrCodeSessionNotFound:                                     "SESSION_NOT_FOUND",
+	ErrCodeSessionIsLocked:                                     "SESSION_IS_LOCKED",
+	ErrCodeInvalidSessionTimeout:                               "INVALID_SESSION_TIMEOUT",
+	ErrCodeCannotDlopen:                                        "CANNOT_DLOPEN",
+	ErrCodeCannotParseUuid:                                     "CANNOT_PARSE_UUID",
+	ErrCodeIllegalSyntaxForDataType:                            "ILLEGAL_SYNTAX_FOR_DATA_TYPE",
+	ErrCodeDataTypeCannotHaveArguments:                         "DATA_TYPE_CANNOT_HAVE_ARGUMENTS",
+	ErrCodeUnknownStatusOfDistributedDdlTask:                   "UNKNOWN_STATUS_OF_DISTRIBUTED_DDL_TASK",
+	ErrCodeCannotKill:                                          "CANNOT_KILL",
+	ErrCodeHttpLengthRequired:                                  "HTTP_LENGTH_REQUIRED",
+	ErrCodeCannotLoadCatboostModel:                             "CANNOT_LOAD_CATBOOST_MODEL",
+	ErrCodeCannotApplyCatboostModel:                            "CANNOT_APPLY_CATBOOST_MODEL",
+	ErrCodePartIsTemporarilyLocked:                             "PART_IS_TEMPORARILY_LOCKED",
+	ErrCodeMultipleStreamsRequired:                             "MULTIPLE_STREAMS_REQUIRED",
+	ErrCodeNoCommonType:                                        "NO_COMMON_TYPE",
+	ErrCodeExternalLoadableAlreadyExists:                       "EXTERNAL_LOADABLE_ALREADY_EXISTS",
+	ErrCodeCannotAssignOptimize:                                "CANNOT_ASSIGN_OPTIMIZE",
+	ErrCodeInsertWasDeduplicated:                               "INSERT_WAS_DEDUPLICATED",
+	ErrCodeCannotGetCreateTableQuery:                           "CANNOT_GET_CREATE_TABLE_QUERY",
+	ErrCodeExternalLibraryError:                                "EXTERNAL_LIBRARY_ERROR",
+	ErrCodeQueryIsProhibited:                                   "QUERY_IS_PROHIBITED",
+	ErrCodeThereIsNoQuery:                                      "THERE_IS_NO_QUERY",
+	ErrCodeQueryWasCancelled:                                   "QUERY_WAS_CANCELLED",
+	ErrCodeFunctionThrowIfValueIsNonZero:                       "FUNCTION_THROW_IF_VALUE_IS_NON_ZERO",
+	ErrCodeTooManyRowsOrBytes:                                  "TOO_MANY_ROWS_OR_BYTES",
+	ErrCodeQueryIsNotSupportedInMaterializedView:               "QUERY_IS_NOT_SUPPORTED_IN_MATERIALIZED_VIEW",
+	ErrCodeUnknownMutationCommand:                              "UNKNOWN_MUTATION_COMMAND",
+	ErrCodeFormatIsNotSuitableForOutput:                        "FORMAT_IS_NOT_SUITABLE_FOR_OUTPUT",
+	ErrCodeCannotStat:                                          "CANNOT_STAT",
+	ErrCodeFeatureIsNotEnabledAtBuildTime:                      "FEATURE_IS_NOT_ENABLED_AT_BUILD_TIME",
+	ErrCodeCannotIosetup:                                       "CANNOT_IOSETUP",
+	ErrCodeInvalidJoinOnExpression:                             "INVALID_JOIN_ON_EXPRESSION",
+	ErrCodeBadOdbcConnectionString:                             "BAD_ODBC_CONNECTION_STRING",
+	ErrCodeTopAndLimitTogether:                                 "TOP_AND_LIMIT_TOGETHER",
+	ErrCodeDecimalOverflow:                                     "DECIMAL_OVERFLOW",
+	ErrCodeBadRequestParameter:                                 "BAD_REQUEST_PARAMETER",
+	ErrCodeExternalServerIsNotResponding:                       "EXTERNAL_SERVER_IS_NOT_RESPONDING",
+	ErrCodePthreadError:                                        "PTHREAD_ERROR",
+	ErrCodeNetlinkError:                                        "NETLINK_ERROR",
+	ErrCodeCannotSetSignalHandler:                              "CANNOT_SET_SIGNAL_HANDLER",
+	ErrCodeAllReplicasLost:                                     "ALL_REPLICAS_LOST",
+	ErrCodeReplicaStatusChanged:                                "REPLICA_STATUS_CHANGED",
+	ErrCodeExpectedAllOrAny:                                    "EXPECTED_ALL_OR_ANY",
+	ErrCodeUnknownJoin:                                         "UNKNOWN_JOIN",
+	ErrCodeMultipleAssignmentsToColumn:                         "MULTIPLE_ASSIGNMENTS_TO_COLUMN",
+	ErrCodeCannotUpdateColumn:                                  "CANNOT_UPDATE_COLUMN",
+	ErrCodeCannotAddDifferentAggregateStates:                   "CANNOT_ADD_DIFFERENT_AGGREGATE_STATES",
+	ErrCodeUnsupportedUriScheme:                                "UNSUPPORTED_URI_SCHEME",
+	ErrCodeCannotGettimeofday:                                  "CANNOT_GETTIMEOFDAY",
+	ErrCodeCannotLink:                                          "CANNOT_LINK",
+	ErrCodeSystemError:                                         "SYSTEM_ERROR",
+	ErrCodeCannotCompileRegexp:                                 "CANNOT_COMPILE_REGEXP",
+	ErrCodeFailedToGetpwuid:                                    "FAILED_TO_GETPWUID",
+	ErrCodeMismatchingUsersForProcessAndData:                   "MISMATCHING_USERS_FOR_PROCESS_AND_DATA",
+	ErrCodeIllegalSyntaxForCodecType:                           "ILLEGAL_SYNTAX_FOR_CODEC_TYPE",
+	ErrCodeUnknownCodec:                                        "UNKNOWN_CODEC",
+	ErrCodeIllegalCodecParameter:                               "ILLEGAL_CODEC_PARAMETER",
+	ErrCodeCannotParseProtobufSchema:                           "CANNOT_PARSE_PROTOBUF_SCHEMA",
+	ErrCodeNoColumnSerializedToRequiredProtobufField:           "NO_COLUMN_SERIALIZED_TO_REQUIRED_PROTOBUF_FIELD",
+	ErrCodeProtobufBadCast:                                     "PROTOBUF_BAD_CAST",
+	ErrCodeProtobufFieldNotRepeated:                            "PROTOBUF_FIELD_NOT_REPEATED",
+	ErrCodeDataTypeCannotBePromoted:                            "DATA_TYPE_CANNOT_BE_PROMOTED",
+	ErrCodeCannotScheduleTask:                                  "CANNOT_SCHEDULE_TASK",
+	ErrCodeInvalidLimitExpression:                              "INVALID_LIMIT_EXPRESSION",
+	ErrCodeCannotParseDomainValueFromString:                    "CANNOT_PARSE_DOMAIN_VALUE_FROM_STRING",
+	ErrCodeBadDatabaseForTemporaryTable:                        "BAD_DATABASE_FOR_TEMPORARY_TABLE",
+	ErrCodeNoColumnsSerializedToProtobufFields:                 "NO_COLUMNS_SERIALIZED_TO_PROTOBUF_FIELDS",
+	ErrCodeUnknownProtobufFormat:                               "UNKNOWN_PROTOBUF_FORMAT",
+	ErrCodeCannotMprotect:                                      "CANNOT_MPROTECT",
+	ErrCodeFunctionNotAllowed:                                  "FUNCTION_NOT_ALLOWED",
+	ErrCodeHyperscanCannotScanText:                             "HYPERSCAN_CANNOT_SCAN_TEXT",
+	ErrCodeBrotliReadFailed:                                    "BROTLI_READ_FAILED",
+	ErrCodeBrotliWriteFailed:                                   "BROTLI_WRITE_FAILED",
+	ErrCodeBadTtlExpression:                                    "BAD_TTL_EXPRESSION",
+	ErrCodeBadTtlFile:                                          "BAD_TTL_FILE",
+	ErrCodeSettingConstraintViolation:                          "SETTING_CONSTRAINT_VIOLATION",
+	ErrCodeMysqlClientInsufficientCapabilities:                 "MYSQL_CLIENT_INSUFFICIENT_CAPABILITIES",
+	ErrCodeOpensslError:                                        "OPENSSL_ERROR",
+	ErrCodeSuspiciousTypeForLowCardinality:                     "SUSPICIOUS_TYPE_FOR_LOW_CARDINALITY",
+	ErrCodeUnknownQueryParameter:                               "UNKNOWN_QUERY_PARAMETER",
+	ErrCodeBadQueryParameter:                                   "BAD_QUERY_PARAMETER",
+	ErrCodeCannotUnlink:                                        "CANNOT_UNLINK",
+	ErrCodeCannotSetThreadPriority:                             "CANNOT_SET_THREAD_PRIORITY",
+	ErrCodeCannotCreateTimer:                                   "CANNOT_CREATE_TIMER",
+	ErrCodeCannotSetTimerPeriod:                                "CANNOT_SET_TIMER_PERIOD",
+	ErrCodeCannotFcntl:                                         "CANNOT_FCNTL",
+	ErrCodeCannotParseElf:                                      "CANNOT_PARSE_ELF",
+	ErrCodeCannotParseDwarf:                                    "CANNOT_PARSE_DWARF",
+	ErrCodeInsecurePath:                                        "INSECURE_PATH",
+	ErrCodeCannotParseBool:                                     "CANNOT_PARSE_BOOL",
+	ErrCodeCannotPthreadAttr:                                   "CANNOT_PTHREAD_ATTR",
+	ErrCodeViolatedConstraint:                                  "VIOLATED_CONSTRAINT",
+	ErrCodeInvalidSettingValue:                                 "INVALID_SETTING_VALUE",
+	ErrCodeReadonlySetting:                                     "READONLY_SETTING",
+	ErrCodeDeadlockAvoided:                                     "DEADLOCK_AVOIDED",
+	ErrCodeInvalidTemplateFormat:                               "INVALID_TEMPLATE_FORMAT",
+	ErrCodeInvalidWithFillExpression:                           "INVALID_WITH_FILL_EXPRESSION",
+	ErrCodeWithTiesWithoutOrderBy:                              "WITH_TIES_WITHOUT_ORDER_BY",
+	ErrCodeInvalidUsageOfInput:                                 "INVALID_USAGE_OF_INPUT",
+	ErrCodeUnknownPolicy:                                       "UNKNOWN_POLICY",
+	ErrCodeUnknownDisk:                                         "UNKNOWN_DISK",
+	ErrCodeUnknownProtocol:                                     "UNKNOWN_PROTOCOL",
+	ErrCodePathAccessDenied:                                    "PATH_ACCESS_DENIED",
+	ErrCodeDictionaryAccessDenied:                              "DICTIONARY_ACCESS_DENIED",
+	ErrCodeTooManyRedirects:                                    "TOO_MANY_REDIRECTS",
+	ErrCodeInternalRedisError:                                  "INTERNAL_REDIS_ERROR",
+	ErrCodeCannotGetCreateDictionaryQuery:                      "CANNOT_GET_CREATE_DICTIONARY_QUERY",
+	ErrCodeIncorrectDictionaryDefinition:                       "INCORRECT_DICTIONARY_DEFINITION",
+	ErrCodeCannotFormatDatetime:                                "CANNOT_FORMAT_DATETIME",
+	ErrCodeUnacceptableUrl:                                     "UNACCEPTABLE_URL",
+	ErrCodeAccessEntityNotFound:                                "ACCESS_ENTITY_NOT_FOUND",
+	ErrCodeAccessEntityAlreadyExists:                           "ACCESS_ENTITY_ALREADY_EXISTS",
+	ErrCodeAccessStorageReadonly:                               "ACCESS_STORAGE_READONLY",
+	ErrCodeQuotaRequiresClientKey:                              "QUOTA_REQUIRES_CLIENT_KEY",
+	ErrCodeAccessDenied:                                        "ACCESS_DENIED",
+	ErrCodeLimitByWithTiesIsNotSupported:                       "LIMIT_BY_WITH_TIES_IS_NOT_SUPPORTED",
+	ErrCodeS3Error:                                             "S3_ERROR",
+	ErrCodeAzureBlobStorageError:                               "AZURE_BLOB_STORAGE_ERROR",
+	ErrCodeCannotCreateDatabase:                                "CANNOT_CREATE_DATABASE",
+	ErrCodeCannotSigqueue:                                      "CANNOT_SIGQUEUE",
+	ErrCodeAggregateFunctionThrow:                              "AGGREGATE_FUNCTION_THROW",
+	ErrCodeFileAlreadyExists:                                   "FILE_ALREADY_EXISTS",
+	ErrCodeUnableToSkipUnusedShards:                            "UNABLE_TO_SKIP_UNUSED_SHARDS",
+	ErrCodeUnknownAccessType:                                   "UNKNOWN_ACCESS_TYPE",
+	ErrCodeInvalidGrant:                                        "INVALID_GRANT",
+	ErrCodeCacheDictionaryUpdateFail:                           "CACHE_DICTIONARY_UPDATE_FAIL",
+	ErrCodeUnknownRole:                                         "UNKNOWN_ROLE",
+	ErrCodeSetNonGrantedRole:                                   "SET_NON_GRANTED_ROLE",
+	ErrCodeUnknownPartType:                                     "UNKNOWN_PART_TYPE",
+	ErrCodeAccessStorageForInsertionNotFound:                   "ACCESS_STORAGE_FOR_INSERTION_NOT_FOUND",
+	ErrCodeIncorrectAccessEntityDefinition:                     "INCORRECT_ACCESS_ENTITY_DEFINITION",
+	ErrCodeAuthenticationFailed:                                "AUTHENTICATION_FAILED",
+	ErrCodeCannotAssignAlter:                                   "CANNOT_ASSIGN_ALTER",
+	ErrCodeCannotCommitOffset:                                  "CANNOT_COMMIT_OFFSET",
+	ErrCodeNoRemoteShardAvailable:                              "NO_REMOTE_SHARD_AVAILABLE",
+	ErrCodeCannotDetachDictionaryAsTable:                       "CANNOT_DETACH_DICTIONARY_AS_TABLE",
+	ErrCodeAtomicRenameFail:                                    "ATOMIC_RENAME_FAIL",
+	ErrCodeUnknownRowPolicy:                                    "UNKNOWN_ROW_POLICY",
+	ErrCodeAlterOfColumnIsForbidden:                            "ALTER_OF_COLUMN_IS_FORBIDDEN",
+	ErrCodeIncorrectDiskIndex:                                  "INCORRECT_DISK_INDEX",
+	ErrCodeNoSuitableFunctionImplementation:                    "NO_SUITABLE_FUNCTION_IMPLEMENTATION",
+	ErrCodeCassandraInternalError:                              "CASSANDRA_INTERNAL_ERROR",
+	ErrCodeNotALeader:                                          "NOT_A_LEADER",
+	ErrCodeCannotConnectRabbitmq:                               "CANNOT_CONNECT_RABBITMQ",
+	ErrCodeCannotFstat:                                         "CANNOT_FSTAT",
+	ErrCodeLdapError:                                           "LDAP_ERROR",
+	ErrCodeUnknownRaidType:                                     "UNKNOWN_RAID_TYPE",
+	ErrCodeCannotRestoreFromFieldDump:                          "CANNOT_RESTORE_FROM_FIELD_DUMP",
+	ErrCodeIllegalMysqlVariable:                                "ILLEGAL_MYSQL_VARIABLE",
+	ErrCodeMysqlSyntaxError:                                    "MYSQL_SYNTAX_ERROR",
+	ErrCodeCannotBindRabbitmqExchange:                          "CANNOT_BIND_RABBITMQ_EXCHANGE",
+	ErrCodeCannotDeclareRabbitmqExchange:                       "CANNOT_DECLARE_RABBITMQ_EXCHANGE",
+	ErrCodeCannotCreateRabbitmqQueueBinding:                    "CANNOT_CREATE_RABBITMQ_QUEUE_BINDING",
+	ErrCodeCannotRemoveRabbitmqExchange:                        "CANNOT_REMOVE_RABBITMQ_EXCHANGE",
+	ErrCodeUnknownMysqlDatatypesSupportLevel:                   "UNKNOWN_MYSQL_DATATYPES_SUPPORT_LEVEL",
+	ErrCodeRowAndRowsTogether:                                  "ROW_AND_ROWS_TOGETHER",
+	ErrCodeFirstAndNextTogether:                                "FIRST_AND_NEXT_TOGETHER",
+	ErrCodeNoRowDelimiter:                                      "NO_ROW_DELIMITER",
+	ErrCodeInvalidRaidType:                                     "INVALID_RAID_TYPE",
+	ErrCodeUnknownVolume:                                       "UNKNOWN_VOLUME",
+	ErrCodeDataTypeCannotBeUsedInKey:                           "DATA_TYPE_CANNOT_BE_USED_IN_KEY",
+	ErrCodeUnrecognizedArguments:                               "UNRECOGNIZED_ARGUMENTS",
+	ErrCodeLzmaStreamEncoderFailed:                             "LZMA_STREAM_ENCODER_FAILED",
+	ErrCodeLzmaStreamDecoderFailed:                             "LZMA_STREAM_DECODER_FAILED",
+	ErrCodeRocksdbError:                                        "ROCKSDB_ERROR",
+	ErrCodeSyncMysqlUserAccessError:                            "SYNC_MYSQL_USER_ACCESS_ERROR",
+	ErrCodeUnknownUnion:                                        "UNKNOWN_UNION",
+	ErrCodeExpectedAllOrDistinct:                               "EXPECTED_ALL_OR_DISTINCT",
+	ErrCodeInvalidGrpcQueryInfo:                                "INVALID_GRPC_QUERY_INFO",
+	ErrCodeZstdEncoderFailed:                                   "ZSTD_ENCODER_FAILED",
+	ErrCodeZstdDecoderFailed:                                   "ZSTD_DECODER_FAILED",
+	ErrCodeTldListNotFound:                                     "TLD_LIST_NOT_FOUND",
+	ErrCodeCannotReadMapFromText:                               "CANNOT_READ_MAP_FROM_TEXT",
+	ErrCodeInterserverSchemeDoesntMatch:                        "INTERSERVER_SCHEME_DOESNT_MATCH",
+	ErrCodeTooManyPartitions:                                   "TOO_MANY_PARTITIONS",
+	ErrCodeCannotRmdir:                                         "CANNOT_RMDIR",
+	ErrCodeDuplicatedPartUuids:                                 "DUPLICATED_PART_UUIDS",
+	ErrCodeRaftError:                                           "RAFT_ERROR",
+	ErrCodeMultipleColumnsSerializedToSameProtobufField:        "MULTIPLE_COLUMNS_SERIALIZED_TO_SAME_PROTOBUF_FIELD",
+	ErrCodeDataTypeIncompatibleWithProtobufField:               "DATA_TYPE_INCOMPATIBLE_WITH_PROTOBUF_FIELD",
+	ErrCodeDatabaseReplicationFailed:                           "DATABASE_REPLICATION_FAILED",
+	ErrCodeTooManyQueryPlanOptimizations:                       "TOO_MANY_QUERY_PLAN_OPTIMIZATIONS",
+	ErrCodeEpollError:                                          "EPOLL_ERROR",
+	ErrCodeDistributedTooManyPendingBytes:                      "DISTRIBUTED_TOO_MANY_PENDING_BYTES",
+	ErrCodeUnknownSnapshot:                                     "UNKNOWN_SNAPSHOT",
+	ErrCodeKerberosError:                                       "KERBEROS_ERROR",
+	ErrCodeInvalidShardId:                                      "INVALID_SHARD_ID",
+	ErrCodeInvalidFormatInsertQueryWithData:                    "INVALID_FORMAT_INSERT_QUERY_WITH_DATA",
+	ErrCodeIncorrectPartType:                                   "INCORRECT_PART_TYPE",
+	ErrCodeCannotSetRoundingMode:                               "CANNOT_SET_ROUNDING_MODE",
+	ErrCodeTooLargeDistributedDepth:                            "TOO_LARGE_DISTRIBUTED_DEPTH",
+	ErrCodeNoSuchProjectionInTable:                             "NO_SUCH_PROJECTION_IN_TABLE",
+	ErrCodeIllegalProjection:                                   "ILLEGAL_PROJECTION",
+	ErrCodeProjectionNotUsed:                                   "PROJECTION_NOT_USED",
+	ErrCodeCannotParseYaml:                                     "CANNOT_PARSE_YAML",
+	ErrCodeCannotCreateFile:                                    "CANNOT_CREATE_FILE",
+	ErrCodeConcurrentAccessNotSupported:                        "CONCURRENT_ACCESS_NOT_SUPPORTED",
+	ErrCodeDistributedBrokenBatchInfo:                          "DISTRIBUTED_BROKEN_BATCH_INFO",
+	ErrCodeDistributedBrokenBatchFiles:                         "DISTRIBUTED_BROKEN_BATCH_FILES",
+	ErrCodeCannotSysconf:                                       "CANNOT_SYSCONF",
+	ErrCodeSqliteEngineError:                                   "SQLITE_ENGINE_ERROR",
+	ErrCodeDataEncryptionError:                                 "DATA_ENCRYPTION_ERROR",
+	ErrCodeZeroCopyReplicationError:                            "ZERO_COPY_REPLICATION_ERROR",
+	ErrCodeBzip2StreamDecoderFailed:                            "BZIP2_STREAM_DECODER_FAILED",
+	ErrCodeBzip2StreamEncoderFailed:                            "BZIP2_STREAM_ENCODER_FAILED",
+	ErrCodeIntersectOrExceptResultStructuresMismatch:           "INTERSECT_OR_EXCEPT_RESULT_STRUCTURES_MISMATCH",
+	ErrCodeNoSuchErrorCode:                                     "NO_SUCH_ERROR_CODE",
+	ErrCodeBackupAlreadyExists:                                 "BACKUP_ALREADY_EXISTS",
+	ErrCodeBackupNotFound:                                      "BACKUP_NOT_FOUND",
+	ErrCodeBackupVersionNotSupported:                           "BACKUP_VERSION_NOT_SUPPORTED",
+	ErrCodeBackupDamaged:                                       "BACKUP_DAMAGED",
+	ErrCodeNoBaseBackup:                                        "NO_BASE_BACKUP",
+	ErrCodeWrongBaseBackup:                                     "WRONG_BASE_BACKUP",
+	ErrCodeBackupEntryAlreadyExists:                            "BACKUP_ENTRY_ALREADY_EXISTS",
+	ErrCodeBackupEntryNotFound:                                 "BACKUP_ENTRY_NOT_FOUND",
+	ErrCodeBackupIsEmpty:                                       "BACKUP_IS_EMPTY",
+	ErrCodeCannotRestoreDatabase:                               "CANNOT_RESTORE_DATABASE",
+	ErrCodeCannotRestoreTable:                                  "CANNOT_RESTORE_TABLE",
+	ErrCodeFunctionAlreadyExists:                               "FUNCTION_ALREADY_EXISTS",
+	ErrCodeCannotDropFunction:                                  "CANNOT_DROP_FUNCTION",
+	ErrCodeCannotCreateRecursiveFunction:                       "CANNOT_CREATE_RECURSIVE_FUNCTION",
+	ErrCodePostgresqlConnectionFailure:                         "POSTGRESQL_CONNECTION_FAILURE",
+	ErrCodeCannotAdvise:                                        "CANNOT_ADVISE",
+	ErrCodeUnknownReadMethod:                                   "UNKNOWN_READ_METHOD",
+	ErrCodeLz4EncoderFailed:                                    "LZ4_ENCODER_FAILED",
+	ErrCodeLz4DecoderFailed:                                    "LZ4_DECODER_FAILED",
+	ErrCodePostgresqlReplicationInternalError:                  "POSTGRESQL_REPLICATION_INTERNAL_ERROR",
+	ErrCodeQueryNotAllowed:                                     "QUERY_NOT_ALLOWED",
+	ErrCodeCannotNormalizeString:                               "CANNOT_NORMALIZE_STRING",
+	ErrCodeCannotParseCapnProtoSchema:                          "CANNOT_PARSE_CAPN_PROTO_SCHEMA",
+	ErrCodeCapnProtoBadCast:                                    "CAPN_PROTO_BAD_CAST",
+	ErrCodeBadFileType:                                         "BAD_FILE_TYPE",
+	ErrCodeIoSetupError:                                        "IO_SETUP_ERROR",
+	ErrCodeCannotSkipUnknownField:                              "CANNOT_SKIP_UNKNOWN_FIELD",
+	ErrCodeBackupEngineNotFound:                                "BACKUP_ENGINE_NOT_FOUND",
+	ErrCodeOffsetFetchWithoutOrderBy:                           "OFFSET_FETCH_WITHOUT_ORDER_BY",
+	ErrCodeHttpRangeNotSatisfiable:                             "HTTP_RANGE_NOT_SATISFIABLE",
+	ErrCodeHaveDependentObjects:                                "HAVE_DEPENDENT_OBJECTS",
+	ErrCodeUnknownFileSize:                                     "UNKNOWN_FILE_SIZE",
+	ErrCodeUnexpectedDataAfterParsedValue:                      "UNEXPECTED_DATA_AFTER_PARSED_VALUE",
+	ErrCodeQueryIsNotSupportedInWindowView:                     "QUERY_IS_NOT_SUPPORTED_IN_WINDOW_VIEW",
+	ErrCodeMongodbError:                                        "MONGODB_ERROR",
+	ErrCodeCannotPoll:                                          "CANNOT_POLL",
+	ErrCodeCannotExtractTableStructure:                         "CANNOT_EXTRACT_TABLE_STRUCTURE",
+	ErrCodeInvalidTableOverride:                                "INVALID_TABLE_OVERRIDE",
+	ErrCodeSnappyUncompressFailed:                              "SNAPPY_UNCOMPRESS_FAILED",
+	ErrCodeSnappyCompressFailed:                                "SNAPPY_COMPRESS_FAILED",
+	ErrCodeNoHivemetastore:                                     "NO_HIVEMETASTORE",
+	ErrCodeCannotAppendToFile:                                  "CANNOT_APPEND_TO_FILE",
+	ErrCodeCannotPackArchive:                                   "CANNOT_PACK_ARCHIVE",
+	ErrCodeCannotUnpackArchive:                                 "CANNOT_UNPACK_ARCHIVE",
+	ErrCodeNumberOfDimensionsMismatched:                        "NUMBER_OF_DIMENSIONS_MISMATCHED",
+	ErrCodeCannotBackupTable:                                   "CANNOT_BACKUP_TABLE",
+	ErrCodeWrongDdlRenamingSettings:                            "WRONG_DDL_RENAMING_SETTINGS",
+	ErrCodeInvalidTransaction:                                  "INVALID_TRANSACTION",
+	ErrCodeSerializationError:                                  "SERIALIZATION_ERROR",
+	ErrCodeCapnProtoBadType:                                    "CAPN_PROTO_BAD_TYPE",
+	ErrCodeOnlyNullsWhileReadingSchema:                         "ONLY_NULLS_WHILE_READING_SCHEMA",
+	ErrCodeCannotParseBackupSettings:                           "CANNOT_PARSE_BACKUP_SETTINGS",
+	ErrCodeWrongBackupSettings:                                 "WRONG_BACKUP_SETTINGS",
+	ErrCodeFailedToSyncBackupOrRestore:                         "FAILED_TO_SYNC_BACKUP_OR_RESTORE",
+	ErrCodeUnknownStatusOfTransaction:                          "UNKNOWN_STATUS_OF_TRANSACTION",
+	ErrCodeHdfsError:                                           "HDFS_ERROR",
+	ErrCodeCannotSendSignal:                                    "CANNOT_SEND_SIGNAL",
+	ErrCodeFsMetadataError:                                     "FS_METADATA_ERROR",
+	ErrCodeInconsistentMetadataForBackup:                       "INCONSISTENT_METADATA_FOR_BACKUP",
+	ErrCodeAccessStorageDoesntAllowBackup:                      "ACCESS_STORAGE_DOESNT_ALLOW_BACKUP",
+	ErrCodeCannotConnectNats:                                   "CANNOT_CONNECT_NATS",
+	ErrCodeNotInitialized:                                      "NOT_INITIALIZED",
+	ErrCodeInvalidState:                                        "INVALID_STATE",
+	ErrCodeNamedCollectionDoesntExist:                          "NAMED_COLLECTION_DOESNT_EXIST",
+	ErrCodeNamedCollectionAlreadyExists:                        "NAMED_COLLECTION_ALREADY_EXISTS",
+	ErrCodeNamedCollectionIsImmutable:                          "NAMED_COLLECTION_IS_IMMUTABLE",
+	ErrCodeInvalidSchedulerNode:                                "INVALID_SCHEDULER_NODE",
+	ErrCodeResourceAccessDenied:                                "RESOURCE_ACCESS_DENIED",
+	ErrCodeResourceNotFound:                                    "RESOURCE_NOT_FOUND",
+	ErrCodeCannotParseIpv4:                                     "CANNOT_PARSE_IPV4",
+	ErrCodeCannotParseIpv6:                                     "CANNOT_PARSE_IPV6",
+	ErrCodeThreadWasCanceled:                                   "THREAD_WAS_CANCELED",
+	ErrCodeIoUringInitFailed:                                   "IO_URING_INIT_FAILED",
+	ErrCodeIoUringSubmitError:                                  "IO_URING_SUBMIT_ERROR",
+	ErrCodeMixedAccessParameterTypes:                           "MIXED_ACCESS_PARAMETER_TYPES",
+	ErrCodeUnknownElementOfEnum:                                "UNKNOWN_ELEMENT_OF_ENUM",
+	ErrCodeTooManyMutations:                                    "TOO_MANY_MUTATIONS",
+	ErrCodeAwsError:                                            "AWS_ERROR",
+	ErrCodeAsyncLoadCycle:                                      "ASYNC_LOAD_CYCLE",
+	ErrCodeAsyncLoadFailed:                                     "ASYNC_LOAD_FAILED",
+	ErrCodeAsyncLoadCanceled:                                   "ASYNC_LOAD_CANCELED",
+	ErrCodeCannotRestoreToNonencryptedDisk:                     "CANNOT_RESTORE_TO_NONENCRYPTED_DISK",
+	ErrCodeInvalidRedisStorageType:                             "INVALID_REDIS_STORAGE_TYPE",
+	ErrCodeInvalidRedisTableStructure:                          "INVALID_REDIS_TABLE_STRUCTURE",
+	ErrCodeUserSessionLimitExceeded:                            "USER_SESSION_LIMIT_EXCEEDED",
+	ErrCodeClusterDoesntExist:                                  "CLUSTER_DOESNT_EXIST",
+	ErrCodeClientInfoDoesNotMatch:                              "CLIENT_INFO_DOES_NOT_MATCH",
+	ErrCodeInvalidIdentifier:                                   "INVALID_IDENTIFIER",
+	ErrCodeQueryCacheUsedWithNondeterministicFunctions:         "QUERY_CACHE_USED_WITH_NONDETERMINISTIC_FUNCTIONS",
+	ErrCodeTableNotEmpty:                                       "TABLE_NOT_EMPTY",
+	ErrCodeLibsshError:                                         "LIBSSH_ERROR",
+	ErrCodeGcpError:                                            "GCP_ERROR",
+	ErrCodeIllegalStatistics:                                   "ILLEGAL_STATISTICS",
+	ErrCodeCannotGetReplicatedDatabaseSnapshot:                 "CANNOT_GET_REPLICATED_DATABASE_SNAPSHOT",
+	ErrCodeFaultInjected:                                       "FAULT_INJECTED",
+	ErrCodeFilecacheAccessDenied:                               "FILECACHE_ACCESS_DENIED",
+	ErrCodeTooManyMaterializedViews:                            "TOO_MANY_MATERIALIZED_VIEWS",
+	ErrCodeBrokenProjection:                                    "BROKEN_PROJECTION",
+	ErrCodeUnexpectedCluster:                                   "UNEXPECTED_CLUSTER",
+	ErrCodeCannotDetectFormat:                                  "CANNOT_DETECT_FORMAT",
+	ErrCodeCannotForgetPartition:                               "CANNOT_FORGET_PARTITION",
+	ErrCodeExperimentalFeatureError:                            "EXPERIMENTAL_FEATURE_ERROR",
+	ErrCodeTooSlowParsing:                                      "TOO_SLOW_PARSING",
+	ErrCodeQueryCacheUsedWithSystemTable:                       "QUERY_CACHE_USED_WITH_SYSTEM_TABLE",
+	ErrCodeUserExpired:                                         "USER_EXPIRED",
+	ErrCodeDeprecatedFunction:                                  "DEPRECATED_FUNCTION",
+	ErrCodeAsyncLoadWaitFailed:                                 "ASYNC_LOAD_WAIT_FAILED",
+	ErrCodeParquetException:                                    "PARQUET_EXCEPTION",
+	ErrCodeTooManyTables:                                       "TOO_MANY_TABLES",
+	ErrCodeTooManyDatabases:                                    "TOO_MANY_DATABASES",
+	ErrCodeUnexpectedHttpHeaders:                               "UNEXPECTED_HTTP_HEADERS",
+	ErrCodeUnexpectedTableEngine:                               "UNEXPECTED_TABLE_ENGINE",
+	ErrCodeUnexpectedDataType:                                  "UNEXPECTED_DATA_TYPE",
+	ErrCodeIllegalTimeSeriesTags:                               "ILLEGAL_TIME_SERIES_TAGS",
+	ErrCodeRefreshFailed:                                       "REFRESH_FAILED",
+	ErrCodeQueryCacheUsedWithNonThrowOverflowMode:              "QUERY_CACHE_USED_WITH_NON_THROW_OVERFLOW_MODE",
+	ErrCodeTableIsBeingRestarted:                               "TABLE_IS_BEING_RESTARTED",
+	ErrCodeCannotWriteAfterBufferCanceled:                      "CANNOT_WRITE_AFTER_BUFFER_CANCELED",
+	ErrCodeQueryWasCancelledByClient:                           "QUERY_WAS_CANCELLED_BY_CLIENT",
+	ErrCodeDatalakeDatabaseError:                               "DATALAKE_DATABASE_ERROR",
+	ErrCodeGoogleCloudError:                                    "GOOGLE_CLOUD_ERROR",
+	ErrCodePartIsLocked:                                        "PART_IS_LOCKED",
+	ErrCodeBuzzhouse:                                           "BUZZHOUSE",
+	ErrCodePotentiallyBrokenDataPart:                           "POTENTIALLY_BROKEN_DATA_PART",
+	ErrCodeTableUuidMismatch:                                   "TABLE_UUID_MISMATCH",
+	ErrCodeDeltaKernelError:                                    "DELTA_KERNEL_ERROR",
+	ErrCodeIcebergSpecificationViolation:                       "ICEBERG_SPECIFICATION_VIOLATION",
+	ErrCodeSessionIdEmpty:                                      "SESSION_ID_EMPTY",
+	ErrCodeServerOverloaded:                                    "SERVER_OVERLOADED",
+	ErrCodeDependenciesNotFound:                                "DEPENDENCIES_NOT_FOUND",
+	ErrCodeFilecacheCannotWriteThroughCacheWithConcurrentReads: "FILECACHE_CANNOT_WRITE_THROUGH_CACHE_WITH_CONCURRENT_READS",
+	ErrCodeDistributedCacheError:                               "DISTRIBUTED_CACHE_ERROR",
+	ErrCodeCannotUseDistributedCache:                           "CANNOT_USE_DISTRIBUTED_CACHE",
+	ErrCodeProtocolVersionMismatch:                             "PROTOCOL_VERSION_MISMATCH",
+	ErrCodeLicenseExpired:                                      "LICENSE_EXPIRED",
+	ErrCodeKeeperException:                                     "KEEPER_EXCEPTION",
+	ErrCodePocoException:                                       "POCO_EXCEPTION",
+	ErrCodeStdException:                                        "STD_EXCEPTION",
+	ErrCodeUnknownException:                                    "UNKNOWN_EXCEPTION",
+	ErrCodeSshException:                                        "SSH_EXCEPTION",
+	ErrCodeStartupScriptsError:                                 "STARTUP_SCRIPTS_ERROR",
+	ErrCodeConditionalTreeParentNotFound:                       "CONDITIONAL_TREE_PARENT_NOT_FOUND",
+	ErrCodeIllegalProjectionManipulator:                        "ILLEGAL_PROJECTION_MANIPULATOR",
+}
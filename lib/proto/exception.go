@@ -37,6 +37,25 @@ func (e *Exception) Error() string {
 	return fmt.Sprintf("code: %d, message: %s", e.Code, e.Message)
 }
 
+// Is reports whether the exception or one of its nested exceptions has the code of
+// an ErrorCode target. The nested ones are searched here and not through Unwrap,
+// errors.Is only follows a list of wrapped errors from Go 1.20.
+func (e *Exception) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	if !ok {
+		return false
+	}
+	if e.Code == int32(code) {
+		return true
+	}
+	for i := range e.Nested {
+		if e.Nested[i].Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Exception) Decode(decoder *binary.Decoder) (err error) {
 	var exceptions []Exception
 	for {
@@ -60,6 +79,34 @@ func (e *Exception) Decode(decoder *binary.Decoder) (err error) {
 	return nil
 }
 
+// Encode writes the exception and its nested exceptions the way Decode reads them
+func (e *Exception) Encode(encoder *binary.Encoder) error {
+	exceptions := append([]Exception{*e}, e.Nested...)
+	for i, ex := range exceptions {
+		ex.nested = i+1 < len(exceptions)
+		if err := ex.encode(encoder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Exception) encode(encoder *binary.Encoder) error {
+	if err := encoder.Int32(e.Code); err != nil {
+		return err
+	}
+	if err := encoder.String(e.Name); err != nil {
+		return err
+	}
+	if err := encoder.String(e.Message); err != nil {
+		return err
+	}
+	if err := encoder.String(e.StackTrace); err != nil {
+		return err
+	}
+	return encoder.Bool(e.nested)
+}
+
 func (e *Exception) decode(decoder *binary.Decoder) (err error) {
 	if e.Code, err = decoder.Int32(); err != nil {
 		return err
@@ -0,0 +1,75 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExceptionIs(t *testing.T) {
+	ex := &Exception{
+		Code:    int32(ErrCodeQueryWasCancelled),
+		Message: "query was cancelled",
+		Nested: []Exception{
+			{Code: int32(ErrCodeUnknownTable), Message: "table default.t doesn't exist"},
+		},
+	}
+	err := fmt.Errorf("query: %w", ex)
+	assert.True(t, errors.Is(err, ErrCodeQueryWasCancelled))
+	assert.True(t, errors.Is(err, ErrCodeUnknownTable))
+	assert.False(t, errors.Is(err, ErrCodeSyntaxError))
+	ex.Nested[0].Nested = []Exception{{Code: int32(ErrCodeSyntaxError)}}
+	assert.True(t, errors.Is(err, ErrCodeSyntaxError), "nested twice")
+	assert.False(t, errors.Is(err, errors.New("code: 60")))
+}
+
+func TestErrorCodeString(t *testing.T) {
+	assert.Equal(t, "UNKNOWN_TABLE", ErrCodeUnknownTable.String())
+	assert.Equal(t, "code: 60, name: UNKNOWN_TABLE", ErrCodeUnknownTable.Error())
+	assert.Equal(t, "UNKNOWN_ERROR_CODE_-1", ErrorCode(-1).String())
+}
+
+func TestExceptionEncode(t *testing.T) {
+	var (
+		_, encoder, decoder = newCodec()
+		expected            = Exception{
+			Code:       int32(ErrCodeQueryWasCancelled),
+			Name:       "DB::Exception",
+			Message:    "query was cancelled",
+			StackTrace: "trace",
+			Nested: []Exception{
+				{Code: int32(ErrCodeUnknownTable), Name: "DB::Exception", Message: "table default.t doesn't exist"},
+			},
+		}
+		actual Exception
+	)
+	require.NoError(t, expected.Encode(encoder))
+	require.NoError(t, actual.Decode(decoder))
+	assert.Equal(t, expected.Code, actual.Code)
+	assert.Equal(t, expected.Message, actual.Message)
+	assert.Equal(t, expected.StackTrace, actual.StackTrace)
+	if assert.Len(t, actual.Nested, 1) {
+		assert.Equal(t, expected.Nested[0].Code, actual.Nested[0].Code)
+		assert.Equal(t, expected.Nested[0].Message, actual.Nested[0].Message)
+	}
+}
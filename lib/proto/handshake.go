@@ -52,6 +52,12 @@ func (ClientHandshake) String() string {
 	return fmt.Sprintf("%s %d.%d.%d", ClientName, ClientVersionMajor, ClientVersionMinor, ClientTCPProtocolVersion)
 }
 
+// SSHChallengeMessage is the message the client signs in answer to the SSH challenge of the server,
+// revision is the one of the client hello
+func SSHChallengeMessage(revision uint64, database, username, challenge string) []byte {
+	return []byte(fmt.Sprintf("%d%s%s%s", revision, database, username, challenge))
+}
+
 type ServerHandshake struct {
 	Name        string
 	DisplayName string
@@ -98,6 +104,76 @@ func (srv *ServerHandshake) Decode(decoder *binary.Decoder) (err error) {
 	} else {
 		srv.Version.Patch = srv.Revision
 	}
+	if srv.revision() >= DBMS_MIN_PROTOCOL_VERSION_WITH_PASSWORD_COMPLEXITY_RULES {
+		// the rules of the passwords set by CREATE USER, checked by the server anyway
+		rules, err := decoder.Uvarint()
+		if err != nil {
+			return fmt.Errorf("could not read password complexity rules: %v", err)
+		}
+		for i := 0; i < int(2*rules); i++ { // pattern and message
+			if _, err := decoder.String(); err != nil {
+				return fmt.Errorf("could not read password complexity rules: %v", err)
+			}
+		}
+	}
+	if srv.revision() >= DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET_V2 {
+		// the nonce of the interserver secret, the secret is only sent by the servers
+		if _, err := decoder.UInt64(); err != nil {
+			return fmt.Errorf("could not read server nonce: %v", err)
+		}
+	}
+	return nil
+}
+
+// revision is the revision of the fields the server adds to the hello, the lowest of the server and the client ones
+func (srv *ServerHandshake) revision() uint64 {
+	if srv.Revision > ClientTCPProtocolVersion {
+		return ClientTCPProtocolVersion
+	}
+	return srv.Revision
+}
+
+// Encode writes the server hello the way Decode reads it, for the servers of tests
+func (srv *ServerHandshake) Encode(encoder *binary.Encoder) error {
+	if err := encoder.String(srv.Name); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(srv.Version.Major); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(srv.Version.Minor); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(srv.Revision); err != nil {
+		return err
+	}
+	if srv.Revision >= DBMS_MIN_REVISION_WITH_SERVER_TIMEZONE {
+		name := "UTC"
+		if srv.Timezone != nil {
+			name = srv.Timezone.String()
+		}
+		if err := encoder.String(name); err != nil {
+			return err
+		}
+	}
+	if srv.Revision >= DBMS_MIN_REVISION_WITH_SERVER_DISPLAY_NAME {
+		if err := encoder.String(srv.DisplayName); err != nil {
+			return err
+		}
+	}
+	if srv.Revision >= DBMS_MIN_REVISION_WITH_VERSION_PATCH {
+		if err := encoder.Uvarint(srv.Version.Patch); err != nil {
+			return err
+		}
+	}
+	if srv.revision() >= DBMS_MIN_PROTOCOL_VERSION_WITH_PASSWORD_COMPLEXITY_RULES {
+		if err := encoder.Uvarint(0); err != nil {
+			return err
+		}
+	}
+	if srv.revision() >= DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET_V2 {
+		return encoder.UInt64(0)
+	}
 	return nil
 }
 
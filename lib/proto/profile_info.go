@@ -54,6 +54,25 @@ func (p *ProfileInfo) Decode(decoder *binary.Decoder, revision uint64) (err erro
 	return nil
 }
 
+func (p *ProfileInfo) Encode(encoder *binary.Encoder, revision uint64) error {
+	if err := encoder.Uvarint(p.Rows); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(p.Blocks); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(p.Bytes); err != nil {
+		return err
+	}
+	if err := encoder.Bool(p.AppliedLimit); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(p.RowsBeforeLimit); err != nil {
+		return err
+	}
+	return encoder.Bool(p.CalculatedRowsBeforeLimit)
+}
+
 func (p *ProfileInfo) String() string {
 	return fmt.Sprintf("rows=%d, bytes=%d, blocks=%d, rows before limit=%d, applied limit=%t, calculated rows before limit=%t",
 		p.Rows,
@@ -19,6 +19,7 @@ package proto
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/supresu/clickhouse-go/v2/lib/binary"
 )
@@ -27,8 +28,10 @@ type Progress struct {
 	Rows       uint64
 	Bytes      uint64
 	TotalRows  uint64
+	TotalBytes uint64
 	WroteRows  uint64
 	WroteBytes uint64
+	Elapsed    time.Duration // the server time of the query since the previous progress
 	withClient bool
 }
 
@@ -42,6 +45,11 @@ func (p *Progress) Decode(decoder *binary.Decoder, revision uint64) (err error)
 	if p.TotalRows, err = decoder.Uvarint(); err != nil {
 		return err
 	}
+	if revision >= DBMS_MIN_PROTOCOL_VERSION_WITH_TOTAL_BYTES_IN_PROGRESS {
+		if p.TotalBytes, err = decoder.Uvarint(); err != nil {
+			return err
+		}
+	}
 	if revision >= DBMS_MIN_REVISION_WITH_CLIENT_WRITE_INFO {
 		p.withClient = true
 		if p.WroteRows, err = decoder.Uvarint(); err != nil {
@@ -51,6 +59,42 @@ func (p *Progress) Decode(decoder *binary.Decoder, revision uint64) (err error)
 			return err
 		}
 	}
+	if revision >= DBMS_MIN_PROTOCOL_VERSION_WITH_SERVER_QUERY_TIME_IN_PROGRESS {
+		elapsed, err := decoder.Uvarint()
+		if err != nil {
+			return err
+		}
+		p.Elapsed = time.Duration(elapsed)
+	}
+	return nil
+}
+
+func (p *Progress) Encode(encoder *binary.Encoder, revision uint64) error {
+	if err := encoder.Uvarint(p.Rows); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(p.Bytes); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(p.TotalRows); err != nil {
+		return err
+	}
+	if revision >= DBMS_MIN_PROTOCOL_VERSION_WITH_TOTAL_BYTES_IN_PROGRESS {
+		if err := encoder.Uvarint(p.TotalBytes); err != nil {
+			return err
+		}
+	}
+	if revision >= DBMS_MIN_REVISION_WITH_CLIENT_WRITE_INFO {
+		if err := encoder.Uvarint(p.WroteRows); err != nil {
+			return err
+		}
+		if err := encoder.Uvarint(p.WroteBytes); err != nil {
+			return err
+		}
+	}
+	if revision >= DBMS_MIN_PROTOCOL_VERSION_WITH_SERVER_QUERY_TIME_IN_PROGRESS {
+		return encoder.Uvarint(uint64(p.Elapsed))
+	}
 	return nil
 }
 
@@ -58,11 +102,13 @@ func (p *Progress) String() string {
 	if !p.withClient {
 		return fmt.Sprintf("rows=%d, bytes=%d, total rows=%d", p.Rows, p.Bytes, p.TotalRows)
 	}
-	return fmt.Sprintf("rows=%d, bytes=%d, total rows=%d, wrote rows=%d wrote bytes=%d",
+	return fmt.Sprintf("rows=%d, bytes=%d, total rows=%d, total bytes=%d, wrote rows=%d wrote bytes=%d, elapsed=%s",
 		p.Rows,
 		p.Bytes,
 		p.TotalRows,
+		p.TotalBytes,
 		p.WroteRows,
 		p.WroteBytes,
+		p.Elapsed,
 	)
 }
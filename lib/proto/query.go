@@ -21,6 +21,7 @@ import (
 	stdbin "encoding/binary"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/supresu/clickhouse-go/v2/lib/binary"
 	"go.opentelemetry.io/otel/trace"
@@ -37,6 +38,7 @@ type Query struct {
 	Body           string
 	QuotaKey       string
 	Settings       Settings
+	Parameters     Parameters
 	Compression    bool
 	InitialUser    string
 	InitialAddress string
@@ -63,7 +65,151 @@ func (q *Query) Encode(encoder *binary.Encoder, revision uint64) error {
 		encoder.Byte(StateComplete)
 		encoder.Bool(q.Compression)
 	}
-	return encoder.String(q.Body)
+	if err := encoder.String(q.Body); err != nil {
+		return err
+	}
+	if revision >= DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS {
+		if err := q.Parameters.Encode(encoder); err != nil {
+			return err
+		}
+		return encoder.String("" /* end of parameters */)
+	}
+	return nil
+}
+
+// Decode reads a query the way the server does (TCPHandler::receiveQuery), for the servers of tests.
+// The settings are read as strings, or as numbers before DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS.
+func (q *Query) Decode(decoder *binary.Decoder, revision uint64) (err error) {
+	if q.ID, err = decoder.String(); err != nil {
+		return err
+	}
+	if err := q.decodeClientInfo(decoder, revision); err != nil {
+		return err
+	}
+	if q.Settings, err = decodeSettings(decoder, revision); err != nil {
+		return err
+	}
+	if revision >= DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET {
+		if _, err := decoder.String(); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.ReadByte(); err != nil { // stage
+		return err
+	}
+	if q.Compression, err = decoder.Bool(); err != nil {
+		return err
+	}
+	if q.Body, err = decoder.String(); err != nil {
+		return err
+	}
+	if revision >= DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS {
+		params, err := decodeSettings(decoder, revision)
+		if err != nil {
+			return err
+		}
+		for _, p := range params {
+			value, err := unquote(p.Value.(string))
+			if err != nil {
+				return fmt.Errorf("invalid parameter %s: %w", p.Key, err)
+			}
+			q.Parameters = append(q.Parameters, Parameter{Key: p.Key, Value: value})
+		}
+	}
+	return nil
+}
+
+func (q *Query) decodeClientInfo(decoder *binary.Decoder, revision uint64) (err error) {
+	kind, err := decoder.ReadByte()
+	if err != nil || kind == ClientQueryNone {
+		return err
+	}
+	if q.InitialUser, err = decoder.String(); err != nil {
+		return err
+	}
+	if _, err := decoder.String(); err != nil { // initial_query_id
+		return err
+	}
+	if q.InitialAddress, err = decoder.String(); err != nil {
+		return err
+	}
+	if revision >= DBMS_MIN_PROTOCOL_VERSION_WITH_INITIAL_QUERY_START_TIME {
+		if _, err := decoder.Int64(); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.ReadByte(); err != nil { // interface
+		return err
+	}
+	for i := 0; i < 3; i++ { // os_user, client_hostname, client_name
+		if _, err := decoder.String(); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < 3; i++ { // client version major, minor and protocol
+		if _, err := decoder.Uvarint(); err != nil {
+			return err
+		}
+	}
+	if revision >= DBMS_MIN_REVISION_WITH_QUOTA_KEY_IN_CLIENT_INFO {
+		if q.QuotaKey, err = decoder.String(); err != nil {
+			return err
+		}
+	}
+	if revision >= DBMS_MIN_PROTOCOL_VERSION_WITH_DISTRIBUTED_DEPTH {
+		if _, err := decoder.Uvarint(); err != nil {
+			return err
+		}
+	}
+	if revision >= DBMS_MIN_REVISION_WITH_VERSION_PATCH {
+		if _, err := decoder.Uvarint(); err != nil {
+			return err
+		}
+	}
+	if revision >= DBMS_MIN_REVISION_WITH_OPENTELEMETRY {
+		traced, err := decoder.Bool()
+		if err != nil {
+			return err
+		}
+		if traced {
+			var (
+				traceID trace.TraceID
+				spanID  trace.SpanID
+			)
+			if err := decoder.Raw(traceID[:]); err != nil {
+				return err
+			}
+			if err := decoder.Raw(spanID[:]); err != nil {
+				return err
+			}
+			swap64(traceID[:])
+			swap64(spanID[:])
+			state, err := decoder.String()
+			if err != nil {
+				return err
+			}
+			flags, err := decoder.ReadByte()
+			if err != nil {
+				return err
+			}
+			traceState, _ := trace.ParseTraceState(state)
+			q.Span = trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceState: traceState,
+				TraceFlags: trace.TraceFlags(flags),
+				Remote:     true,
+			})
+		}
+	}
+	if revision >= DBMS_MIN_REVISION_WITH_PARALLEL_REPLICAS {
+		for i := 0; i < 3; i++ {
+			if _, err := decoder.Uvarint(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func swap64(b []byte) {
@@ -131,6 +277,12 @@ func (q *Query) encodeClientInfo(encoder *binary.Encoder, revision uint64) error
 
 type Settings []Setting
 
+// flags of the settings serialized as strings, see BaseSettingsHelpers::Flags
+const (
+	settingFlagImportant = 0x01
+	settingFlagCustom    = 0x02
+)
+
 type Setting struct {
 	Key   string
 	Value interface{}
@@ -149,7 +301,7 @@ func (s *Setting) encode(encoder *binary.Encoder, revision uint64) error {
 	if err := encoder.String(s.Key); err != nil {
 		return err
 	}
-	if revision <= DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS {
+	if revision < DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS {
 		var value uint64
 		switch v := s.Value.(type) {
 		case int:
@@ -163,8 +315,65 @@ func (s *Setting) encode(encoder *binary.Encoder, revision uint64) error {
 		}
 		return encoder.Uvarint(value)
 	}
-	if err := encoder.Bool(true); err != nil { // is_important
+	if err := encoder.Uvarint(settingFlagImportant); err != nil {
 		return err
 	}
 	return encoder.String(fmt.Sprint(s.Value))
 }
+
+// decodeSettings reads the settings until the empty name that ends them
+func decodeSettings(decoder *binary.Decoder, revision uint64) (settings Settings, err error) {
+	for {
+		key, err := decoder.String()
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return settings, nil
+		}
+		if revision < DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS {
+			value, err := decoder.Uvarint()
+			if err != nil {
+				return nil, err
+			}
+			settings = append(settings, Setting{Key: key, Value: int(value)})
+			continue
+		}
+		if _, err := decoder.Uvarint(); err != nil { // flags
+			return nil, err
+		}
+		value, err := decoder.String()
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, Setting{Key: key, Value: value})
+	}
+}
+
+// Parameters are the values of the {name:Type} placeholders in the ClickHouse text format of their type
+type Parameters []Parameter
+
+type Parameter struct {
+	Key   string
+	Value string
+}
+
+func (p Parameters) Encode(encoder *binary.Encoder) error {
+	for _, p := range p {
+		if err := p.encode(encoder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encode writes the parameter as a custom setting, the value is the dump of a String field
+func (p *Parameter) encode(encoder *binary.Encoder) error {
+	if err := encoder.String(p.Key); err != nil {
+		return err
+	}
+	if err := encoder.Uvarint(settingFlagCustom); err != nil {
+		return err
+	}
+	return encoder.String("'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(p.Value) + "'")
+}
@@ -0,0 +1,402 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2/lib/binary"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// revisions covers every protocol revision the encoders and decoders are gated on, plus the neighbours of each gate
+var revisions = func() []uint64 {
+	var (
+		gates = []uint64{
+			DBMS_MIN_REVISION_WITH_CLIENT_INFO,
+			DBMS_MIN_REVISION_WITH_SERVER_TIMEZONE,
+			DBMS_MIN_REVISION_WITH_QUOTA_KEY_IN_CLIENT_INFO,
+			DBMS_MIN_REVISION_WITH_SERVER_DISPLAY_NAME,
+			DBMS_MIN_REVISION_WITH_VERSION_PATCH,
+			DBMS_MIN_REVISION_WITH_CLIENT_WRITE_INFO,
+			DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS,
+			DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET,
+			DBMS_MIN_REVISION_WITH_OPENTELEMETRY,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_DISTRIBUTED_DEPTH,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_INITIAL_QUERY_START_TIME,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_INCREMENTAL_PROFILE_EVENTS,
+			DBMS_MIN_REVISION_WITH_PARALLEL_REPLICAS,
+			DBMS_MIN_REVISION_WITH_CUSTOM_SERIALIZATION,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_ADDENDUM,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_SERVER_QUERY_TIME_IN_PROGRESS,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_PASSWORD_COMPLEXITY_RULES,
+			DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET_V2,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_TOTAL_BYTES_IN_PROGRESS,
+			DBMS_MIN_PROTOCOL_VERSION_WITH_TIMEZONE_UPDATES,
+			DBMS_MIN_REVISION_WITH_SPARSE_SERIALIZATION,
+			DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION,
+		}
+		seen   = make(map[uint64]bool)
+		result []uint64
+	)
+	for _, gate := range gates {
+		for _, rev := range []uint64{gate - 1, gate, gate + 1} {
+			if !seen[rev] && rev <= DBMS_TCP_PROTOCOL_VERSION {
+				seen[rev], result = true, append(result, rev)
+			}
+		}
+	}
+	return result
+}()
+
+func newCodec() (*bytes.Buffer, *binary.Encoder, *binary.Decoder) {
+	var buf bytes.Buffer
+	return &buf, binary.NewEncoder(&buf), binary.NewDecoder(&buf)
+}
+
+func TestProgressRevisions(t *testing.T) {
+	for _, rev := range revisions {
+		t.Run(fmt.Sprint(rev), func(t *testing.T) {
+			var (
+				buf, encoder, decoder = newCodec()
+				expected              = Progress{Rows: 1, Bytes: 2, TotalRows: 3, TotalBytes: 6, WroteRows: 4, WroteBytes: 5, Elapsed: 7}
+				actual                Progress
+			)
+			require.NoError(t, expected.Encode(encoder, rev))
+			require.NoError(t, actual.Decode(decoder, rev))
+			assert.Zero(t, buf.Len())
+			assert.Equal(t, expected.Rows, actual.Rows)
+			assert.Equal(t, expected.Bytes, actual.Bytes)
+			assert.Equal(t, expected.TotalRows, actual.TotalRows)
+			if rev >= DBMS_MIN_REVISION_WITH_CLIENT_WRITE_INFO {
+				assert.Equal(t, expected.WroteRows, actual.WroteRows)
+				assert.Equal(t, expected.WroteBytes, actual.WroteBytes)
+			} else {
+				assert.Zero(t, actual.WroteRows)
+				assert.Zero(t, actual.WroteBytes)
+			}
+			if rev >= DBMS_MIN_PROTOCOL_VERSION_WITH_TOTAL_BYTES_IN_PROGRESS {
+				assert.Equal(t, expected.TotalBytes, actual.TotalBytes)
+			} else {
+				assert.Zero(t, actual.TotalBytes)
+			}
+			if rev >= DBMS_MIN_PROTOCOL_VERSION_WITH_SERVER_QUERY_TIME_IN_PROGRESS {
+				assert.Equal(t, expected.Elapsed, actual.Elapsed)
+			} else {
+				assert.Zero(t, actual.Elapsed)
+			}
+		})
+	}
+}
+
+func TestProfileInfoRevisions(t *testing.T) {
+	for _, rev := range revisions {
+		t.Run(fmt.Sprint(rev), func(t *testing.T) {
+			var (
+				buf, encoder, decoder = newCodec()
+				expected              = ProfileInfo{
+					Rows:                      1,
+					Bytes:                     2,
+					Blocks:                    3,
+					AppliedLimit:              true,
+					RowsBeforeLimit:           4,
+					CalculatedRowsBeforeLimit: true,
+				}
+				actual ProfileInfo
+			)
+			require.NoError(t, expected.Encode(encoder, rev))
+			require.NoError(t, actual.Decode(decoder, rev))
+			assert.Zero(t, buf.Len())
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestBlockRevisions(t *testing.T) {
+	for _, rev := range append([]uint64{0}, revisions...) {
+		t.Run(fmt.Sprint(rev), func(t *testing.T) {
+			var (
+				buf, encoder, decoder = newCodec()
+				block                 Block
+			)
+			require.NoError(t, block.AddColumn("id", "UInt64"))
+			require.NoError(t, block.AddColumn("name", "String"))
+			for i := 0; i < 3; i++ {
+				require.NoError(t, block.Append(uint64(i), fmt.Sprintf("name_%d", i)))
+			}
+			require.NoError(t, block.Encode(encoder, rev))
+			var actual Block
+			require.NoError(t, actual.Decode(decoder, rev))
+			assert.Zero(t, buf.Len())
+			if assert.Equal(t, 3, actual.Rows()) {
+				assert.Equal(t, []string{"id", "name"}, actual.ColumnsNames())
+				var name string
+				require.NoError(t, actual.Columns[1].ScanRow(&name, 2))
+				assert.Equal(t, "name_2", name)
+			}
+		})
+	}
+}
+
+// decodeQuery reads a query packet the way the server does, see TCPHandler::receiveQuery and ClientInfo::read
+func decodeQuery(t *testing.T, decoder *binary.Decoder, rev uint64) (id string, settings map[string]string, body string, params map[string]string) {
+	str := func() string {
+		v, err := decoder.String()
+		require.NoError(t, err)
+		return v
+	}
+	uvarint := func() uint64 {
+		v, err := decoder.Uvarint()
+		require.NoError(t, err)
+		return v
+	}
+	readByte := func() byte {
+		v, err := decoder.ReadByte()
+		require.NoError(t, err)
+		return v
+	}
+	id = str()
+	{
+		require.Equal(t, readByte(), uint8(ClientQueryInitial))
+		str() // initial_user
+		str() // initial_query_id
+		str() // initial_address
+		if rev >= DBMS_MIN_PROTOCOL_VERSION_WITH_INITIAL_QUERY_START_TIME {
+			_, err := decoder.Int64()
+			require.NoError(t, err)
+		}
+		require.Equal(t, readByte(), uint8(1)) // interface: TCP
+		str()                                  // os_user
+		str()                                  // client_hostname
+		assert.Equal(t, ClientName, str())
+		assert.Equal(t, uint64(ClientVersionMajor), uvarint())
+		assert.Equal(t, uint64(ClientVersionMinor), uvarint())
+		assert.Equal(t, uint64(ClientTCPProtocolVersion), uvarint())
+		if rev >= DBMS_MIN_REVISION_WITH_QUOTA_KEY_IN_CLIENT_INFO {
+			assert.Equal(t, "quota", str())
+		}
+		if rev >= DBMS_MIN_PROTOCOL_VERSION_WITH_DISTRIBUTED_DEPTH {
+			uvarint()
+		}
+		if rev >= DBMS_MIN_REVISION_WITH_VERSION_PATCH {
+			uvarint()
+		}
+		if rev >= DBMS_MIN_REVISION_WITH_OPENTELEMETRY {
+			if readByte() == 1 {
+				require.NoError(t, decoder.Raw(make([]byte, 16+8)))
+				str()      // tracestate
+				readByte() // trace_flags
+			}
+		}
+		if rev >= DBMS_MIN_REVISION_WITH_PARALLEL_REPLICAS {
+			uvarint()
+			uvarint()
+			uvarint()
+		}
+	}
+	settings = make(map[string]string)
+	for {
+		key := str()
+		if len(key) == 0 {
+			break
+		}
+		switch {
+		case rev >= DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS:
+			assert.Equal(t, uint64(settingFlagImportant), uvarint())
+			settings[key] = str()
+		default:
+			settings[key] = fmt.Sprint(uvarint())
+		}
+	}
+	if rev >= DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET {
+		str()
+	}
+	assert.Equal(t, uint8(StateComplete), readByte())
+	readByte() // compression
+	body = str()
+	params = make(map[string]string)
+	if rev >= DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS {
+		for {
+			key := str()
+			if len(key) == 0 {
+				break
+			}
+			assert.Equal(t, uint64(settingFlagCustom), uvarint())
+			params[key] = str()
+		}
+	}
+	return id, settings, body, params
+}
+
+func TestQueryRevisions(t *testing.T) {
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+	for _, rev := range revisions {
+		t.Run(fmt.Sprint(rev), func(t *testing.T) {
+			var (
+				buf, encoder, decoder = newCodec()
+				query                 = Query{
+					ID:       "query_id",
+					Span:     span,
+					Body:     "SELECT {n:UInt8}, {s:String}",
+					QuotaKey: "quota",
+					Settings: Settings{
+						{Key: "max_block_size", Value: 10},
+						{Key: "extremes", Value: true},
+					},
+					Parameters: Parameters{
+						{Key: "n", Value: "1"},
+						{Key: "s", Value: `it's a \\ back\\tslash`},
+					},
+				}
+			)
+			require.NoError(t, query.Encode(encoder, rev))
+			id, settings, body, params := decodeQuery(t, decoder, rev)
+			assert.Zero(t, buf.Len())
+			assert.Equal(t, "query_id", id)
+			assert.Equal(t, "SELECT {n:UInt8}, {s:String}", body)
+			if rev >= DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS {
+				// the values are String fields dumps
+				assert.Equal(t, map[string]string{"n": "'1'", "s": `'it\'s a \\\\ back\\\\tslash'`}, params)
+			} else {
+				assert.Empty(t, params)
+			}
+			assert.Equal(t, "10", settings["max_block_size"])
+			switch {
+			case rev >= DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS:
+				assert.Equal(t, "true", settings["extremes"])
+			default:
+				assert.Equal(t, "1", settings["extremes"])
+			}
+		})
+	}
+}
+
+func TestQueryDecodeRevisions(t *testing.T) {
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+	for _, rev := range revisions {
+		t.Run(fmt.Sprint(rev), func(t *testing.T) {
+			var (
+				buf, encoder, decoder = newCodec()
+				query                 = Query{
+					ID:          "query_id",
+					Span:        span,
+					Body:        "SELECT {s:String}",
+					QuotaKey:    "quota",
+					Compression: true,
+					Settings:    Settings{{Key: "max_block_size", Value: 10}},
+					Parameters:  Parameters{{Key: "s", Value: `it's a \\ back\\tslash`}},
+				}
+				actual Query
+			)
+			require.NoError(t, query.Encode(encoder, rev))
+			require.NoError(t, actual.Decode(decoder, rev))
+			assert.Zero(t, buf.Len())
+			assert.Equal(t, query.ID, actual.ID)
+			assert.Equal(t, query.Body, actual.Body)
+			assert.True(t, actual.Compression)
+			if assert.Len(t, actual.Settings, 1) {
+				assert.Equal(t, "max_block_size", actual.Settings[0].Key)
+				assert.Equal(t, "10", fmt.Sprint(actual.Settings[0].Value))
+			}
+			if rev >= DBMS_MIN_REVISION_WITH_QUOTA_KEY_IN_CLIENT_INFO {
+				assert.Equal(t, "quota", actual.QuotaKey)
+			}
+			if rev >= DBMS_MIN_REVISION_WITH_OPENTELEMETRY {
+				assert.Equal(t, span.TraceID(), actual.Span.TraceID())
+				assert.Equal(t, span.SpanID(), actual.Span.SpanID())
+			}
+			if rev >= DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS {
+				assert.Equal(t, query.Parameters, actual.Parameters)
+			} else {
+				assert.Empty(t, actual.Parameters)
+			}
+		})
+	}
+}
+
+func TestServerHandshakeRevisions(t *testing.T) {
+	for _, rev := range revisions {
+		t.Run(fmt.Sprint(rev), func(t *testing.T) {
+			var (
+				buf, encoder, decoder = newCodec()
+				expected              = ServerHandshake{Name: "ClickHouse", DisplayName: "test", Revision: rev}
+				actual                ServerHandshake
+			)
+			expected.Version.Major, expected.Version.Minor, expected.Version.Patch = 22, 3, 1
+			require.NoError(t, expected.Encode(encoder))
+			require.NoError(t, actual.Decode(decoder))
+			assert.Zero(t, buf.Len())
+			assert.Equal(t, expected.Version.Major, actual.Version.Major)
+			assert.Equal(t, expected.Version.Minor, actual.Version.Minor)
+			assert.Equal(t, rev, actual.Revision)
+			if rev >= DBMS_MIN_REVISION_WITH_SERVER_TIMEZONE {
+				assert.Equal(t, "UTC", actual.Timezone.String())
+			}
+			if rev >= DBMS_MIN_REVISION_WITH_SERVER_DISPLAY_NAME {
+				assert.Equal(t, "test", actual.DisplayName)
+			}
+			if rev >= DBMS_MIN_REVISION_WITH_VERSION_PATCH {
+				assert.Equal(t, uint64(1), actual.Version.Patch)
+			}
+		})
+	}
+}
+
+func TestServerHandshakeNewer(t *testing.T) {
+	// a newer server sends the fields of the client revision, see TCPHandler::sendHello
+	buf, encoder, decoder := newCodec()
+	require.NoError(t, encoder.String("ClickHouse"))
+	require.NoError(t, encoder.Uvarint(24))
+	require.NoError(t, encoder.Uvarint(8))
+	require.NoError(t, encoder.Uvarint(DBMS_TCP_PROTOCOL_VERSION+10))
+	require.NoError(t, encoder.String("Europe/Amsterdam"))
+	require.NoError(t, encoder.String("server"))
+	require.NoError(t, encoder.Uvarint(2))
+	require.NoError(t, encoder.Uvarint(1)) // password complexity rules
+	require.NoError(t, encoder.String(".{12}"))
+	require.NoError(t, encoder.String("be at least 12 characters long"))
+	require.NoError(t, encoder.UInt64(42)) // nonce
+	require.NoError(t, encoder.Flush())
+	var hello ServerHandshake
+	require.NoError(t, hello.Decode(decoder))
+	assert.Zero(t, buf.Len())
+	assert.Equal(t, "Europe/Amsterdam", hello.Timezone.String())
+	assert.Equal(t, uint64(2), hello.Version.Patch)
+}
+
+func TestStringSettingsRevision(t *testing.T) {
+	setting := Settings{{Key: "network_compression_method", Value: "ZSTD"}}
+	{
+		_, encoder, _ := newCodec()
+		assert.Error(t, setting.Encode(encoder, DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS-1))
+	}
+	{
+		_, encoder, _ := newCodec()
+		assert.NoError(t, setting.Encode(encoder, DBMS_MIN_REVISION_WITH_SETTINGS_SERIALIZED_AS_STRINGS))
+	}
+}
@@ -18,16 +18,32 @@
 package proto
 
 import (
+	"bufio"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/supresu/clickhouse-go/v2/lib/binary"
 )
 
+// TableColumns is sent by the server on INSERT, First is the name of the external table
+// (empty for the table of the query) and Second the text of its columns description
 type TableColumns struct {
 	First  string
 	Second string
 }
 
+// ColumnDescription describes a column of the table of an INSERT
+type ColumnDescription struct {
+	Name              string
+	Type              string
+	DefaultKind       string // DEFAULT, MATERIALIZED, ALIAS or EPHEMERAL, empty when the column has no default expression
+	DefaultExpression string
+	Comment           string
+	Codec             string
+	TTL               string
+}
+
 func (t *TableColumns) Decode(decoder *binary.Decoder, revision uint64) (err error) {
 	if t.First, err = decoder.String(); err != nil {
 		return err
@@ -41,3 +57,129 @@ func (t *TableColumns) Decode(decoder *binary.Decoder, revision uint64) (err err
 func (t *TableColumns) String() string {
 	return fmt.Sprintf("first=%s, second=%s", t.First, t.Second)
 }
+
+// Columns parses the columns description written by the server, one column per line:
+//
+//	columns format version: 1
+//	2 columns:
+//	`id` UInt64
+//	`name` String\tDEFAULT\t'none'\tCOMMENT 'the name'
+func (t *TableColumns) Columns() ([]ColumnDescription, error) {
+	var (
+		scanner = bufio.NewScanner(strings.NewReader(t.Second))
+		count   int
+	)
+	scanner.Buffer(nil, len(t.Second)+1)
+	if !scanner.Scan() || scanner.Text() != "columns format version: 1" {
+		return nil, fmt.Errorf("unsupported columns description %q", t.Second)
+	}
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("missing the columns count")
+	}
+	if _, err := fmt.Sscanf(scanner.Text(), "%d columns:", &count); err != nil {
+		return nil, fmt.Errorf("invalid columns count %q: %w", scanner.Text(), err)
+	}
+	columns := make([]ColumnDescription, 0, count)
+	for scanner.Scan() {
+		column, err := parseColumnDescription(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) != count {
+		return nil, fmt.Errorf("expected %d columns, got %d", count, len(columns))
+	}
+	return columns, nil
+}
+
+func parseColumnDescription(line string) (c ColumnDescription, err error) {
+	if !strings.HasPrefix(line, "`") {
+		return c, fmt.Errorf("invalid column description %q", line)
+	}
+	// the name is back quoted, the fields that follow are escaped and separated with tabs
+	end := 1
+	for ; end < len(line) && line[end] != '`'; end++ {
+		if line[end] == '\\' {
+			end++
+		}
+	}
+	if end >= len(line) || end+1 >= len(line) || line[end+1] != ' ' {
+		return c, fmt.Errorf("invalid column description %q", line)
+	}
+	c.Name = unescape(line[1:end])
+	fields := strings.Split(line[end+2:], "\t")
+	c.Type = unescape(fields[0])
+	for i := 1; i < len(fields); i++ {
+		switch field := fields[i]; {
+		case strings.HasPrefix(field, "COMMENT "):
+			comment := unescape(strings.TrimPrefix(field, "COMMENT "))
+			if c.Comment, err = unquote(comment); err != nil {
+				return c, fmt.Errorf("invalid comment %q: %w", comment, err)
+			}
+		case strings.HasPrefix(field, "CODEC("):
+			c.Codec = unescape(field)
+		case strings.HasPrefix(field, "TTL "):
+			c.TTL = unescape(strings.TrimPrefix(field, "TTL "))
+		case i+1 < len(fields):
+			c.DefaultKind, c.DefaultExpression = field, unescape(fields[i+1])
+			i++
+		default:
+			return c, fmt.Errorf("invalid column description %q", line)
+		}
+	}
+	return c, nil
+}
+
+// unescape reverses the escaping of writeEscapedString and writeBackQuotedString
+func unescape(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '0':
+			b.WriteByte(0)
+		case 'a':
+			b.WriteByte('\a')
+		case 'v':
+			b.WriteByte('\v')
+		case 'x':
+			if i+2 < len(s) {
+				if v, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+					b.WriteByte(byte(v))
+					i += 2
+					continue
+				}
+			}
+			b.WriteByte('x')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// unquote returns the value of a single quoted string literal
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", fmt.Errorf("not a string literal")
+	}
+	return unescape(s[1 : len(s)-1]), nil
+}
@@ -0,0 +1,55 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableColumns(t *testing.T) {
+	info := TableColumns{
+		Second: "columns format version: 1\n" +
+			"5 columns:\n" +
+			"`id` UInt64\n" +
+			"`name` String\tDEFAULT\t\\'none\\'\tCOMMENT \\'it\\\\\\'s the name\\'\n" +
+			"`upper name` String\tMATERIALIZED\tupper(name)\n" +
+			"`back\\`quote` LowCardinality(String)\tALIAS\tconcat(name, \\'\\\\t\\')\n" +
+			"`ts` DateTime\tCODEC(Delta(4), LZ4)\tTTL ts + toIntervalDay(1)\n",
+	}
+	columns, err := info.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []ColumnDescription{
+		{Name: "id", Type: "UInt64"},
+		{Name: "name", Type: "String", DefaultKind: "DEFAULT", DefaultExpression: "'none'", Comment: "it's the name"},
+		{Name: "upper name", Type: "String", DefaultKind: "MATERIALIZED", DefaultExpression: "upper(name)"},
+		{Name: "back`quote", Type: "LowCardinality(String)", DefaultKind: "ALIAS", DefaultExpression: `concat(name, '\t')`},
+		{Name: "ts", Type: "DateTime", Codec: "CODEC(Delta(4), LZ4)", TTL: "ts + toIntervalDay(1)"},
+	}, columns)
+	for _, invalid := range []string{
+		"",
+		"columns format version: 2\n0 columns:\n",
+		"columns format version: 1\n2 columns:\n`id` UInt64\n",
+		"columns format version: 1\n1 columns:\nid UInt64\n",
+	} {
+		_, err := (&TableColumns{Second: invalid}).Columns()
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,179 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"sync"
+	"time"
+)
+
+// QueryStats accumulates what the server reports about a query given WithQueryStats: the progress increments,
+// the profile info and the profile events. It is filled while the query runs and is safe for concurrent use.
+// A retried query starts over.
+type QueryStats struct {
+	mu          sync.Mutex
+	queryID     string
+	start       time.Time
+	end         time.Time
+	progress    Progress
+	profileInfo ProfileInfo
+	events      map[string]int64
+}
+
+// QueryID is the ID of the query on the server, a native query without WithQueryID is given a random one
+func (s *QueryStats) QueryID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queryID
+}
+
+// Progress returns the sum of the progress increments
+func (s *QueryStats) Progress() Progress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.progress
+}
+
+// ProfileInfo returns the profile info, it is sent with the last block of a SELECT
+func (s *QueryStats) ProfileInfo() ProfileInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profileInfo
+}
+
+// ProfileEvents returns the profile events by name, the increments are summed and gauges keep their last value.
+// The server only sends them over the native protocol.
+func (s *QueryStats) ProfileEvents() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := make(map[string]int64, len(s.events))
+	for name, v := range s.events {
+		events[name] = v
+	}
+	return events
+}
+
+// Done reports whether the query has finished
+func (s *QueryStats) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.end.IsZero()
+}
+
+// Elapsed is the time since the query was sent, up to its end
+func (s *QueryStats) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.elapsed()
+}
+
+func (s *QueryStats) elapsed() time.Duration {
+	switch {
+	case s.start.IsZero():
+		return 0
+	case s.end.IsZero():
+		return time.Since(s.start)
+	}
+	return s.end.Sub(s.start)
+}
+
+// ETA estimates the time left from the rate of the rows read so far and the rows to read in total,
+// it is 0 when the query is done or the total is unknown
+func (s *QueryStats) ETA() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.end.IsZero() || s.progress.Rows == 0 || s.progress.TotalRows <= s.progress.Rows {
+		return 0
+	}
+	left := float64(s.progress.TotalRows-s.progress.Rows) / float64(s.progress.Rows)
+	return time.Duration(float64(s.elapsed()) * left)
+}
+
+// begin resets the stats of a query that is sent, a nil QueryStats ignores the calls
+func (s *QueryStats) begin(queryID string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queryID, s.start, s.end = queryID, time.Now(), time.Time{}
+	s.progress, s.profileInfo, s.events = Progress{}, ProfileInfo{}, nil
+}
+
+func (s *QueryStats) setQueryID(queryID string) {
+	if s == nil || len(queryID) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queryID = queryID
+}
+
+func (s *QueryStats) finish() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.start.IsZero() && s.end.IsZero() {
+		s.end = time.Now()
+	}
+}
+
+func (s *QueryStats) addProgress(p *Progress) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress.Rows += p.Rows
+	s.progress.Bytes += p.Bytes
+	s.progress.TotalRows += p.TotalRows
+	s.progress.TotalBytes += p.TotalBytes
+	s.progress.WroteRows += p.WroteRows
+	s.progress.WroteBytes += p.WroteBytes
+	s.progress.Elapsed += p.Elapsed
+}
+
+func (s *QueryStats) setProfileInfo(p *ProfileInfo) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profileInfo = *p
+}
+
+func (s *QueryStats) addProfileEvents(events []ProfileEvent) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.events == nil {
+		s.events = make(map[string]int64)
+	}
+	for _, e := range events {
+		switch e.Type {
+		case "gauge":
+			s.events[e.Name] = e.Value
+		default:
+			s.events[e.Name] += e.Value
+		}
+	}
+}
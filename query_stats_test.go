@@ -0,0 +1,98 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryStats(t *testing.T) {
+	var (
+		stats   QueryStats
+		options = QueryOptions{stats: &stats}
+		on      = options.onProcess()
+	)
+	assert.Zero(t, stats.Elapsed())
+	stats.begin("query_id")
+	on.progress(&Progress{Rows: 10, Bytes: 80, TotalRows: 40})
+	on.progress(&Progress{Rows: 10, Bytes: 80})
+	on.profileInfo(&ProfileInfo{Rows: 20, Blocks: 2})
+	on.profileEvents([]ProfileEvent{
+		{Type: "increment", Name: "SelectedRows", Value: 10},
+		{Type: "gauge", Name: "MemoryTrackerUsage", Value: 100},
+	})
+	on.profileEvents([]ProfileEvent{
+		{Type: "increment", Name: "SelectedRows", Value: 10},
+		{Type: "gauge", Name: "MemoryTrackerUsage", Value: 50},
+	})
+	assert.Equal(t, "query_id", stats.QueryID())
+	progress := stats.Progress()
+	assert.Equal(t, uint64(20), progress.Rows)
+	assert.Equal(t, uint64(160), progress.Bytes)
+	assert.Equal(t, uint64(40), progress.TotalRows)
+	assert.Equal(t, uint64(2), stats.ProfileInfo().Blocks)
+	assert.Equal(t, map[string]int64{"SelectedRows": 20, "MemoryTrackerUsage": 50}, stats.ProfileEvents())
+	{
+		// half of the rows were read, so the rest should take about as long
+		stats.mu.Lock()
+		stats.start = time.Now().Add(-time.Second)
+		stats.mu.Unlock()
+		eta := stats.ETA()
+		assert.Greater(t, int64(eta), int64(900*time.Millisecond))
+		assert.Less(t, int64(eta), int64(2*time.Second))
+	}
+	assert.False(t, stats.Done())
+	on.stats.finish()
+	assert.True(t, stats.Done())
+	assert.Zero(t, stats.ETA())
+	elapsed := stats.Elapsed()
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, elapsed, stats.Elapsed())
+
+	stats.begin("retry")
+	assert.Zero(t, stats.Progress())
+	assert.Empty(t, stats.ProfileEvents())
+	assert.False(t, stats.Done())
+}
+
+func TestHTTPQueryStats(t *testing.T) {
+	_, conn := openHTTP(t, nil)
+	var (
+		stats QueryStats
+		ctx   = Context(context.Background(), WithQueryStats(&stats))
+	)
+	rows, err := conn.Query(ctx, "SELECT number")
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	require.NoError(t, rows.Close())
+	assert.Equal(t, "stand-in", stats.QueryID())
+	assert.Equal(t, uint64(3), stats.Progress().Rows)
+	assert.Equal(t, uint64(3), stats.Progress().TotalRows)
+	assert.Eventually(t, stats.Done, time.Second, time.Millisecond)
+
+	ctx = Context(context.Background(), WithQueryStats(&stats), WithQueryID("my_query"))
+	assert.ErrorIs(t, conn.QueryRow(ctx, "SELECT * FROM unknown").Scan(), ErrTableNotFound)
+	assert.Equal(t, "my_query", stats.QueryID())
+	assert.True(t, stats.Done())
+}
@@ -53,17 +53,9 @@ func (m *structMap) Map(op string, columns []string, s interface{}, ptr bool) ([
 	}
 
 	var (
-		index  map[string][]int
+		index  = m.index(t)
 		values = make([]interface{}, 0, len(columns))
 	)
-
-	switch idx, found := m.cache.Load(t); {
-	case found:
-		index = idx.(map[string][]int)
-	default:
-		index = structIdx(t)
-		m.cache.Store(t, index)
-	}
 	for _, name := range columns {
 		idx, found := index[name]
 		if !found {
@@ -82,6 +74,24 @@ func (m *structMap) Map(op string, columns []string, s interface{}, ptr bool) ([
 	return values, nil
 }
 
+func (m *structMap) index(t reflect.Type) map[string][]int {
+	if idx, found := m.cache.Load(t); found {
+		return idx.(map[string][]int)
+	}
+	index := structIdx(t)
+	m.cache.Store(t, index)
+	return index
+}
+
+// fields returns the column names of the struct pointed to by s, ok is false when s is not a struct pointer
+func (m *structMap) fields(s interface{}) (_ map[string][]int, ok bool) {
+	t := reflect.TypeOf(s)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	return m.index(t.Elem()), true
+}
+
 func structIdx(t reflect.Type) map[string][]int {
 	fields := make(map[string][]int)
 	for i := 0; i < t.NumField(); i++ {
@@ -0,0 +1,138 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supresu/clickhouse-go/v2"
+	"github.com/supresu/clickhouse-go/v2/lib/column"
+)
+
+func TestAggregateFunction(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		conn, err = clickhouse.Open(&clickhouse.Options{
+			Addr: []string{"127.0.0.1:9000"},
+			Auth: clickhouse.Auth{
+				Database: "default",
+				Username: "default",
+				Password: "",
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+		})
+	)
+	if assert.NoError(t, err) {
+		if err := checkMinServerVersion(conn, 21, 1); err != nil {
+			t.Skip(err.Error())
+			return
+		}
+		const ddl = `
+		CREATE TABLE test_aggregate_function (
+			  Col1 AggregateFunction(count)
+			, Col2 AggregateFunction(sum, UInt64)
+			, Col3 AggregateFunction(avg, Float64)
+			, Col4 AggregateFunction(max, String)
+			, Col5 AggregateFunction(uniqExact, String)
+			, Col6 AggregateFunction(uniq, UInt64)
+			, Col7 AggregateFunction(quantiles(0.5, 0.9), Float64)
+		) Engine Memory
+		`
+		defer func() {
+			conn.Exec(ctx, "DROP TABLE test_aggregate_function")
+		}()
+		const query = `
+		SELECT
+			  countState()
+			, sumState(number)
+			, avgState(toFloat64(number))
+			, maxState(toString(number))
+			, uniqExactState(toString(number % 3))
+			, uniqState(number)
+			, quantilesState(0.5, 0.9)(toFloat64(number))
+		FROM numbers(10)
+		`
+		states := make([]column.AggregateState, 7)
+		dest := make([]interface{}, 0, len(states))
+		for i := range states {
+			dest = append(dest, &states[i])
+		}
+		if err := conn.QueryRow(ctx, query).Scan(dest...); !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, uint64(10), states[0].Value)
+		assert.Equal(t, uint64(45), states[1].Value)
+		assert.Equal(t, column.AvgState{Numerator: float64(45), Denominator: 10}, states[2].Value)
+		assert.Equal(t, "9", states[3].Value)
+		assert.Equal(t, uint64(3), states[4].Value)
+		if err := conn.Exec(ctx, ddl); assert.NoError(t, err) {
+			if batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_aggregate_function"); assert.NoError(t, err) {
+				values := make([]interface{}, 0, len(states))
+				for _, state := range states {
+					values = append(values, state.State)
+				}
+				if err := batch.Append(values...); !assert.NoError(t, err) {
+					return
+				}
+				if assert.NoError(t, batch.Send()) {
+					var result struct {
+						Count     uint64
+						Sum       uint64
+						Avg       float64
+						Max       string
+						UniqExact uint64
+						Uniq      uint64
+						Quantiles []float64
+					}
+					const query = `
+					SELECT
+						  finalizeAggregation(Col1)
+						, finalizeAggregation(Col2)
+						, finalizeAggregation(Col3)
+						, finalizeAggregation(Col4)
+						, finalizeAggregation(Col5)
+						, finalizeAggregation(Col6)
+						, finalizeAggregation(Col7)
+					FROM test_aggregate_function
+					`
+					err := conn.QueryRow(ctx, query).Scan(
+						&result.Count,
+						&result.Sum,
+						&result.Avg,
+						&result.Max,
+						&result.UniqExact,
+						&result.Uniq,
+						&result.Quantiles,
+					)
+					if assert.NoError(t, err) {
+						assert.Equal(t, uint64(10), result.Count)
+						assert.Equal(t, uint64(45), result.Sum)
+						assert.Equal(t, 4.5, result.Avg)
+						assert.Equal(t, "9", result.Max)
+						assert.Equal(t, uint64(3), result.UniqExact)
+						assert.Equal(t, uint64(10), result.Uniq)
+						assert.Len(t, result.Quantiles, 2)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestBatchColumns(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		conn, err = clickhouse.Open(&clickhouse.Options{
+			Addr: []string{"127.0.0.1:9000"},
+			Auth: clickhouse.Auth{
+				Database: "default",
+				Username: "default",
+				Password: "",
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+		})
+	)
+	require.NoError(t, err)
+	const ddl = `
+		CREATE TABLE test_batch_columns (
+			  ID        UInt64
+			, Name      String
+			, Note      String DEFAULT concat('note ', toString(ID)) COMMENT 'a note'
+			, UpperName String MATERIALIZED upper(Name)
+			, NameAlias String ALIAS Name
+		) Engine Memory
+	`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE test_batch_columns")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_batch_columns")
+	require.NoError(t, err)
+	if columns := batch.Columns(); assert.Len(t, columns, 5) {
+		assert.Equal(t, clickhouse.ColumnDescription{
+			Name:              "Note",
+			Type:              "String",
+			DefaultKind:       "DEFAULT",
+			DefaultExpression: "concat('note ', toString(ID))",
+			Comment:           "a note",
+		}, columns[2])
+		assert.Equal(t, "MATERIALIZED", columns[3].DefaultKind)
+		assert.Equal(t, "ALIAS", columns[4].DefaultKind)
+	}
+	type row struct {
+		ID   uint64
+		Name string
+	}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, batch.AppendStruct(&row{ID: uint64(i), Name: "name"}))
+	}
+	require.NoError(t, batch.Send())
+	var (
+		count     uint64
+		note      string
+		upperName string
+	)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT count() FROM test_batch_columns").Scan(&count))
+	assert.Equal(t, uint64(10), count)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT Note, UpperName FROM test_batch_columns WHERE ID = 3").Scan(&note, &upperName))
+	assert.Equal(t, "note 3", note)
+	assert.Equal(t, "NAME", upperName)
+	// a struct with every column does not start the INSERT again
+	batch, err = conn.PrepareBatch(ctx, "INSERT INTO test_batch_columns")
+	require.NoError(t, err)
+	require.NoError(t, batch.AppendStruct(&struct {
+		row
+		Note string
+	}{row: row{ID: 100, Name: "name"}, Note: "explicit"}))
+	require.NoError(t, batch.Send())
+	require.NoError(t, conn.QueryRow(ctx, "SELECT Note FROM test_batch_columns WHERE ID = 100").Scan(&note))
+	assert.Equal(t, "explicit", note)
+}
@@ -0,0 +1,70 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestConnMaxIdleTime(t *testing.T) {
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr: []string{"127.0.0.1:9000"},
+		Auth: clickhouse.Auth{
+			Database: "default",
+			Username: "default",
+			Password: "",
+		},
+		ConnMaxIdleTime: 500 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.Ping(context.Background()))
+	assert.Equal(t, 1, conn.Stats().Idle)
+	time.Sleep(time.Second)
+	stats := conn.Stats()
+	assert.Equal(t, 0, stats.Idle)
+	assert.Equal(t, int64(1), stats.MaxIdleTimeClosed)
+}
+
+func TestConnPingInterval(t *testing.T) {
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr: []string{"127.0.0.1:9000"},
+		Auth: clickhouse.Auth{
+			Database: "default",
+			Username: "default",
+			Password: "",
+		},
+		ConnPingInterval: 200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, conn.Ping(context.Background()))
+	time.Sleep(time.Second)
+	stats := conn.Stats()
+	assert.Equal(t, 1, stats.Idle)
+	assert.True(t, stats.KeepAlivePings > 0)
+	assert.Equal(t, int64(0), stats.KeepAliveFailures)
+	require.NoError(t, conn.Close())
+	assert.Equal(t, 0, conn.Stats().Idle)
+	require.NoError(t, conn.Close())
+}
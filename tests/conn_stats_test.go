@@ -0,0 +1,62 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestConnStats(t *testing.T) {
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr: []string{"127.0.0.1:9000", "127.0.0.1:9999"},
+		Auth: clickhouse.Auth{
+			Database: "default",
+			Username: "default",
+			Password: "",
+		},
+		Compression: &clickhouse.Compression{
+			Method: clickhouse.CompressionLZ4,
+		},
+		ConnOpenStrategy: clickhouse.ConnOpenInOrder,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+	var count uint64
+	require.NoError(t, conn.QueryRow(ctx, "SELECT count() FROM (SELECT toString(number) FROM numbers(100000))").Scan(&count))
+	rows, err := conn.Query(ctx, "SELECT repeat('a', 100) FROM numbers(10000)")
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	require.NoError(t, rows.Close())
+	stats := conn.Stats()
+	if assert.Contains(t, stats.Dials, "127.0.0.1:9000") {
+		assert.Equal(t, int64(1), stats.Dials["127.0.0.1:9000"].Attempts)
+		assert.Equal(t, int64(0), stats.Dials["127.0.0.1:9000"].Failures)
+	}
+	assert.NotContains(t, stats.Dials, "127.0.0.1:9999")
+	assert.Greater(t, stats.BytesRead, int64(0))
+	assert.Greater(t, stats.BytesWritten, int64(0))
+	assert.Greater(t, stats.CompressionRatio(), float64(1))
+	assert.Equal(t, int64(0), stats.ErrorClosed)
+}
@@ -0,0 +1,81 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestWithExtremes(t *testing.T) {
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr: []string{"127.0.0.1:9000"},
+		Auth: clickhouse.Auth{
+			Database: "default",
+			Username: "default",
+			Password: "",
+		},
+		Compression: &clickhouse.Compression{
+			Method: clickhouse.CompressionLZ4,
+		},
+	})
+	require.NoError(t, err)
+	const query = `
+	SELECT
+		number AS n
+		, COUNT()
+	FROM (
+		SELECT number FROM system.numbers LIMIT 100
+	) GROUP BY n WITH TOTALS
+	`
+	ctx := clickhouse.Context(context.Background(), clickhouse.WithSettings(clickhouse.Settings{
+		"extremes": 1,
+	}))
+	rows, err := conn.Query(ctx, query)
+	require.NoError(t, err)
+	var count int
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, 100, count)
+	var (
+		n, totals              uint64
+		minN, minC, maxN, maxC uint64
+	)
+	if assert.NoError(t, rows.Totals(&n, &totals)) {
+		assert.Equal(t, uint64(100), totals)
+	}
+	if assert.NoError(t, rows.Extremes(&minN, &minC, &maxN, &maxC)) {
+		assert.Equal(t, uint64(0), minN)
+		assert.Equal(t, uint64(99), maxN)
+		assert.Equal(t, uint64(1), minC)
+		assert.Equal(t, uint64(1), maxC)
+	}
+
+	rows, err = conn.Query(context.Background(), "SELECT 1")
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	assert.Equal(t, sql.ErrNoRows, rows.Extremes(&minN, &maxN))
+}
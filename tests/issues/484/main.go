@@ -37,7 +37,7 @@ func main() {
 		},
 		DialTimeout: 5 * time.Second,
 		Compression: &clickhouse.Compression{
-			clickhouse.CompressionLZ4,
+			Method: clickhouse.CompressionLZ4,
 		},
 		//Debug: true,
 	})
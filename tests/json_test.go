@@ -0,0 +1,152 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+type jsonRepository struct {
+	Name  string   `ch:"name"`
+	Stars uint32   `ch:"stars"`
+	Tags  []string `ch:"tags"`
+	Owner struct {
+		Login string `ch:"login"`
+	} `ch:"owner"`
+}
+
+func TestObjectJSON(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		conn, err = clickhouse.Open(&clickhouse.Options{
+			Addr: []string{"127.0.0.1:9000"},
+			Auth: clickhouse.Auth{
+				Database: "default",
+				Username: "default",
+				Password: "",
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+			Settings: clickhouse.Settings{
+				"allow_experimental_object_type": 1,
+			},
+		})
+	)
+	if assert.NoError(t, err) {
+		if err := checkMinServerVersion(conn, 22, 3); err != nil {
+			t.Skip(err.Error())
+			return
+		}
+		const ddl = `
+		CREATE TABLE test_json (
+			  ID   UInt32
+			, Col1 Object('json')
+		) Engine Memory
+		`
+		defer func() {
+			conn.Exec(ctx, "DROP TABLE test_json")
+		}()
+		if err := conn.Exec(ctx, ddl); assert.NoError(t, err) {
+			if batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_json"); assert.NoError(t, err) {
+				var repository jsonRepository
+				repository.Name, repository.Stars, repository.Tags, repository.Owner.Login = "clickhouse-go", 100, []string{"go", "sql"}, "ClickHouse"
+				assert.NoError(t, batch.Append(uint32(1), repository))
+				assert.NoError(t, batch.Append(uint32(2), map[string]interface{}{"name": "clickhouse", "stars": 1000}))
+				assert.NoError(t, batch.Append(uint32(3), json.RawMessage(`{"name": "ch-go", "owner": {"login": "go-faster"}}`)))
+				if assert.NoError(t, batch.Send()) {
+					var scanned jsonRepository
+					if err := conn.QueryRow(ctx, "SELECT Col1 FROM test_json WHERE ID = 1").Scan(&scanned); assert.NoError(t, err) {
+						assert.Equal(t, repository, scanned)
+					}
+					var row map[string]interface{}
+					if err := conn.QueryRow(ctx, "SELECT Col1 FROM test_json WHERE ID = 2").Scan(&row); assert.NoError(t, err) {
+						assert.Equal(t, "clickhouse", row["name"])
+						assert.EqualValues(t, 1000, row["stars"])
+					}
+					var text json.RawMessage
+					if err := conn.QueryRow(ctx, "SELECT Col1 FROM test_json WHERE ID = 3").Scan(&text); assert.NoError(t, err) {
+						assert.Contains(t, string(text), `"owner":{"login":"go-faster"}`)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		conn, err = clickhouse.Open(&clickhouse.Options{
+			Addr: []string{"127.0.0.1:9000"},
+			Auth: clickhouse.Auth{
+				Database: "default",
+				Username: "default",
+				Password: "",
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+			Settings: clickhouse.Settings{
+				"allow_experimental_json_type": 1,
+			},
+		})
+	)
+	if assert.NoError(t, err) {
+		if err := checkMinServerVersion(conn, 24, 8); err != nil {
+			t.Skip(err.Error())
+			return
+		}
+		const ddl = `
+		CREATE TABLE test_new_json (
+			  ID   UInt32
+			, Col1 JSON(max_dynamic_paths = 2, stars UInt32, SKIP secret)
+		) Engine Memory
+		`
+		defer func() {
+			conn.Exec(ctx, "DROP TABLE test_new_json")
+		}()
+		if err := conn.Exec(ctx, ddl); assert.NoError(t, err) {
+			if batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_new_json"); assert.NoError(t, err) {
+				var repository jsonRepository
+				repository.Name, repository.Stars, repository.Tags, repository.Owner.Login = "clickhouse-go", 100, []string{"go", "sql"}, "ClickHouse"
+				assert.NoError(t, batch.Append(uint32(1), repository))
+				assert.NoError(t, batch.Append(uint32(2), map[string]interface{}{"name": "clickhouse", "stars": 1000, "secret": "s"}))
+				assert.NoError(t, batch.Append(uint32(3), json.RawMessage(`{"name": "ch-go", "owner": {"login": "go-faster"}}`)))
+				if assert.NoError(t, batch.Send()) {
+					var scanned jsonRepository
+					if err := conn.QueryRow(ctx, "SELECT Col1 FROM test_new_json WHERE ID = 1").Scan(&scanned); assert.NoError(t, err) {
+						assert.Equal(t, repository, scanned)
+					}
+					var row map[string]interface{}
+					if err := conn.QueryRow(ctx, "SELECT Col1 FROM test_new_json WHERE ID = 2").Scan(&row); assert.NoError(t, err) {
+						assert.Equal(t, map[string]interface{}{"name": "clickhouse", "stars": uint32(1000)}, row)
+					}
+					var text json.RawMessage
+					if err := conn.QueryRow(ctx, "SELECT Col1 FROM test_new_json WHERE ID = 3").Scan(&text); assert.NoError(t, err) {
+						assert.JSONEq(t, `{"name": "ch-go", "owner": {"login": "go-faster"}, "stars": 0}`, string(text))
+					}
+				}
+			}
+		}
+	}
+}
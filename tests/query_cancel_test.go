@@ -0,0 +1,68 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tests
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestQueryCancelReusesConnection(t *testing.T) {
+	var (
+		dialCount int32
+		conn, err = clickhouse.Open(&clickhouse.Options{
+			Addr: []string{"127.0.0.1:9000"},
+			Auth: clickhouse.Auth{
+				Database: "default",
+				Username: "default",
+				Password: "",
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+			MaxOpenConns: 1,
+			DialContext: func(ctx context.Context, addr string) (net.Conn, error) {
+				atomic.AddInt32(&dialCount, 1)
+				var d net.Dialer
+				return d.DialContext(ctx, "tcp", addr)
+			},
+		})
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, conn.Ping(ctx))
+	for i := 0; i < 3; i++ {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(200*time.Millisecond, cancel)
+		start := time.Now()
+		var sum uint64
+		err := conn.QueryRow(cancelCtx, "SELECT sum(number) FROM numbers(1000000000000)").Scan(&sum)
+		if assert.Error(t, err) {
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+		assert.True(t, time.Since(start) < time.Second)
+		require.NoError(t, conn.Ping(ctx))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&dialCount))
+}
@@ -0,0 +1,81 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestQueryStats(t *testing.T) {
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr: []string{"127.0.0.1:9000"},
+		Auth: clickhouse.Auth{
+			Database: "default",
+			Username: "default",
+			Password: "",
+		},
+		Compression: &clickhouse.Compression{
+			Method: clickhouse.CompressionLZ4,
+		},
+	})
+	require.NoError(t, err)
+	var (
+		stats clickhouse.QueryStats
+		ctx   = clickhouse.Context(context.Background(), clickhouse.WithQueryStats(&stats))
+	)
+	rows, err := conn.Query(ctx, "SELECT number FROM system.numbers LIMIT 100000")
+	require.NoError(t, err)
+	var count int
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, 100000, count)
+	assert.True(t, stats.Done())
+	assert.NotEmpty(t, stats.QueryID())
+	assert.GreaterOrEqual(t, stats.Progress().Rows, uint64(100000))
+	assert.Equal(t, uint64(100000), stats.ProfileInfo().Rows)
+	assert.Greater(t, stats.ProfileEvents()["SelectedRows"], int64(0))
+	assert.Greater(t, int64(stats.Elapsed()), int64(0))
+	assert.Zero(t, stats.ETA())
+
+	var queryID string
+	require.NoError(t, conn.Exec(context.Background(), "SYSTEM FLUSH LOGS"))
+	require.NoError(t, conn.QueryRow(context.Background(), "SELECT query_id FROM system.query_log WHERE query_id = $1 LIMIT 1", stats.QueryID()).Scan(&queryID))
+	assert.Equal(t, stats.QueryID(), queryID)
+}
+
+func TestStdQueryStats(t *testing.T) {
+	conn, err := sql.Open("clickhouse", "clickhouse://127.0.0.1:9000")
+	require.NoError(t, err)
+	var (
+		stats clickhouse.QueryStats
+		ctx   = clickhouse.Context(context.Background(), clickhouse.WithQueryStats(&stats))
+		sum   uint64
+	)
+	require.NoError(t, conn.QueryRowContext(ctx, "SELECT sum(number) FROM numbers(1000)").Scan(&sum))
+	assert.Equal(t, uint64(499500), sum)
+	assert.GreaterOrEqual(t, stats.Progress().Rows, uint64(1000))
+	assert.NotEmpty(t, stats.QueryID())
+}
@@ -0,0 +1,94 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestServerParams(t *testing.T) {
+	for name, protocol := range map[string]clickhouse.Protocol{"native": clickhouse.Native, "http": clickhouse.HTTP} {
+		t.Run(name, func(t *testing.T) {
+			addr := "127.0.0.1:9000"
+			if protocol == clickhouse.HTTP {
+				addr = "127.0.0.1:8123"
+			}
+			conn, err := clickhouse.Open(&clickhouse.Options{
+				Protocol: protocol,
+				Addr:     []string{addr},
+				Auth: clickhouse.Auth{
+					Database: "default",
+					Username: "default",
+					Password: "",
+				},
+			})
+			require.NoError(t, err)
+			var (
+				ts  = time.Date(2022, 5, 10, 12, 30, 15, 123000000, time.UTC)
+				ctx = clickhouse.Context(context.Background(), clickhouse.WithParameters(clickhouse.Parameters{
+					"ts": ts,
+				}))
+				s      string
+				names  []string
+				m      map[string]uint64
+				tuple  []interface{}
+				d      decimal.Decimal
+				actual time.Time
+			)
+			require.NoError(t, conn.QueryRow(ctx, `
+				SELECT
+					{s:String}
+					, {names:Array(String)}
+					, {m:Map(String, UInt64)}
+					, {tuple:Tuple(String, Nullable(UInt8))}
+					, {d:Decimal(9, 2)}
+					, {ts:DateTime64(3, 'UTC')}
+				`,
+				clickhouse.Named("s", "it's a\ttab \\ and\nnewline"),
+				clickhouse.Named("names", []string{"a", "it's"}),
+				clickhouse.Named("m", map[string]uint64{"a": 1}),
+				clickhouse.Named("tuple", []interface{}{"b", nil}),
+				clickhouse.Named("d", decimal.RequireFromString("3.14")),
+			).Scan(&s, &names, &m, &tuple, &d, &actual))
+			assert.Equal(t, "it's a\ttab \\ and\nnewline", s)
+			assert.Equal(t, []string{"a", "it's"}, names)
+			assert.Equal(t, map[string]uint64{"a": 1}, m)
+			if assert.Len(t, tuple, 2) {
+				assert.Equal(t, "b", tuple[0])
+			}
+			assert.Equal(t, "3.14", d.StringFixed(2))
+			assert.Equal(t, ts, actual.UTC())
+		})
+	}
+}
+
+func TestStdServerParams(t *testing.T) {
+	conn, err := sql.Open("clickhouse", "clickhouse://127.0.0.1:9000")
+	require.NoError(t, err)
+	var n uint64
+	require.NoError(t, conn.QueryRow("SELECT {n:UInt64} + 1", sql.Named("n", 41)).Scan(&n))
+	assert.Equal(t, uint64(42), n)
+}
@@ -0,0 +1,83 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestSession(t *testing.T) {
+	var (
+		dialCount int32
+		conn, err = clickhouse.Open(&clickhouse.Options{
+			Addr: []string{"127.0.0.1:9000"},
+			Auth: clickhouse.Auth{
+				Database: "default",
+				Username: "default",
+				Password: "",
+			},
+			MaxOpenConns: 1,
+			DialContext: func(ctx context.Context, addr string) (net.Conn, error) {
+				atomic.AddInt32(&dialCount, 1)
+				var d net.Dialer
+				return d.DialContext(ctx, "tcp", addr)
+			},
+		})
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+	var maxThreads string
+	require.NoError(t, conn.QueryRow(ctx, "SELECT value FROM system.settings WHERE name = 'max_threads'").Scan(&maxThreads))
+	session, err := conn.Acquire(ctx)
+	require.NoError(t, err)
+	require.NoError(t, session.Exec(ctx, "CREATE TEMPORARY TABLE test_session (id UInt64)"))
+	require.NoError(t, session.Exec(ctx, "INSERT INTO test_session SELECT number FROM numbers(10)"))
+	require.NoError(t, session.Exec(ctx, "SET max_threads = 3, max_block_size = 100"))
+	require.NoError(t, session.Exec(ctx, "USE system"))
+	var (
+		count    uint64
+		database string
+		value    string
+	)
+	require.NoError(t, session.QueryRow(ctx, "SELECT count() FROM test_session").Scan(&count))
+	assert.Equal(t, uint64(10), count)
+	require.NoError(t, session.QueryRow(ctx, "SELECT currentDatabase()").Scan(&database))
+	assert.Equal(t, "system", database)
+	require.NoError(t, session.QueryRow(ctx, "SELECT value FROM settings WHERE name = 'max_threads'").Scan(&value))
+	assert.Equal(t, "3", value)
+	session.SetSettings(clickhouse.Settings{"max_block_size": 42})
+	require.NoError(t, session.QueryRow(ctx, "SELECT getSetting('max_block_size')").Scan(&count))
+	assert.Equal(t, uint64(42), count)
+	assert.ErrorIs(t, conn.Ping(clickhouse.Context(ctx, clickhouse.WithRetry(clickhouse.RetryPolicy{}))), clickhouse.ErrAcquireConnTimeout, "the session holds the only connection")
+	require.NoError(t, session.Close())
+	// the same connection is back in the pool with its state reset
+	require.NoError(t, conn.QueryRow(ctx, "SELECT currentDatabase()").Scan(&database))
+	assert.Equal(t, "default", database)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT value FROM system.settings WHERE name = 'max_threads'").Scan(&value))
+	assert.Equal(t, maxThreads, value)
+	assert.Error(t, conn.Exec(ctx, "SELECT * FROM test_session"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&dialCount))
+}
@@ -0,0 +1,69 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build go1.21
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supresu/clickhouse-go/v2"
+)
+
+func TestSlogServerLogs(t *testing.T) {
+	var (
+		query  bytes.Buffer
+		global bytes.Buffer
+	)
+	conn, err := clickhouse.Open(&clickhouse.Options{
+		Addr: []string{"127.0.0.1:9000"},
+		Auth: clickhouse.Auth{
+			Database: "default",
+			Username: "default",
+			Password: "",
+		},
+		Compression: &clickhouse.Compression{
+			Method: clickhouse.CompressionLZ4,
+		},
+		ServerLogs: clickhouse.SlogLogs(slog.New(slog.NewTextHandler(&global, nil)), slog.LevelDebug),
+	})
+	require.NoError(t, err)
+	var (
+		stats clickhouse.QueryStats
+		ctx   = clickhouse.Context(context.Background(),
+			clickhouse.WithQueryStats(&stats),
+			clickhouse.WithSlog(slog.New(slog.NewTextHandler(&query, &slog.HandlerOptions{Level: slog.LevelDebug - 4})), slog.LevelDebug-4),
+		)
+		count uint64
+	)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT count() FROM numbers(1000)").Scan(&count))
+	assert.Equal(t, uint64(1000), count)
+	assert.Contains(t, query.String(), "query_id="+stats.QueryID())
+	assert.Contains(t, query.String(), "level=DEBUG-4")
+	assert.Zero(t, global.Len(), "the query option replaces the global logs")
+
+	require.NoError(t, conn.QueryRow(context.Background(), "SELECT count() FROM numbers(1000)").Scan(&count))
+	assert.Contains(t, global.String(), "level=DEBUG")
+	assert.NotContains(t, global.String(), "level=DEBUG-4")
+	assert.Contains(t, global.String(), "thread_id=")
+}
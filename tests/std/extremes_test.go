@@ -0,0 +1,48 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package std
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdWithExtremes(t *testing.T) {
+	conn, err := sql.Open("clickhouse", "clickhouse://127.0.0.1:9000?extremes=1")
+	require.NoError(t, err)
+	rows, err := conn.Query("SELECT number FROM numbers(10)")
+	require.NoError(t, err)
+	var count int
+	for rows.Next() {
+		count++
+	}
+	assert.Equal(t, 10, count)
+	// there are no totals, the next result set holds the minimum and maximum rows
+	require.True(t, rows.NextResultSet())
+	var extremes []uint64
+	for rows.Next() {
+		var n uint64
+		require.NoError(t, rows.Scan(&n))
+		extremes = append(extremes, n)
+	}
+	assert.Equal(t, []uint64{0, 9}, extremes)
+	assert.False(t, rows.NextResultSet())
+}
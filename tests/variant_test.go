@@ -0,0 +1,104 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supresu/clickhouse-go/v2"
+	"github.com/supresu/clickhouse-go/v2/lib/column"
+)
+
+func TestVariant(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		conn, err = clickhouse.Open(&clickhouse.Options{
+			Addr: []string{"127.0.0.1:9000"},
+			Auth: clickhouse.Auth{
+				Database: "default",
+				Username: "default",
+				Password: "",
+			},
+			Compression: &clickhouse.Compression{
+				Method: clickhouse.CompressionLZ4,
+			},
+			Settings: clickhouse.Settings{
+				"allow_experimental_variant_type": 1,
+			},
+		})
+	)
+	if assert.NoError(t, err) {
+		if err := checkMinServerVersion(conn, 24, 1); err != nil {
+			t.Skip(err.Error())
+			return
+		}
+		const ddl = `
+		CREATE TABLE test_variant (
+			  ID   UInt32
+			, Col1 Variant(String, Int64, Array(String))
+		) Engine Memory
+		`
+		defer func() {
+			conn.Exec(ctx, "DROP TABLE test_variant")
+		}()
+		if err := conn.Exec(ctx, ddl); assert.NoError(t, err) {
+			if batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_variant"); assert.NoError(t, err) {
+				values := []interface{}{
+					"text",
+					int64(42),
+					[]string{"a", "b"},
+					nil,
+					column.VariantValue{Type: "String", Value: "set"},
+				}
+				for i, v := range values {
+					assert.NoError(t, batch.Append(uint32(i), v))
+				}
+				if assert.NoError(t, batch.Send()) {
+					if rows, err := conn.Query(ctx, "SELECT Col1, variantType(Col1) FROM test_variant ORDER BY ID"); assert.NoError(t, err) {
+						var scanned []column.VariantValue
+						for rows.Next() {
+							var (
+								value column.VariantValue
+								name  string
+							)
+							if assert.NoError(t, rows.Scan(&value, &name)) {
+								if value.Type != "" {
+									assert.Equal(t, string(value.Type), name)
+								}
+								scanned = append(scanned, value)
+							}
+						}
+						assert.NoError(t, rows.Err())
+						assert.Equal(t, []column.VariantValue{
+							{Type: "String", Value: "text"},
+							{Type: "Int64", Value: int64(42)},
+							{Type: "Array(String)", Value: []string{"a", "b"}},
+							{},
+							{Type: "String", Value: "set"},
+						}, scanned)
+					}
+					var n int64
+					if err := conn.QueryRow(ctx, "SELECT Col1 FROM test_variant WHERE ID = 1").Scan(&n); assert.NoError(t, err) {
+						assert.Equal(t, int64(42), n)
+					}
+				}
+			}
+		}
+	}
+}